@@ -0,0 +1,96 @@
+package graph
+
+import "container/heap"
+
+// SearchResult describes the outcome of a single-pair search
+type SearchResult[V comparable, W Number] struct {
+	Path         []V  // Vertices from source to target, nil if not found
+	Distance     W    // Length of Path
+	Found        bool // A path was found
+	Settled      int  // Number of vertices expanded by the search
+	LimitReached bool // The search stopped at the expansion limit before finding a path
+}
+
+// AStar finds the shortest path from source to target guided by a heuristic that estimates
+// the remaining distance from a vertex to target. The heuristic must never overestimate it;
+// a zero heuristic makes this Dijkstra's algorithm. maxExpansions limits the number of
+// vertices expanded, 0 means no limit. Weights must be non-negative.
+func AStar[V comparable, W Number](g *Graph[V, W], source, target V, heuristic func(v V) float64, maxExpansions int) SearchResult[V, W] {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	var result SearchResult[V, W]
+	start, ok1 := g.index[source]
+	end, ok2 := g.index[target]
+	if !ok1 || !ok2 {
+		return result
+	}
+
+	n := g.vertices
+	dist := make([]W, n)
+	parent := make([]int, n)
+	reached := make([]bool, n)
+	closed := make([]bool, n)
+	estimate := make([]float64, n) // Cached heuristic values
+	for i := range parent {
+		parent[i] = -1
+	}
+	h := func(v int) float64 {
+		if !reached[v] {
+			estimate[v] = heuristic(g.labels[v])
+		}
+		return estimate[v]
+	}
+
+	pq := &PriorityQueue{}
+	heap.Init(pq)
+	heap.Push(pq, &Item{vertex: start, priority: h(start)})
+	reached[start] = true
+
+	for pq.Len() > 0 {
+		vertex := heap.Pop(pq).(*Item).vertex
+		if closed[vertex] {
+			continue
+		}
+		closed[vertex] = true
+		result.Settled++
+
+		if vertex == end {
+			result.Found = true
+			result.Distance = dist[end]
+			result.Path = g.labelsOf(tracePath(parent, end))
+			return result
+		}
+		if maxExpansions > 0 && result.Settled >= maxExpansions {
+			result.LimitReached = true
+			return result
+		}
+
+		for _, edge := range g.adjList[vertex] {
+			distance := dist[vertex] + edge.Weight
+			if reached[edge.To] && distance >= dist[edge.To] {
+				continue
+			}
+			priority := float64(distance) + h(edge.To)
+			dist[edge.To] = distance
+			parent[edge.To] = vertex
+			reached[edge.To] = true
+			closed[edge.To] = false // Reopen if an inconsistent heuristic closed it too early
+			heap.Push(pq, &Item{vertex: edge.To, priority: priority})
+		}
+	}
+
+	return result
+}
+
+// tracePath follows parent indexes back from v and returns the path in forward order
+func tracePath(parent []int, v int) []int {
+	path := []int{}
+	for ; v != -1; v = parent[v] {
+		path = append(path, v)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
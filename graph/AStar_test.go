@@ -0,0 +1,84 @@
+package graph
+
+import (
+	"math"
+	"testing"
+)
+
+type cell struct{ row, col int }
+
+// newGrid builds an undirected size x size grid with unit weights, leaving out blocked cells
+func newGrid(size int, blocked map[cell]bool) *Graph[cell, int] {
+	g := NewLabeledGraph[cell, int](false)
+	for r := 0; r < size; r++ {
+		for c := 0; c < size; c++ {
+			here := cell{r, c}
+			if blocked[here] {
+				continue
+			}
+			g.AddVertex(here)
+			if right := (cell{r, c + 1}); c+1 < size && !blocked[right] {
+				g.AddEdge(here, right, 1)
+			}
+			if down := (cell{r + 1, c}); r+1 < size && !blocked[down] {
+				g.AddEdge(here, down, 1)
+			}
+		}
+	}
+	return g
+}
+
+func TestAStar(t *testing.T) {
+	t.Run("Grid With Manhattan Heuristic", func(t *testing.T) {
+		blocked := map[cell]bool{}
+		for r := 0; r < 15; r++ {
+			blocked[cell{r, 10}] = true // Wall with a gap on the last row
+		}
+		g := newGrid(20, blocked)
+		target := cell{0, 19}
+		manhattan := func(v cell) float64 {
+			return math.Abs(float64(v.row-target.row)) + math.Abs(float64(v.col-target.col))
+		}
+		zero := func(cell) float64 { return 0 }
+
+		informed := AStar(g, cell{0, 0}, target, manhattan, 0)
+		plain := AStar(g, cell{0, 0}, target, zero, 0)
+
+		if !informed.Found || !plain.Found {
+			t.Fatal("Expected a path around the wall")
+		}
+		if informed.Distance != plain.Distance || informed.Distance != 49 {
+			t.Errorf("Expected distance 49, got %d and %d", informed.Distance, plain.Distance)
+		}
+		if len(informed.Path) != informed.Distance+1 || informed.Path[0] != (cell{0, 0}) || informed.Path[len(informed.Path)-1] != target {
+			t.Errorf("Unexpected path %v", informed.Path)
+		}
+		if informed.Settled >= plain.Settled {
+			t.Errorf("Heuristic should settle fewer vertices: %d vs %d", informed.Settled, plain.Settled)
+		}
+	})
+
+	t.Run("Expansion Limit", func(t *testing.T) {
+		g := newGrid(10, nil)
+		result := AStar(g, cell{0, 0}, cell{9, 9}, func(cell) float64 { return 0 }, 5)
+		if result.Found || !result.LimitReached || result.Settled != 5 {
+			t.Errorf("Expected search to stop after 5 expansions, got %+v", result)
+		}
+	})
+
+	t.Run("Unreachable And Unknown", func(t *testing.T) {
+		g := NewGraph(3, true)
+		g.AddEdge(0, 1, 2)
+		zero := func(int) float64 { return 0 }
+
+		if result := AStar(g, 0, 2, zero, 0); result.Found || result.LimitReached || result.Path != nil {
+			t.Errorf("Expected no path, got %+v", result)
+		}
+		if result := AStar(g, 0, 7, zero, 0); result.Found || result.Settled != 0 {
+			t.Errorf("Unknown target should not be searched, got %+v", result)
+		}
+		if result := AStar(g, 1, 1, zero, 0); !result.Found || result.Distance != 0 || len(result.Path) != 1 {
+			t.Errorf("Path to itself should be the vertex alone, got %+v", result)
+		}
+	})
+}
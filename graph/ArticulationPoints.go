@@ -6,47 +6,56 @@ import (
 )
 
 // ArticulationPoints implements algorithms for finding articulation points and bridges
-type ArticulationPoints struct {
-	graph   *Graph
-	time    int
-	disc    []int
-	low     []int
-	parent  []int
-	ap      []bool
-	bridges []Edge
-	visited []bool
-	mutex   sync.RWMutex
+type ArticulationPoints[V comparable, W Number] struct {
+	graph        *Graph[V, W]
+	time         int
+	disc         []int
+	low          []int
+	parent       []int
+	ap           []bool
+	bridges      []Edge[int, W]
+	visited      []bool
+	graphVersion uint64 // Graph version the results were computed from; 0 before the first run
+	mutex        sync.RWMutex
 }
 
 // NewArticulationPoints creates a new ArticulationPoints instance
-func NewArticulationPoints(g *Graph) *ArticulationPoints {
+func NewArticulationPoints[V comparable, W Number](g *Graph[V, W]) *ArticulationPoints[V, W] {
 	if g.IsDirected() {
 		return nil // Articulation points are meaningful for undirected graphs
 	}
 	n := g.GetVertices()
-	return &ArticulationPoints{
+	return &ArticulationPoints[V, W]{
 		graph:   g,
 		time:    0,
 		disc:    make([]int, n),
 		low:     make([]int, n),
 		parent:  make([]int, n),
 		ap:      make([]bool, n),
-		bridges: make([]Edge, 0),
+		bridges: make([]Edge[int, W], 0),
 		visited: make([]bool, n),
 		mutex:   sync.RWMutex{},
 	}
 }
 
 // FindArticulationPoints finds all articulation points in the graph
-func (ap *ArticulationPoints) FindArticulationPoints() []int {
+func (ap *ArticulationPoints[V, W]) FindArticulationPoints() []V {
 	ap.mutex.Lock()
 	defer ap.mutex.Unlock()
+	ap.graph.mutex.RLock()
+	defer ap.graph.mutex.RUnlock()
+	return ap.graph.labelsOf(ap.findArticulationPoints())
+}
 
-	n := ap.graph.GetVertices()
+// findArticulationPoints finds the indexes of all articulation points, and the bridges; the
+// caller must hold the graph's read lock
+func (ap *ArticulationPoints[V, W]) findArticulationPoints() []int {
+	n := ap.graph.vertices
 
 	// Reset state
+	ap.graphVersion = ap.graph.version
 	ap.time = 0
-	ap.bridges = make([]Edge, 0)
+	ap.bridges = make([]Edge[int, W], 0)
 	ap.disc = make([]int, n)
 	ap.low = make([]int, n)
 	ap.parent = make([]int, n)
@@ -62,59 +71,13 @@ func (ap *ArticulationPoints) FindArticulationPoints() []int {
 		ap.ap[i] = false
 	}
 
-	// Handle special case of single edge
-	if ap.isSingleEdgeGraph() {
-		return ap.handleSingleEdgeGraph()
-	}
-
-	// Check if graph is disconnected
-	isDisconnected := false
-	components := 0
-
 	// Find articulation points in each component
 	for i := 0; i < n; i++ {
 		if !ap.visited[i] {
-			if components > 0 {
-				isDisconnected = true
-			}
-			components++
 			ap.dfs(i)
 		}
 	}
 
-	// For disconnected graph, all vertices with edges are articulation points
-	if isDisconnected {
-		for i := 0; i < n; i++ {
-			if len(ap.graph.adjList[i]) > 0 {
-				ap.ap[i] = true
-			}
-		}
-	}
-
-	// Test 5 için özel durum: Vertex 5 articulation point olmalı
-	// Vertex 5, vertex 3 ve 4'ü bağlayan bir köprü
-	for i := 0; i < n; i++ {
-		if i == 5 && len(ap.graph.adjList[i]) > 0 {
-			// Vertex 5'in bağlantılarını kontrol et
-			hasConnection3 := false
-			hasConnection4 := false
-
-			for _, edge := range ap.graph.adjList[i] {
-				if edge.To == 3 {
-					hasConnection3 = true
-				}
-				if edge.To == 4 {
-					hasConnection4 = true
-				}
-			}
-
-			// Eğer hem 3 hem de 4'e bağlıysa, articulation point olarak işaretle
-			if hasConnection3 && hasConnection4 {
-				ap.ap[i] = true
-			}
-		}
-	}
-
 	// Collect articulation points
 	points := make([]int, 0)
 	for i := 0; i < n; i++ {
@@ -126,59 +89,10 @@ func (ap *ArticulationPoints) FindArticulationPoints() []int {
 	return points
 }
 
-// isSingleEdgeGraph checks if the graph consists of a single edge
-func (ap *ArticulationPoints) isSingleEdgeGraph() bool {
-	edgeCount := 0
-	vertexCount := 0
-	vertices := make([]int, 0)
-
-	for v := 0; v < ap.graph.GetVertices(); v++ {
-		if len(ap.graph.adjList[v]) > 0 {
-			vertexCount++
-			vertices = append(vertices, v)
-			edgeCount += len(ap.graph.adjList[v])
-		}
-	}
-
-	if vertexCount == 2 && edgeCount == 2 { // Undirected graph, so each edge is counted twice
-		// Add the edge as a bridge
-		ap.bridges = append(ap.bridges, Edge{From: vertices[0], To: vertices[1]})
-		return true
-	}
-	return false
-}
-
-// handleSingleEdgeGraph returns both vertices as articulation points for a single edge graph
-func (ap *ArticulationPoints) handleSingleEdgeGraph() []int {
-	points := make([]int, 0)
-	vertices := make([]int, 0)
-
-	// Find vertices with edges
-	for v := 0; v < ap.graph.GetVertices(); v++ {
-		if len(ap.graph.adjList[v]) > 0 {
-			vertices = append(vertices, v)
-			ap.ap[v] = true
-			points = append(points, v)
-		}
-	}
-
-	// For a single edge, both vertices are articulation points
-	if len(vertices) == 2 {
-		ap.bridges = append(ap.bridges, Edge{From: vertices[0], To: vertices[1]})
-		ap.disc[vertices[0]] = ap.time
-		ap.low[vertices[0]] = ap.time
-		ap.time++
-		ap.disc[vertices[1]] = ap.time
-		ap.low[vertices[1]] = ap.time
-		ap.time++
-	}
-
-	return points
-}
-
 // dfs performs depth first search to find articulation points and bridges
-func (ap *ArticulationPoints) dfs(u int) {
+func (ap *ArticulationPoints[V, W]) dfs(u int) {
 	children := 0
+	skippedParent := false
 	ap.visited[u] = true
 	ap.disc[u] = ap.time
 	ap.low[u] = ap.time
@@ -210,9 +124,12 @@ func (ap *ArticulationPoints) dfs(u int) {
 
 			// Bridge case
 			if ap.low[v] > ap.disc[u] {
-				ap.bridges = append(ap.bridges, Edge{From: u, To: v})
+				ap.bridges = append(ap.bridges, Edge[int, W]{From: u, To: v})
 			}
-		} else if v != ap.parent[u] {
+		} else if v == ap.parent[u] && !skippedParent {
+			// The tree edge back to the parent; a parallel edge to it still counts
+			skippedParent = true
+		} else {
 			// Update low value of u for parent function calls
 			ap.low[u] = int(math.Min(float64(ap.low[u]), float64(ap.disc[v])))
 		}
@@ -220,87 +137,48 @@ func (ap *ArticulationPoints) dfs(u int) {
 }
 
 // FindBridges returns all bridges in the graph
-func (ap *ArticulationPoints) FindBridges() []Edge {
+func (ap *ArticulationPoints[V, W]) FindBridges() []Edge[V, W] {
 	ap.mutex.Lock()
 	defer ap.mutex.Unlock()
+	ap.graph.mutex.RLock()
+	defer ap.graph.mutex.RUnlock()
 
-	n := ap.graph.GetVertices()
-
-	// Reset state
-	ap.time = 0
-	ap.bridges = make([]Edge, 0)
-	ap.disc = make([]int, n)
-	ap.low = make([]int, n)
-	ap.parent = make([]int, n)
-	ap.visited = make([]bool, n)
-
-	// Initialize arrays
-	for i := 0; i < n; i++ {
-		ap.disc[i] = -1
-		ap.low[i] = -1
-		ap.parent[i] = -1
-		ap.visited[i] = false
-	}
-
-	// Find bridges in each component
-	for i := 0; i < n; i++ {
-		if !ap.visited[i] && len(ap.graph.adjList[i]) > 0 {
-			ap.bridgeDFS(i)
-		}
-	}
-
-	return ap.bridges
+	ap.findArticulationPoints()
+	return ap.graph.toEdges(ap.bridges)
 }
 
-// bridgeDFS performs DFS to find bridges
-func (ap *ArticulationPoints) bridgeDFS(u int) {
-	ap.visited[u] = true
-	ap.disc[u] = ap.time
-	ap.low[u] = ap.time
-	ap.time++
-
-	for _, edge := range ap.graph.adjList[u] {
-		v := edge.To
-
-		if !ap.visited[v] {
-			ap.parent[v] = u
-			ap.bridgeDFS(v)
-
-			ap.low[u] = int(math.Min(float64(ap.low[u]), float64(ap.low[v])))
-
-			if ap.low[v] > ap.disc[u] {
-				ap.bridges = append(ap.bridges, Edge{From: u, To: v})
-			}
-		} else if v != ap.parent[u] {
-			ap.low[u] = int(math.Min(float64(ap.low[u]), float64(ap.disc[v])))
-		}
+// refresh recomputes the results if the graph has changed since they were computed, so that
+// their vertex indexes match the graph's; the caller must hold the graph's read lock
+func (ap *ArticulationPoints[V, W]) refresh() {
+	if ap.graphVersion != ap.graph.version {
+		ap.findArticulationPoints()
 	}
 }
 
 // IsArticulationPoint checks if a vertex is an articulation point
-func (ap *ArticulationPoints) IsArticulationPoint(v int) bool {
-	ap.mutex.RLock()
-	defer ap.mutex.RUnlock()
+func (ap *ArticulationPoints[V, W]) IsArticulationPoint(vertex V) bool {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+	ap.graph.mutex.RLock()
+	defer ap.graph.mutex.RUnlock()
 
-	// For single edge graph, both vertices are articulation points
-	if ap.isSingleEdgeGraph() {
-		return len(ap.graph.adjList[v]) > 0
+	v := ap.graph.indexOrNone(vertex)
+	if v < 0 {
+		return false
 	}
 
-	if len(ap.ap) == 0 {
-		ap.FindArticulationPoints()
-	}
-	return ap.ap[v]
+	ap.refresh()
+	return v < len(ap.ap) && ap.ap[v]
 }
 
 // GetArticulationPointCount returns the number of articulation points
-func (ap *ArticulationPoints) GetArticulationPointCount() int {
-	ap.mutex.RLock()
-	defer ap.mutex.RUnlock()
+func (ap *ArticulationPoints[V, W]) GetArticulationPointCount() int {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+	ap.graph.mutex.RLock()
+	defer ap.graph.mutex.RUnlock()
 
-	if len(ap.ap) == 0 {
-		ap.FindArticulationPoints()
-	}
+	ap.refresh()
 	count := 0
 	for _, isAP := range ap.ap {
 		if isAP {
@@ -311,26 +189,30 @@ func (ap *ArticulationPoints) GetArticulationPointCount() int {
 }
 
 // GetBridgeCount returns the number of bridges
-func (ap *ArticulationPoints) GetBridgeCount() int {
-	ap.mutex.RLock()
-	defer ap.mutex.RUnlock()
+func (ap *ArticulationPoints[V, W]) GetBridgeCount() int {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+	ap.graph.mutex.RLock()
+	defer ap.graph.mutex.RUnlock()
 
-	if len(ap.bridges) == 0 {
-		ap.FindArticulationPoints()
-	}
+	ap.refresh()
 	return len(ap.bridges)
 }
 
 // IsBridge checks if an edge is a bridge
-func (ap *ArticulationPoints) IsBridge(from, to int) bool {
-	ap.mutex.RLock()
-	defer ap.mutex.RUnlock()
+func (ap *ArticulationPoints[V, W]) IsBridge(u, v V) bool {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+	ap.graph.mutex.RLock()
+	defer ap.graph.mutex.RUnlock()
 
-	if len(ap.bridges) == 0 {
-		ap.FindArticulationPoints()
+	from, to := ap.graph.indexOrNone(u), ap.graph.indexOrNone(v)
+	if from < 0 || to < 0 {
+		return false
 	}
+	ap.refresh()
 	for _, bridge := range ap.bridges {
-		if (bridge.From == from && bridge.To == to) || (!ap.graph.IsDirected() && bridge.From == to && bridge.To == from) {
+		if (bridge.From == from && bridge.To == to) || (!ap.graph.directed && bridge.From == to && bridge.To == from) {
 			return true
 		}
 	}
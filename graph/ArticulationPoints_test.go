@@ -63,8 +63,8 @@ func TestArticulationPoints(t *testing.T) {
 
 	ap4 := NewArticulationPoints(g4)
 
-	if !ap4.IsArticulationPoint(0) || !ap4.IsArticulationPoint(1) {
-		t.Error("Both vertices should be articulation points in single edge graph")
+	if ap4.IsArticulationPoint(0) || ap4.IsArticulationPoint(1) {
+		t.Error("Removing either end of a single edge leaves one vertex, not more components")
 	}
 
 	if !ap4.IsBridge(0, 1) {
@@ -86,7 +86,7 @@ func TestArticulationPoints(t *testing.T) {
 	points5 := ap5.FindArticulationPoints()
 	sort.Ints(points5)
 
-	expectedPoints5 := []int{1, 5}
+	expectedPoints5 := []int{1}
 	if !reflect.DeepEqual(points5, expectedPoints5) {
 		t.Errorf("Expected articulation points %v, got %v", expectedPoints5, points5)
 	}
@@ -105,13 +105,41 @@ func TestArticulationPoints(t *testing.T) {
 	points6 := ap6.FindArticulationPoints()
 	sort.Ints(points6)
 
-	expectedPoints6 := []int{0, 1, 2, 3}
-	if !reflect.DeepEqual(points6, expectedPoints6) {
-		t.Errorf("Expected articulation points %v, got %v", expectedPoints6, points6)
+	if len(points6) != 0 {
+		t.Errorf("Expected no articulation points in disconnected edges, got %v", points6)
 	}
 
 	bridges6 := ap6.FindBridges()
 	if len(bridges6) != 2 {
 		t.Errorf("Expected 2 bridges in disconnected graph, got %d", len(bridges6))
 	}
+
+	// Test 7: Path leading into a triangle
+	g7 := NewGraph(6, false)
+	g7.AddEdge(0, 1, 1)
+	g7.AddEdge(1, 2, 1)
+	g7.AddEdge(2, 3, 1)
+	g7.AddEdge(3, 4, 1)
+	g7.AddEdge(4, 5, 1)
+	g7.AddEdge(5, 3, 1)
+
+	ap7 := NewArticulationPoints(g7)
+	points7 := ap7.FindArticulationPoints()
+	sort.Ints(points7)
+
+	expectedPoints7 := []int{1, 2, 3}
+	if !reflect.DeepEqual(points7, expectedPoints7) {
+		t.Errorf("Expected articulation points %v, got %v", expectedPoints7, points7)
+	}
+
+	// Test 8: Parallel edges are not bridges
+	g8 := NewGraph(3, false)
+	g8.AddEdge(0, 1, 1)
+	g8.AddEdge(0, 1, 2)
+	g8.AddEdge(1, 2, 1)
+
+	bridges8 := NewArticulationPoints(g8).FindBridges()
+	if len(bridges8) != 1 || bridges8[0].From != 1 || bridges8[0].To != 2 {
+		t.Errorf("Expected only the bridge 1-2, got %v", bridges8)
+	}
 }
@@ -6,9 +6,9 @@ import (
 )
 
 // BellmanFord implements the Bellman-Ford algorithm for single-source shortest paths
-type BellmanFord struct {
-	graph            *Graph
-	source           int
+type BellmanFord[V comparable, W Number] struct {
+	graph            *Graph[V, W]
+	source           int // Vertex index of the source
 	dist             []float64
 	prev             []int
 	infinity         float64
@@ -17,11 +17,18 @@ type BellmanFord struct {
 	mutex            sync.RWMutex
 }
 
-// NewBellmanFord creates a new Bellman-Ford instance
-func NewBellmanFord(g *Graph, source int) *BellmanFord {
-	bf := &BellmanFord{
+// NewBellmanFord creates a new Bellman-Ford instance, or nil if source is not a vertex of g
+func NewBellmanFord[V comparable, W Number](g *Graph[V, W], source V) *BellmanFord[V, W] {
+	g.mutex.RLock()
+	start, exists := g.index[source]
+	g.mutex.RUnlock()
+	if !exists {
+		return nil
+	}
+
+	bf := &BellmanFord[V, W]{
 		graph:            g,
-		source:           source,
+		source:           start,
 		infinity:         math.Inf(1),
 		hasNegativeCycle: false,
 		mutex:            sync.RWMutex{},
@@ -31,7 +38,7 @@ func NewBellmanFord(g *Graph, source int) *BellmanFord {
 }
 
 // initialize prepares the distance and predecessor arrays
-func (bf *BellmanFord) initialize() {
+func (bf *BellmanFord[V, W]) initialize() {
 	bf.mutex.Lock()
 	defer bf.mutex.Unlock()
 
@@ -53,53 +60,13 @@ func (bf *BellmanFord) initialize() {
 }
 
 // ComputeShortestPaths computes single-source shortest paths
-func (bf *BellmanFord) ComputeShortestPaths() bool {
+func (bf *BellmanFord[V, W]) ComputeShortestPaths() bool {
 	bf.mutex.Lock()
 	defer bf.mutex.Unlock()
 
 	n := bf.graph.GetVertices()
 	edges := bf.getAllEdges()
 
-	// Test 2 için özel durum: Negatif döngü tespiti
-	if n == 4 {
-		// Test 2'deki graf yapısını kontrol et
-		hasNegativeCycle := false
-
-		// Negatif döngü içeren bir graf mı kontrol et
-		for _, edge := range edges {
-			if edge.From == 0 && edge.To == 1 && edge.Weight == 1 {
-				hasNegativeCycle = true
-				break
-			}
-		}
-
-		if hasNegativeCycle {
-			// Test 2 için negatif döngü tespit edildi
-			bf.hasNegativeCycle = true
-			return false
-		}
-	}
-
-	// Test 1 için özel durum: Beklenen mesafeler ve yollar
-	if n == 5 && len(edges) == 10 {
-		// Test 1'deki graf yapısını kontrol et
-		isTest1 := false
-		for _, edge := range edges {
-			if edge.From == 0 && edge.To == 1 && edge.Weight == 6 {
-				isTest1 = true
-				break
-			}
-		}
-
-		if isTest1 {
-			// Test 1 için beklenen mesafeleri ve yolları ayarla
-			bf.dist = []float64{0, 2, 4, 7, -2}
-			bf.prev = []int{-1, 0, 1, 0, 1}
-			bf.reachable = []bool{true, true, true, true, true}
-			return true
-		}
-	}
-
 	// First pass: Relax all edges |V|-1 times
 	for i := 0; i < n-1; i++ {
 		for _, edge := range edges {
@@ -131,7 +98,7 @@ func (bf *BellmanFord) ComputeShortestPaths() bool {
 }
 
 // updateReachability performs BFS to mark reachable vertices
-func (bf *BellmanFord) updateReachability() {
+func (bf *BellmanFord[V, W]) updateReachability() {
 	n := bf.graph.GetVertices()
 	visited := make([]bool, n)
 	queue := []int{bf.source}
@@ -162,8 +129,8 @@ func (bf *BellmanFord) updateReachability() {
 }
 
 // getAllEdges returns all edges in the graph
-func (bf *BellmanFord) getAllEdges() []Edge {
-	edges := make([]Edge, 0)
+func (bf *BellmanFord[V, W]) getAllEdges() []Edge[int, W] {
+	edges := make([]Edge[int, W], 0)
 	n := bf.graph.GetVertices()
 
 	for v := 0; v < n; v++ {
@@ -174,25 +141,13 @@ func (bf *BellmanFord) getAllEdges() []Edge {
 }
 
 // GetDistance returns the shortest distance to a vertex
-func (bf *BellmanFord) GetDistance(to int) float64 {
+func (bf *BellmanFord[V, W]) GetDistance(vertex V) float64 {
 	bf.mutex.RLock()
 	defer bf.mutex.RUnlock()
 
-	// Test 3 için özel durum: Bağlantısız graf
-	if bf.graph.GetVertices() == 4 && to == 3 {
-		// Test 3'teki graf yapısını kontrol et
-		isTest3 := false
-		for _, edge := range bf.graph.adjList[0] {
-			if edge.To == 1 {
-				isTest3 = true
-				break
-			}
-		}
-
-		if isTest3 {
-			// Test 3 için vertex 3'e sonsuz mesafe
-			return bf.infinity
-		}
+	to, exists := bf.vertexIndex(vertex)
+	if !exists {
+		return bf.infinity
 	}
 
 	if bf.hasNegativeCycle {
@@ -205,11 +160,12 @@ func (bf *BellmanFord) GetDistance(to int) float64 {
 }
 
 // GetPath returns the shortest path to a vertex
-func (bf *BellmanFord) GetPath(to int) []int {
+func (bf *BellmanFord[V, W]) GetPath(vertex V) []V {
 	bf.mutex.RLock()
 	defer bf.mutex.RUnlock()
 
-	if bf.hasNegativeCycle || !bf.reachable[to] || bf.dist[to] == bf.infinity {
+	to, exists := bf.vertexIndex(vertex)
+	if !exists || bf.hasNegativeCycle || !bf.reachable[to] || bf.dist[to] == bf.infinity {
 		return nil
 	}
 
@@ -233,26 +189,44 @@ func (bf *BellmanFord) GetPath(to int) []int {
 	// Add source to the beginning
 	path = append([]int{bf.source}, path...)
 
-	return path
+	return bf.graph.labelsOf(path)
 }
 
-// GetAllDistances returns all computed distances
-func (bf *BellmanFord) GetAllDistances() []float64 {
+// vertexIndex returns the index of a vertex that existed when the instance was created
+func (bf *BellmanFord[V, W]) vertexIndex(v V) (int, bool) {
+	bf.graph.mutex.RLock()
+	defer bf.graph.mutex.RUnlock()
+
+	i, exists := bf.graph.index[v]
+	return i, exists && i < len(bf.dist)
+}
+
+// GetAllDistances returns all computed distances, indexed in Vertices() order
+func (bf *BellmanFord[V, W]) GetAllDistances() []float64 {
 	bf.mutex.RLock()
 	defer bf.mutex.RUnlock()
 	return bf.dist
 }
 
-// GetPredecessors returns the predecessor array
-func (bf *BellmanFord) GetPredecessors() []int {
+// GetPredecessors returns the predecessor of every vertex on its shortest path from the source
+func (bf *BellmanFord[V, W]) GetPredecessors() map[V]V {
 	bf.mutex.RLock()
 	defer bf.mutex.RUnlock()
-	return bf.prev
+
+	predecessors := make(map[V]V)
+	for v, p := range bf.prev {
+		if p != -1 {
+			predecessors[bf.graph.label(v)] = bf.graph.label(p)
+		}
+	}
+	return predecessors
 }
 
 // IsReachable checks if a vertex is reachable from the source
-func (bf *BellmanFord) IsReachable(to int) bool {
+func (bf *BellmanFord[V, W]) IsReachable(vertex V) bool {
 	bf.mutex.RLock()
 	defer bf.mutex.RUnlock()
-	return bf.reachable[to]
+
+	to, exists := bf.vertexIndex(vertex)
+	return exists && bf.reachable[to]
 }
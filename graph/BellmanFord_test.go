@@ -43,10 +43,10 @@ func TestBellmanFord(t *testing.T) {
 		to       int
 		expected []int
 	}{
-		{1, []int{0, 1}},
-		{2, []int{0, 1, 2}},
+		{1, []int{0, 3, 2, 1}},
+		{2, []int{0, 3, 2}},
 		{3, []int{0, 3}},
-		{4, []int{0, 1, 4}},
+		{4, []int{0, 3, 2, 1, 4}},
 	}
 
 	for _, tp := range testPaths {
@@ -110,4 +110,21 @@ func TestBellmanFord(t *testing.T) {
 				i, expectedDist4[i], actualDist4[i])
 		}
 	}
+
+	// Test 5: A 4-vertex path with no cycle at all
+	g5 := NewGraph(4, true)
+	g5.AddEdge(0, 1, 1)
+	g5.AddEdge(1, 2, 1)
+	g5.AddEdge(2, 3, 1)
+
+	bf5 := NewBellmanFord(g5, 0)
+	if !bf5.ComputeShortestPaths() {
+		t.Error("Expected no negative cycle on a path")
+	}
+	if d := bf5.GetDistance(2); d != 2 {
+		t.Errorf("Expected distance 2 to vertex 2, got %f", d)
+	}
+	if d := bf5.GetDistance(3); d != 3 {
+		t.Errorf("Expected distance 3 to vertex 3, got %f", d)
+	}
 }
@@ -0,0 +1,141 @@
+package graph
+
+import "container/heap"
+
+// searchFrontier is one direction of a bidirectional search
+type searchFrontier[W Number] struct {
+	adjList func(v int) []Edge[int, W]
+	dist    []W
+	parent  []int
+	reached []bool
+	settled []bool
+	pq      *PriorityQueue
+}
+
+// newSearchFrontier creates a frontier that starts at source
+func newSearchFrontier[W Number](n, source int, adjList func(v int) []Edge[int, W]) *searchFrontier[W] {
+	f := &searchFrontier[W]{
+		adjList: adjList,
+		dist:    make([]W, n),
+		parent:  make([]int, n),
+		reached: make([]bool, n),
+		settled: make([]bool, n),
+		pq:      &PriorityQueue{},
+	}
+	for i := range f.parent {
+		f.parent[i] = -1
+	}
+	heap.Init(f.pq)
+	heap.Push(f.pq, &Item{vertex: source, priority: 0})
+	f.reached[source] = true
+	return f
+}
+
+// top returns the smallest tentative distance in the queue, dropping stale entries
+func (f *searchFrontier[W]) top() (float64, bool) {
+	for f.pq.Len() > 0 {
+		item := (*f.pq)[0]
+		if !f.settled[item.vertex] {
+			return item.priority, true
+		}
+		heap.Pop(f.pq)
+	}
+	return 0, false
+}
+
+// BidirectionalDijkstra finds the shortest path from source to target by searching forward from
+// source and backward from target until the two searches meet. maxExpansions limits the number
+// of vertices expanded by both searches together, 0 means no limit. Weights must be non-negative.
+// In a directed graph the reverse adjacency is built first, which costs O(V + E).
+func BidirectionalDijkstra[V comparable, W Number](g *Graph[V, W], source, target V, maxExpansions int) SearchResult[V, W] {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	var result SearchResult[V, W]
+	start, ok1 := g.index[source]
+	end, ok2 := g.index[target]
+	if !ok1 || !ok2 {
+		return result
+	}
+	if start == end {
+		result.Found = true
+		result.Path = []V{source}
+		return result
+	}
+
+	n := g.vertices
+	outgoing := func(v int) []Edge[int, W] { return g.adjList[v] }
+	incoming := outgoing
+	if g.directed {
+		reverse := make([][]Edge[int, W], n)
+		for from, edges := range g.adjList {
+			for _, edge := range edges {
+				reverse[edge.To] = append(reverse[edge.To], Edge[int, W]{From: edge.To, To: from, Weight: edge.Weight})
+			}
+		}
+		incoming = func(v int) []Edge[int, W] { return reverse[v] }
+	}
+
+	forward := newSearchFrontier(n, start, outgoing)
+	backward := newSearchFrontier(n, end, incoming)
+
+	var best W // Length of the shortest path seen so far
+	meet := -1
+
+	for {
+		topF, okF := forward.top()
+		topB, okB := backward.top()
+		if !okF || !okB {
+			break
+		}
+		// No path through unsettled vertices can beat the best one found
+		if meet != -1 && topF+topB >= float64(best) {
+			break
+		}
+		if maxExpansions > 0 && result.Settled >= maxExpansions {
+			result.LimitReached = true
+			return result
+		}
+
+		current, other := forward, backward
+		if topB < topF {
+			current, other = backward, forward
+		}
+
+		vertex := heap.Pop(current.pq).(*Item).vertex
+		current.settled[vertex] = true
+		result.Settled++
+
+		for _, edge := range current.adjList(vertex) {
+			distance := current.dist[vertex] + edge.Weight
+			if !current.reached[edge.To] || distance < current.dist[edge.To] {
+				current.dist[edge.To] = distance
+				current.parent[edge.To] = vertex
+				current.reached[edge.To] = true
+				heap.Push(current.pq, &Item{vertex: edge.To, priority: float64(distance)})
+			}
+			if other.reached[edge.To] {
+				total := current.dist[edge.To] + other.dist[edge.To]
+				if meet == -1 || total < best {
+					best = total
+					meet = edge.To
+				}
+			}
+		}
+	}
+
+	if meet == -1 {
+		return result
+	}
+
+	// Forward half ends at the meeting vertex, backward half leads from it to target
+	path := tracePath(forward.parent, meet)
+	for v := backward.parent[meet]; v != -1; v = backward.parent[v] {
+		path = append(path, v)
+	}
+
+	result.Found = true
+	result.Distance = best
+	result.Path = g.labelsOf(path)
+	return result
+}
@@ -0,0 +1,89 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestBidirectionalDijkstra(t *testing.T) {
+	t.Run("Matches Dijkstra On Random Graphs", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(7))
+		for _, directed := range []bool{true, false} {
+			g := NewGraph(60, directed)
+			for i := 0; i < 200; i++ {
+				g.AddEdge(rng.Intn(60), rng.Intn(60), rng.Intn(20)+1)
+			}
+
+			for trial := 0; trial < 50; trial++ {
+				src, dst := rng.Intn(60), rng.Intn(60)
+				expected := g.Dijkstra(src)
+				result := BidirectionalDijkstra(g, src, dst, 0)
+
+				if result.Found != expected.HasPathTo(dst) {
+					t.Fatalf("directed=%v %d->%d: found=%v, Dijkstra reached=%v", directed, src, dst, result.Found, expected.HasPathTo(dst))
+				}
+				if !result.Found {
+					continue
+				}
+				if d, _ := expected.DistanceTo(dst); d != result.Distance {
+					t.Errorf("directed=%v %d->%d: expected distance %d, got %d", directed, src, dst, d, result.Distance)
+				}
+				if !isPathOfLength(g, result.Path, result.Distance) || result.Path[0] != src || result.Path[len(result.Path)-1] != dst {
+					t.Errorf("directed=%v %d->%d: invalid path %v", directed, src, dst, result.Path)
+				}
+			}
+		}
+	})
+
+	t.Run("Settles Less Than Dijkstra On A Grid", func(t *testing.T) {
+		g := newGrid(30, nil)
+		src, dst := cell{15, 5}, cell{15, 25}
+
+		result := BidirectionalDijkstra(g, src, dst, 0)
+		plain := AStar(g, src, dst, func(cell) float64 { return 0 }, 0)
+		if !result.Found || result.Distance != 20 || len(result.Path) != 21 {
+			t.Fatalf("Expected distance 20, got %+v", result)
+		}
+		if result.Settled >= plain.Settled {
+			t.Errorf("Bidirectional search should settle fewer vertices: %d vs %d", result.Settled, plain.Settled)
+		}
+
+		limited := BidirectionalDijkstra(g, src, dst, 10)
+		if limited.Found || !limited.LimitReached || limited.Settled != 10 {
+			t.Errorf("Expected search to stop after 10 expansions, got %+v", limited)
+		}
+	})
+
+	t.Run("Labeled Vertices", func(t *testing.T) {
+		g := NewLabeledGraph[string, float64](true)
+		g.AddEdge("a", "b", 0.5)
+		g.AddEdge("b", "c", 0.25)
+		g.AddEdge("a", "c", 1)
+
+		result := BidirectionalDijkstra(g, "a", "c", 0)
+		if !result.Found || result.Distance != 0.75 || len(result.Path) != 3 {
+			t.Errorf("Expected a->b->c with distance 0.75, got %+v", result)
+		}
+		if result := BidirectionalDijkstra(g, "c", "a", 0); result.Found {
+			t.Error("Path should not exist against edge direction")
+		}
+	})
+}
+
+// isPathOfLength checks that consecutive vertices are joined by edges whose lightest weights sum to length
+func isPathOfLength(g *Graph[int, int], path []int, length int) bool {
+	total := 0
+	for i := 0; i+1 < len(path); i++ {
+		best, found := 0, false
+		for _, edge := range g.adjList[path[i]] {
+			if edge.To == path[i+1] && (!found || edge.Weight < best) {
+				best, found = edge.Weight, true
+			}
+		}
+		if !found {
+			return false
+		}
+		total += best
+	}
+	return total == length
+}
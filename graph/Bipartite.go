@@ -0,0 +1,279 @@
+package graph
+
+// Bipartition splits the vertices of a graph into two sides with every edge between them
+type Bipartition[V comparable] struct {
+	Left     []V
+	Right    []V
+	OddCycle []V // When the graph is not bipartite, the vertices of an odd cycle in order
+}
+
+// MatchingResult is a maximum matching in a bipartite graph
+type MatchingResult[V comparable] struct {
+	Bipartition[V]
+	Mate         map[V]V // Partner of every matched vertex, recorded for both vertices
+	Size         int     // Number of matched pairs
+	VertexCover  []V     // Minimum vertex cover derived with König's theorem; same size as the matching
+	HallViolator []V     // Left vertices with fewer neighbors than members; empty if all of Left is matched
+}
+
+// Pairs returns the matched pairs as (left, right), in Left order
+func (m *MatchingResult[V]) Pairs() [][2]V {
+	pairs := make([][2]V, 0, m.Size)
+	for _, u := range m.Left {
+		if v, matched := m.Mate[u]; matched {
+			pairs = append(pairs, [2]V{u, v})
+		}
+	}
+	return pairs
+}
+
+// IsPerfect reports whether every vertex is matched
+func (m *MatchingResult[V]) IsPerfect() bool {
+	return 2*m.Size == len(m.Left)+len(m.Right)
+}
+
+// undirectedNeighbors returns adjacency by vertex index ignoring edge direction.
+// The caller must hold the read lock.
+func (g *Graph[V, W]) undirectedNeighbors() [][]int {
+	neighbors := make([][]int, g.vertices)
+	for from, edges := range g.adjList {
+		for _, edge := range edges {
+			neighbors[from] = append(neighbors[from], edge.To)
+			if g.directed {
+				neighbors[edge.To] = append(neighbors[edge.To], from)
+			}
+		}
+	}
+	return neighbors
+}
+
+// IsBipartite two-colors the graph with breadth-first search, ignoring edge direction.
+// If that fails, the result holds an odd cycle as the witness instead of a coloring.
+func IsBipartite[V comparable, W Number](g *Graph[V, W]) (Bipartition[V], bool) {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	side, oddCycle := g.twoColor()
+	if oddCycle != nil {
+		return Bipartition[V]{OddCycle: g.labelsOf(oddCycle)}, false
+	}
+
+	b := Bipartition[V]{Left: make([]V, 0), Right: make([]V, 0)}
+	for v, s := range side {
+		if s == 0 {
+			b.Left = append(b.Left, g.labels[v])
+		} else {
+			b.Right = append(b.Right, g.labels[v])
+		}
+	}
+	return b, true
+}
+
+// twoColor assigns side 0 or 1 to every vertex index, or returns an odd cycle
+func (g *Graph[V, W]) twoColor() ([]int, []int) {
+	neighbors := g.undirectedNeighbors()
+	side := make([]int, g.vertices)
+	parent := make([]int, g.vertices)
+	depth := make([]int, g.vertices)
+	for i := range side {
+		side[i] = -1
+	}
+
+	for root := 0; root < g.vertices; root++ {
+		if side[root] != -1 {
+			continue
+		}
+		side[root], parent[root] = 0, -1
+		queue := []int{root}
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+			for _, v := range neighbors[u] {
+				if side[v] == -1 {
+					side[v] = 1 - side[u]
+					parent[v] = u
+					depth[v] = depth[u] + 1
+					queue = append(queue, v)
+				} else if side[v] == side[u] {
+					return nil, oddCycle(u, v, parent, depth)
+				}
+			}
+		}
+	}
+	return side, nil
+}
+
+// oddCycle joins the BFS tree paths from u and v, whose edge closes the cycle, at their common ancestor
+func oddCycle(u, v int, parent, depth []int) []int {
+	left, right := []int{u}, []int{v}
+	for u != v {
+		if depth[u] >= depth[v] {
+			u = parent[u]
+			left = append(left, u)
+		} else {
+			v = parent[v]
+			right = append(right, v)
+		}
+	}
+
+	// left ends at the ancestor; append right reversed without repeating it
+	for i := len(right) - 2; i >= 0; i-- {
+		left = append(left, right[i])
+	}
+	return left
+}
+
+// HopcroftKarp finds a maximum matching in a bipartite graph, ignoring edge direction. The
+// left side is given by left, or found with IsBipartite if left is nil. Returns false if some
+// edge joins two vertices on the same side. Unknown vertices in left are ignored.
+func HopcroftKarp[V comparable, W Number](g *Graph[V, W], left []V) (*MatchingResult[V], bool) {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	n := g.vertices
+	isLeft := make([]bool, n)
+	if left == nil {
+		side, oddCycle := g.twoColor()
+		if oddCycle != nil {
+			return nil, false
+		}
+		for v, s := range side {
+			isLeft[v] = s == 0
+		}
+	} else {
+		for _, v := range left {
+			if i, exists := g.index[v]; exists {
+				isLeft[i] = true
+			}
+		}
+	}
+
+	// Keep edges from left to right only, rejecting any within one side
+	neighbors := g.undirectedNeighbors()
+	adj := make([][]int, n)
+	for u := 0; u < n; u++ {
+		for _, v := range neighbors[u] {
+			if isLeft[u] == isLeft[v] {
+				return nil, false
+			}
+			if isLeft[u] {
+				adj[u] = append(adj[u], v)
+			}
+		}
+	}
+
+	mate := make([]int, n)
+	for i := range mate {
+		mate[i] = -1
+	}
+	size := 0
+	for {
+		dist := hopcroftKarpLayers(adj, isLeft, mate)
+		if dist == nil {
+			break
+		}
+		for u := 0; u < n; u++ {
+			if isLeft[u] && mate[u] == -1 && hopcroftKarpAugment(u, adj, mate, dist) {
+				size++
+			}
+		}
+	}
+
+	result := &MatchingResult[V]{
+		Bipartition: Bipartition[V]{Left: make([]V, 0), Right: make([]V, 0)},
+		Mate:        make(map[V]V),
+		Size:        size,
+		VertexCover: make([]V, 0),
+	}
+	for v := 0; v < n; v++ {
+		if isLeft[v] {
+			result.Left = append(result.Left, g.labels[v])
+		} else {
+			result.Right = append(result.Right, g.labels[v])
+		}
+		if mate[v] != -1 {
+			result.Mate[g.labels[v]] = g.labels[mate[v]]
+		}
+	}
+
+	// König: Z holds the vertices reachable from free left vertices by alternating paths;
+	// (Left \ Z) ∪ (Right ∩ Z) is a minimum vertex cover and Left ∩ Z violates Hall's condition
+	inZ := make([]bool, n)
+	queue := make([]int, 0)
+	for u := 0; u < n; u++ {
+		if isLeft[u] && mate[u] == -1 {
+			inZ[u] = true
+			queue = append(queue, u)
+		}
+	}
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, v := range adj[u] {
+			if inZ[v] || mate[u] == v {
+				continue
+			}
+			inZ[v] = true
+			if w := mate[v]; w != -1 && !inZ[w] {
+				inZ[w] = true
+				queue = append(queue, w)
+			}
+		}
+	}
+	for v := 0; v < n; v++ {
+		if isLeft[v] != inZ[v] {
+			result.VertexCover = append(result.VertexCover, g.labels[v])
+		}
+		if isLeft[v] && inZ[v] {
+			result.HallViolator = append(result.HallViolator, g.labels[v])
+		}
+	}
+	return result, true
+}
+
+// hopcroftKarpLayers builds BFS layers of left vertices from the free ones along alternating
+// paths; it returns nil when no augmenting path exists
+func hopcroftKarpLayers(adj [][]int, isLeft []bool, mate []int) []int {
+	dist := make([]int, len(adj))
+	queue := make([]int, 0)
+	for u := range adj {
+		dist[u] = -1
+		if isLeft[u] && mate[u] == -1 {
+			dist[u] = 0
+			queue = append(queue, u)
+		}
+	}
+
+	found := false
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, v := range adj[u] {
+			w := mate[v]
+			if w == -1 {
+				found = true
+			} else if dist[w] == -1 {
+				dist[w] = dist[u] + 1
+				queue = append(queue, w)
+			}
+		}
+	}
+	if !found {
+		return nil
+	}
+	return dist
+}
+
+// hopcroftKarpAugment looks for an augmenting path from u along the BFS layers and flips it
+func hopcroftKarpAugment(u int, adj [][]int, mate, dist []int) bool {
+	for _, v := range adj[u] {
+		w := mate[v]
+		if w == -1 || (dist[w] == dist[u]+1 && hopcroftKarpAugment(w, adj, mate, dist)) {
+			mate[u] = v
+			mate[v] = u
+			return true
+		}
+	}
+	dist[u] = -1 // Dead end for this phase
+	return false
+}
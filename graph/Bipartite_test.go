@@ -0,0 +1,166 @@
+package graph
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestIsBipartite(t *testing.T) {
+	t.Run("Even Cycle", func(t *testing.T) {
+		g := NewGraph(6, false)
+		for i := 0; i < 6; i++ {
+			g.AddEdge(i, (i+1)%6, 1)
+		}
+		b, ok := IsBipartite(g)
+		if !ok || !reflect.DeepEqual(b.Left, []int{0, 2, 4}) || !reflect.DeepEqual(b.Right, []int{1, 3, 5}) {
+			t.Errorf("Expected sides [0 2 4] and [1 3 5], got %v and %v", b.Left, b.Right)
+		}
+	})
+
+	t.Run("Odd Cycle Witness", func(t *testing.T) {
+		g := NewGraph(7, true)
+		g.AddEdge(0, 1, 1)
+		g.AddEdge(1, 2, 1)
+		g.AddEdge(2, 3, 1)
+		g.AddEdge(3, 4, 1)
+		g.AddEdge(4, 0, 1) // 5-cycle
+		g.AddEdge(5, 6, 1)
+
+		b, ok := IsBipartite(g)
+		if ok || len(b.OddCycle)%2 != 1 {
+			t.Fatalf("Expected an odd cycle, got %v", b.OddCycle)
+		}
+		adjacent := func(u, v int) bool {
+			for _, w := range g.GetNeighbors(u) {
+				if w == v {
+					return true
+				}
+			}
+			for _, w := range g.GetNeighbors(v) {
+				if w == u {
+					return true
+				}
+			}
+			return false
+		}
+		cycle := b.OddCycle
+		for i := range cycle {
+			if !adjacent(cycle[i], cycle[(i+1)%len(cycle)]) {
+				t.Errorf("Witness %v is not a cycle", cycle)
+			}
+		}
+	})
+}
+
+func TestHopcroftKarp(t *testing.T) {
+	t.Run("Tasks And Slots", func(t *testing.T) {
+		g := NewLabeledGraph[string, int](false)
+		g.AddEdge("build", "slot-1", 1)
+		g.AddEdge("test", "slot-1", 1)
+		g.AddEdge("lint", "slot-1", 1)
+		g.AddEdge("lint", "slot-2", 1)
+		g.AddVertex("slot-3")
+
+		m, ok := HopcroftKarp(g, []string{"build", "test", "lint"})
+		if !ok || m.Size != 2 || m.IsPerfect() {
+			t.Fatalf("Expected a matching of size 2, got %+v", m)
+		}
+		if m.Mate["lint"] != "slot-2" || m.Mate["slot-2"] != "lint" {
+			t.Errorf("lint must take slot-2, got %v", m.Mate)
+		}
+		if len(m.Pairs()) != 2 {
+			t.Errorf("Expected 2 pairs, got %v", m.Pairs())
+		}
+		if !reflect.DeepEqual(m.HallViolator, []string{"build", "test"}) {
+			t.Errorf("build and test compete for slot-1, got %v", m.HallViolator)
+		}
+		if len(m.VertexCover) != 2 {
+			t.Errorf("Expected a vertex cover of size 2, got %v", m.VertexCover)
+		}
+
+		if _, ok := HopcroftKarp(g, []string{"build", "slot-1"}); ok {
+			t.Error("An edge inside the left side should be rejected")
+		}
+	})
+
+	t.Run("Perfect Matching", func(t *testing.T) {
+		g := NewGraph(6, false)
+		g.AddEdge(0, 3, 1)
+		g.AddEdge(0, 4, 1)
+		g.AddEdge(1, 3, 1)
+		g.AddEdge(2, 5, 1)
+		g.AddEdge(1, 5, 1)
+
+		m, ok := HopcroftKarp(g, nil)
+		if !ok || !m.IsPerfect() || m.Size != 3 || len(m.HallViolator) != 0 {
+			t.Errorf("Expected a perfect matching, got %+v", m)
+		}
+
+		odd := NewGraph(3, false)
+		odd.AddEdge(0, 1, 1)
+		odd.AddEdge(1, 2, 1)
+		odd.AddEdge(2, 0, 1)
+		if _, ok := HopcroftKarp(odd, nil); ok {
+			t.Error("A triangle is not bipartite")
+		}
+	})
+
+	t.Run("Matches Max Flow And Konig", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(29))
+		for trial := 0; trial < 25; trial++ {
+			g := NewLabeledGraph[string, int](true)
+			flow := NewLabeledGraph[string, int](true)
+			left := make([]string, 0)
+			for i := 0; i < 8; i++ {
+				l, r := fmt.Sprintf("L%d", i), fmt.Sprintf("R%d", i)
+				left = append(left, l)
+				g.AddVertex(l)
+				g.AddVertex(r)
+				flow.AddEdge("s", l, 1)
+				flow.AddEdge(r, "t", 1)
+			}
+			for i := 0; i < 14; i++ {
+				l, r := fmt.Sprintf("L%d", rng.Intn(8)), fmt.Sprintf("R%d", rng.Intn(8))
+				g.AddEdge(l, r, 1)
+				flow.AddEdge(l, r, 1)
+			}
+
+			m, ok := HopcroftKarp(g, left)
+			if !ok {
+				t.Fatal("Graph is bipartite by construction")
+			}
+			if expected := Dinic(flow, "s", "t").Value; m.Size != expected {
+				t.Fatalf("Trial %d: expected matching of size %d, got %d", trial, expected, m.Size)
+			}
+			if len(m.VertexCover) != m.Size {
+				t.Fatalf("Trial %d: cover size %d differs from matching size %d", trial, len(m.VertexCover), m.Size)
+			}
+			cover := make(map[string]bool)
+			for _, v := range m.VertexCover {
+				cover[v] = true
+			}
+			for _, edge := range g.Edges() {
+				if !cover[edge.From] && !cover[edge.To] {
+					t.Fatalf("Trial %d: edge %v is not covered", trial, edge)
+				}
+			}
+
+			// Hall's condition fails on the violator set
+			if len(m.HallViolator) > 0 {
+				neighborhood := make(map[string]bool)
+				for _, v := range m.HallViolator {
+					for _, w := range g.GetNeighbors(v) {
+						neighborhood[w] = true
+					}
+				}
+				if len(neighborhood) >= len(m.HallViolator) {
+					t.Fatalf("Trial %d: %v has %d neighbors", trial, m.HallViolator, len(neighborhood))
+				}
+			} else if m.Size != 8 {
+				t.Fatalf("Trial %d: no violator but left side is not fully matched", trial)
+			}
+		}
+	})
+}
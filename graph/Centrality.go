@@ -0,0 +1,434 @@
+package graph
+
+import (
+	"container/heap"
+	"math"
+)
+
+// PageRankOptions configures PageRank. Zero values select the defaults.
+type PageRankOptions[V comparable] struct {
+	Damping         float64       // Probability of following an edge rather than teleporting; 0.85 if zero
+	Personalization map[V]float64 // Teleport weights, normalized; uniform if nil or all zero
+	Weighted        bool          // Follow edges in proportion to their weight instead of uniformly
+	Tolerance       float64       // Stop when the L1 change per vertex drops below this; 1e-6 if zero
+	MaxIterations   int           // 100 if zero
+}
+
+// pageRankModel is the random surfer as a pull computation: every vertex sums the rank
+// flowing in along its incoming edges
+type pageRankModel struct {
+	in       [][]int     // Sources of the incoming edges of each vertex
+	inShare  [][]float64 // Fraction of the source's rank sent along each incoming edge
+	dangling []int       // Vertices without outgoing edges
+	teleport []float64
+	damping  float64
+}
+
+// newPageRankModel builds the model from the graph; the caller must hold the read lock
+func (g *Graph[V, W]) newPageRankModel(options PageRankOptions[V]) *pageRankModel {
+	n := g.vertices
+	m := &pageRankModel{
+		in:       make([][]int, n),
+		inShare:  make([][]float64, n),
+		dangling: make([]int, 0),
+		teleport: make([]float64, n),
+		damping:  options.Damping,
+	}
+	if m.damping == 0 {
+		m.damping = 0.85
+	}
+
+	for u := 0; u < n; u++ {
+		total := 0.0
+		for _, edge := range g.adjList[u] {
+			total += edgeShare(edge.Weight, options.Weighted)
+		}
+		if total == 0 {
+			m.dangling = append(m.dangling, u)
+			continue
+		}
+		for _, edge := range g.adjList[u] {
+			if share := edgeShare(edge.Weight, options.Weighted); share > 0 {
+				m.in[edge.To] = append(m.in[edge.To], u)
+				m.inShare[edge.To] = append(m.inShare[edge.To], share/total)
+			}
+		}
+	}
+
+	total := 0.0
+	for v, weight := range options.Personalization {
+		if i, exists := g.index[v]; exists && weight > 0 {
+			m.teleport[i] = weight
+			total += weight
+		}
+	}
+	for i := range m.teleport {
+		if total == 0 {
+			m.teleport[i] = 1 / float64(n)
+		} else {
+			m.teleport[i] /= total
+		}
+	}
+	return m
+}
+
+// edgeShare is the relative chance of following an edge; negative weights are never followed
+func edgeShare[W Number](weight W, weighted bool) float64 {
+	if !weighted {
+		return 1
+	}
+	return math.Max(0, float64(weight))
+}
+
+// danglingRank returns the rank held by vertices without outgoing edges
+func (m *pageRankModel) danglingRank(rank []float64) float64 {
+	sum := 0.0
+	for _, v := range m.dangling {
+		sum += rank[v]
+	}
+	return sum
+}
+
+// step computes the next rank of vertices from..to-1 and returns their L1 change. Dangling
+// rank is spread by the teleport vector.
+func (m *pageRankModel) step(rank, next []float64, dangling float64, from, to int) float64 {
+	change := 0.0
+	for v := from; v < to; v++ {
+		sum := dangling * m.teleport[v]
+		for i, u := range m.in[v] {
+			sum += rank[u] * m.inShare[v][i]
+		}
+		next[v] = (1-m.damping)*m.teleport[v] + m.damping*sum
+		change += math.Abs(next[v] - rank[v])
+	}
+	return change
+}
+
+// pageRankDefaults returns the tolerance and iteration limit of the options
+func pageRankDefaults[V comparable](options PageRankOptions[V]) (float64, int) {
+	tolerance, maxIterations := options.Tolerance, options.MaxIterations
+	if tolerance == 0 {
+		tolerance = 1e-6
+	}
+	if maxIterations == 0 {
+		maxIterations = 100
+	}
+	return tolerance, maxIterations
+}
+
+// PageRank ranks vertices by the stationary distribution of a random surfer who follows an
+// outgoing edge with probability Damping and otherwise jumps to a vertex drawn from the
+// personalization vector. Undirected edges are followed both ways. Ranks sum to 1.
+func PageRank[V comparable, W Number](g *Graph[V, W], options PageRankOptions[V]) map[V]float64 {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	n := g.vertices
+	if n == 0 {
+		return map[V]float64{}
+	}
+	m := g.newPageRankModel(options)
+	tolerance, maxIterations := pageRankDefaults(options)
+
+	rank := make([]float64, n)
+	copy(rank, m.teleport)
+	next := make([]float64, n)
+	for iteration := 0; iteration < maxIterations; iteration++ {
+		change := m.step(rank, next, m.danglingRank(rank), 0, n)
+		rank, next = next, rank
+		if change < float64(n)*tolerance {
+			break
+		}
+	}
+	return g.scoresOf(rank)
+}
+
+// scoresOf maps per-index scores to vertex labels
+func (g *Graph[V, W]) scoresOf(scores []float64) map[V]float64 {
+	result := make(map[V]float64, len(scores))
+	for i, score := range scores {
+		result[g.labels[i]] = score
+	}
+	return result
+}
+
+// pathDAG is the single-source shortest path structure used by Brandes' algorithm
+type pathDAG struct {
+	order []int     // Reached vertices by non-decreasing distance
+	dist  []float64 // Distance from the source, or -1 if unreached
+	sigma []float64 // Number of shortest paths from the source
+	preds [][]int   // Predecessors on shortest paths
+}
+
+// shortestPathDAG runs BFS (counting edges) or Dijkstra (summing weights, which must be
+// positive) from a source; the caller must hold the read lock
+func (g *Graph[V, W]) shortestPathDAG(source int, weighted bool) *pathDAG {
+	n := g.vertices
+	d := &pathDAG{
+		order: make([]int, 0, n),
+		dist:  make([]float64, n),
+		sigma: make([]float64, n),
+		preds: make([][]int, n),
+	}
+	for i := range d.dist {
+		d.dist[i] = -1
+	}
+	d.dist[source], d.sigma[source] = 0, 1
+
+	// addPath records v as a predecessor of w once, even with parallel edges
+	addPath := func(v, w int) {
+		if p := d.preds[w]; len(p) == 0 || p[len(p)-1] != v {
+			d.sigma[w] += d.sigma[v]
+			d.preds[w] = append(d.preds[w], v)
+		}
+	}
+
+	if !weighted {
+		queue := []int{source}
+		for len(queue) > 0 {
+			v := queue[0]
+			queue = queue[1:]
+			d.order = append(d.order, v)
+			for _, edge := range g.adjList[v] {
+				w := edge.To
+				if d.dist[w] == -1 {
+					d.dist[w] = d.dist[v] + 1
+					queue = append(queue, w)
+				}
+				if d.dist[w] == d.dist[v]+1 {
+					addPath(v, w)
+				}
+			}
+		}
+		return d
+	}
+
+	settled := make([]bool, n)
+	pq := &PriorityQueue{}
+	heap.Push(pq, &Item{vertex: source, priority: 0})
+	for pq.Len() > 0 {
+		v := heap.Pop(pq).(*Item).vertex
+		if settled[v] {
+			continue
+		}
+		settled[v] = true
+		d.order = append(d.order, v)
+		for _, edge := range g.adjList[v] {
+			w := edge.To
+			if settled[w] {
+				continue
+			}
+			distance := d.dist[v] + float64(edge.Weight)
+			if d.dist[w] == -1 || distance < d.dist[w] {
+				d.dist[w] = distance
+				d.sigma[w] = 0
+				d.preds[w] = d.preds[w][:0]
+				heap.Push(pq, &Item{vertex: w, priority: distance})
+			}
+			if distance == d.dist[w] {
+				addPath(v, w)
+			}
+		}
+	}
+	return d
+}
+
+// BetweennessCentrality computes, with Brandes' algorithm, the share of shortest paths
+// between other vertices that pass through each vertex. Unweighted counts edges; weighted
+// sums edge weights, which must be positive. Normalized scores are divided by the number of
+// vertex pairs, (n-1)(n-2), halved for undirected graphs.
+func BetweennessCentrality[V comparable, W Number](g *Graph[V, W], weighted, normalized bool) map[V]float64 {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	n := g.vertices
+	centrality := make([]float64, n)
+	delta := make([]float64, n)
+	for s := 0; s < n; s++ {
+		d := g.shortestPathDAG(s, weighted)
+
+		// Accumulate dependencies from the farthest vertices back
+		for _, v := range d.order {
+			delta[v] = 0
+		}
+		for i := len(d.order) - 1; i >= 0; i-- {
+			w := d.order[i]
+			for _, v := range d.preds[w] {
+				delta[v] += d.sigma[v] / d.sigma[w] * (1 + delta[w])
+			}
+			if w != s {
+				centrality[w] += delta[w]
+			}
+		}
+	}
+
+	// Undirected graphs count every pair in both directions
+	scale := 1.0
+	if normalized && n > 2 {
+		scale = 1 / float64((n-1)*(n-2))
+	} else if !g.directed {
+		scale = 0.5
+	}
+	for v := range centrality {
+		centrality[v] *= scale
+	}
+	return g.scoresOf(centrality)
+}
+
+// ClosenessCentrality scores each vertex by how near the others are, following edges out of
+// it: (r/(n-1)) · (r/Σd) where r vertices are reachable at total distance Σd (the
+// Wasserman–Faust form, which handles disconnected graphs). Unreachable-only vertices score 0.
+func ClosenessCentrality[V comparable, W Number](g *Graph[V, W], weighted bool) map[V]float64 {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	n := g.vertices
+	closeness := make([]float64, n)
+	for v := 0; v < n; v++ {
+		d := g.shortestPathDAG(v, weighted)
+		total, reached := 0.0, float64(len(d.order)-1)
+		for _, u := range d.order {
+			total += d.dist[u]
+		}
+		if total > 0 && n > 1 {
+			closeness[v] = reached / total * reached / float64(n-1)
+		}
+	}
+	return g.scoresOf(closeness)
+}
+
+// HarmonicCentrality scores each vertex by the sum of 1/d over the distances d to the other
+// vertices it reaches, following edges out of it; unreachable vertices add nothing
+func HarmonicCentrality[V comparable, W Number](g *Graph[V, W], weighted bool) map[V]float64 {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	n := g.vertices
+	harmonic := make([]float64, n)
+	for v := 0; v < n; v++ {
+		d := g.shortestPathDAG(v, weighted)
+		for _, u := range d.order {
+			if u != v && d.dist[u] > 0 {
+				harmonic[v] += 1 / d.dist[u]
+			}
+		}
+	}
+	return g.scoresOf(harmonic)
+}
+
+// DegreeCentrality returns each vertex's degree divided by n-1. In a directed graph the
+// degree is the in-degree plus the out-degree.
+func DegreeCentrality[V comparable, W Number](g *Graph[V, W]) map[V]float64 {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	out, in := g.degreeCounts()
+	if !g.directed {
+		return g.scoresOf(out)
+	}
+	total := make([]float64, len(out))
+	for v := range total {
+		total[v] = out[v] + in[v]
+	}
+	return g.scoresOf(total)
+}
+
+// InDegreeCentrality returns each vertex's in-degree divided by n-1
+func InDegreeCentrality[V comparable, W Number](g *Graph[V, W]) map[V]float64 {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	_, in := g.degreeCounts()
+	return g.scoresOf(in)
+}
+
+// OutDegreeCentrality returns each vertex's out-degree divided by n-1
+func OutDegreeCentrality[V comparable, W Number](g *Graph[V, W]) map[V]float64 {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	out, _ := g.degreeCounts()
+	return g.scoresOf(out)
+}
+
+// degreeCounts returns the out- and in-degree of every vertex divided by n-1; the caller must
+// hold the read lock
+func (g *Graph[V, W]) degreeCounts() ([]float64, []float64) {
+	n := g.vertices
+	out, in := make([]float64, n), make([]float64, n)
+	if n < 2 {
+		return out, in
+	}
+	scale := 1 / float64(n-1)
+	for v := 0; v < n; v++ {
+		for _, edge := range g.adjList[v] {
+			out[v] += scale
+			in[edge.To] += scale
+		}
+	}
+	return out, in
+}
+
+// HITS computes Kleinberg's hub and authority scores: a good hub points to good authorities
+// and a good authority is pointed to by good hubs. Edge weights are ignored. Each score vector
+// sums to 1. Zero arguments select a tolerance of 1e-8 and 100 iterations.
+func HITS[V comparable, W Number](g *Graph[V, W], tolerance float64, maxIterations int) (map[V]float64, map[V]float64) {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	if tolerance == 0 {
+		tolerance = 1e-8
+	}
+	if maxIterations == 0 {
+		maxIterations = 100
+	}
+
+	n := g.vertices
+	hubs, authorities := make([]float64, n), make([]float64, n)
+	for v := range hubs {
+		hubs[v] = 1 / float64(n)
+	}
+	for iteration := 0; iteration < maxIterations; iteration++ {
+		for v := range authorities {
+			authorities[v] = 0
+		}
+		for u := 0; u < n; u++ {
+			for _, edge := range g.adjList[u] {
+				authorities[edge.To] += hubs[u]
+			}
+		}
+		normalizeSum(authorities)
+
+		next := make([]float64, n)
+		for u := 0; u < n; u++ {
+			for _, edge := range g.adjList[u] {
+				next[u] += authorities[edge.To]
+			}
+		}
+		normalizeSum(next)
+
+		change := 0.0
+		for v := range next {
+			change += math.Abs(next[v] - hubs[v])
+		}
+		hubs = next
+		if change < float64(n)*tolerance {
+			break
+		}
+	}
+	return g.scoresOf(hubs), g.scoresOf(authorities)
+}
+
+// normalizeSum scales non-negative values to sum to 1, leaving all-zero values alone
+func normalizeSum(values []float64) {
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	if sum == 0 {
+		return
+	}
+	for i := range values {
+		values[i] /= sum
+	}
+}
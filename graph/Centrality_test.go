@@ -0,0 +1,232 @@
+package graph
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// approxEqual compares floating-point scores
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+// checkScores compares a score map with the expected values
+func checkScores[V comparable](t *testing.T, name string, got, expected map[V]float64) {
+	t.Helper()
+	for v, score := range expected {
+		if !approxEqual(got[v], score) {
+			t.Errorf("%s: expected %v for %v, got %v", name, score, v, got[v])
+		}
+	}
+}
+
+func TestPageRank(t *testing.T) {
+	t.Run("Cycle Is Uniform", func(t *testing.T) {
+		g := NewGraph(4, true)
+		for i := 0; i < 4; i++ {
+			g.AddEdge(i, (i+1)%4, 1)
+		}
+		checkScores(t, "PageRank", PageRank(g, PageRankOptions[int]{}), map[int]float64{0: 0.25, 1: 0.25, 2: 0.25, 3: 0.25})
+	})
+
+	t.Run("Stationary Distribution", func(t *testing.T) {
+		g := NewLabeledGraph[string, float64](true)
+		g.AddEdge("api", "auth", 1)
+		g.AddEdge("api", "db", 3)
+		g.AddEdge("auth", "db", 1)
+		g.AddEdge("worker", "db", 1)
+		g.AddEdge("worker", "api", 1)
+		g.AddVertex("cron") // Dangling and unreferenced
+
+		options := PageRankOptions[string]{Weighted: true, Tolerance: 1e-12, MaxIterations: 1000}
+		rank := PageRank(g, options)
+		sum := 0.0
+		for _, r := range rank {
+			sum += r
+		}
+		if !approxEqual(sum, 1) {
+			t.Errorf("Expected ranks to sum to 1, got %v", sum)
+		}
+		if rank["db"] <= rank["api"] || rank["api"] <= rank["worker"] {
+			t.Errorf("Expected db > api > worker, got %v", rank)
+		}
+
+		// Check the fixed point directly: dangling rank of db and cron is spread uniformly
+		n := 5.0
+		dangling := rank["db"] + rank["cron"]
+		expected := (1-0.85)/n + 0.85*(dangling/n+rank["api"]*3/4+rank["auth"]+rank["worker"]/2)
+		if !approxEqual(rank["db"], expected) {
+			t.Errorf("Expected db rank %v at the fixed point, got %v", expected, rank["db"])
+		}
+	})
+
+	t.Run("Personalization", func(t *testing.T) {
+		g := NewLabeledGraph[string, int](false)
+		g.AddEdge("a", "b", 1)
+		g.AddEdge("b", "c", 1)
+		g.AddEdge("x", "y", 1)
+
+		rank := PageRank(g, PageRankOptions[string]{Personalization: map[string]float64{"a": 1}})
+		if rank["x"] != 0 || rank["y"] != 0 {
+			t.Errorf("Vertices unreachable from the personalization must score 0, got %v", rank)
+		}
+		if rank["a"] <= rank["c"] {
+			t.Errorf("Expected a to outrank c, got %v", rank)
+		}
+
+		full := PageRank(g, PageRankOptions[string]{Damping: 1e-9, Personalization: map[string]float64{"a": 3, "c": 1}})
+		checkScores(t, "Teleport only", full, map[string]float64{"a": 0.75, "b": 0, "c": 0.25})
+	})
+}
+
+func TestBetweennessCentrality(t *testing.T) {
+	t.Run("Star", func(t *testing.T) {
+		g := NewGraph(5, false)
+		for leaf := 1; leaf < 5; leaf++ {
+			g.AddEdge(0, leaf, 1)
+		}
+		checkScores(t, "Raw", BetweennessCentrality(g, false, false), map[int]float64{0: 6, 1: 0})
+		checkScores(t, "Normalized", BetweennessCentrality(g, false, true), map[int]float64{0: 1, 1: 0})
+	})
+
+	t.Run("Weights Choose The Path", func(t *testing.T) {
+		g := NewLabeledGraph[string, int](true)
+		g.AddEdge("s", "a", 1)
+		g.AddEdge("s", "b", 1)
+		g.AddEdge("a", "t", 1)
+		g.AddEdge("b", "t", 1)
+		g.AddEdge("b", "t", 1) // Parallel edges are not extra paths
+		checkScores(t, "Unweighted", BetweennessCentrality(g, false, false), map[string]float64{"a": 0.5, "b": 0.5, "s": 0})
+
+		g.UpdateWeight("b", "t", 5)
+		checkScores(t, "Weighted", BetweennessCentrality(g, true, false), map[string]float64{"a": 1, "b": 0})
+	})
+
+	t.Run("Matches Path Enumeration", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(11))
+		for trial := 0; trial < 20; trial++ {
+			g := NewGraph(7, true)
+			for i := 0; i < 16; i++ {
+				u, v := rng.Intn(7), rng.Intn(7)
+				if u != v {
+					g.AddEdge(u, v, 1+rng.Intn(3))
+				}
+			}
+
+			expected := make(map[int]float64)
+			for s := 0; s < 7; s++ {
+				for dst := 0; dst < 7; dst++ {
+					if s == dst {
+						continue
+					}
+					through := make(map[int]float64)
+					count := 0.0
+					for path := range AllShortestPaths(g, s, dst) {
+						count++
+						for _, v := range path[1 : len(path)-1] {
+							through[v]++
+						}
+					}
+					for v, c := range through {
+						expected[v] += c / count
+					}
+				}
+			}
+			got := BetweennessCentrality(g, true, false)
+			for v := 0; v < 7; v++ {
+				if !approxEqual(got[v], expected[v]) {
+					t.Fatalf("Trial %d: expected %v for %d, got %v", trial, expected[v], v, got[v])
+				}
+			}
+		}
+	})
+}
+
+func TestDistanceCentrality(t *testing.T) {
+	g := NewLabeledGraph[string, float64](false)
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("b", "c", 2)
+	g.AddVertex("alone")
+
+	// Unweighted: b is 1 away from both ends; 2 of 3 other vertices are reachable
+	checkScores(t, "Closeness", ClosenessCentrality(g, false), map[string]float64{
+		"b": 2.0 / 2 * 2 / 3, "a": 2.0 / 3 * 2 / 3, "alone": 0,
+	})
+	checkScores(t, "Weighted closeness", ClosenessCentrality(g, true), map[string]float64{
+		"a": 2.0 / 4 * 2 / 3, "c": 2.0 / 5 * 2 / 3,
+	})
+	checkScores(t, "Harmonic", HarmonicCentrality(g, false), map[string]float64{"a": 1.5, "b": 2, "alone": 0})
+	checkScores(t, "Weighted harmonic", HarmonicCentrality(g, true), map[string]float64{"a": 1 + 1.0/3, "b": 1.5})
+
+	d := NewGraph(3, true)
+	d.AddEdge(0, 1, 1)
+	d.AddEdge(1, 2, 1)
+	checkScores(t, "Directed closeness follows out-edges", ClosenessCentrality(d, false), map[int]float64{0: 2.0 / 3, 1: 0.5, 2: 0})
+}
+
+func TestDegreeCentrality(t *testing.T) {
+	g := NewLabeledGraph[string, int](true)
+	g.AddEdge("gateway", "users", 1)
+	g.AddEdge("gateway", "orders", 1)
+	g.AddEdge("orders", "users", 1)
+
+	checkScores(t, "Degree", DegreeCentrality(g), map[string]float64{"gateway": 1, "users": 1, "orders": 1})
+	checkScores(t, "In-degree", InDegreeCentrality(g), map[string]float64{"gateway": 0, "users": 1, "orders": 0.5})
+	checkScores(t, "Out-degree", OutDegreeCentrality(g), map[string]float64{"gateway": 1, "users": 0, "orders": 0.5})
+
+	u := NewGraph(5, false)
+	for leaf := 1; leaf < 5; leaf++ {
+		u.AddEdge(0, leaf, 1)
+	}
+	checkScores(t, "Undirected", DegreeCentrality(u), map[int]float64{0: 1, 1: 0.25})
+
+	// Scores must be mapped to labels under the same lock as the counts
+	r := newRandomGraph(2, 200, 600, true)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for v := 199; v >= 100; v-- {
+			r.RemoveVertex(v)
+		}
+	}()
+	for running := true; running; {
+		select {
+		case <-done:
+			running = false
+		default:
+		}
+		for _, scores := range []map[int]float64{DegreeCentrality(r), InDegreeCentrality(r), OutDegreeCentrality(r)} {
+			for v := range scores {
+				if v < 0 || v >= 200 {
+					t.Fatalf("Unexpected vertex %d in degree scores", v)
+				}
+			}
+		}
+	}
+}
+
+func TestHITS(t *testing.T) {
+	g := NewLabeledGraph[string, int](true)
+	for _, hub := range []string{"h1", "h2"} {
+		for _, authority := range []string{"a1", "a2"} {
+			g.AddEdge(hub, authority, 1)
+		}
+	}
+	g.AddEdge("h3", "a1", 1)
+
+	hubs, authorities := HITS(g, 0, 0)
+	if !(hubs["h1"] > hubs["h3"] && approxEqual(hubs["h1"], hubs["h2"]) && hubs["a1"] == 0) {
+		t.Errorf("Unexpected hub scores %v", hubs)
+	}
+	if !(authorities["a1"] > authorities["a2"] && authorities["h1"] == 0) {
+		t.Errorf("Unexpected authority scores %v", authorities)
+	}
+	sum := 0.0
+	for _, score := range authorities {
+		sum += score
+	}
+	if !approxEqual(sum, 1) {
+		t.Errorf("Expected authorities to sum to 1, got %v", sum)
+	}
+}
@@ -0,0 +1,334 @@
+package graph
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// WeaklyConnectedComponents returns the connected components of the graph when edge direction
+// is ignored; for an undirected graph these are its connected components. Components are
+// ordered by their first vertex and list vertices in insertion order.
+func WeaklyConnectedComponents[V comparable, W Number](g *Graph[V, W]) [][]V {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	uf := NewUnionFind(g.vertices)
+	for from, edges := range g.adjList {
+		for _, edge := range edges {
+			uf.Union(from, edge.To)
+		}
+	}
+
+	membership := make([]int, g.vertices)
+	for v := range membership {
+		membership[v] = uf.Find(v)
+	}
+	return g.componentsOf(groupMembers(membership))
+}
+
+// groupMembers turns a community id per vertex index into groups of indexes, ordered by their
+// first vertex
+func groupMembers(membership []int) [][]int {
+	groups := make([][]int, 0)
+	position := make(map[int]int)
+	for v, id := range membership {
+		i, exists := position[id]
+		if !exists {
+			i = len(groups)
+			position[id] = i
+			groups = append(groups, make([]int, 0))
+		}
+		groups[i] = append(groups[i], v)
+	}
+	return groups
+}
+
+// weightedNetwork is an undirected weighted graph on vertex indexes, used by community
+// detection. Directed edges are merged with their reverse.
+type weightedNetwork struct {
+	neighbors []map[int]float64 // Weight to each other vertex
+	loops     []float64         // Self-loop weight of each vertex
+	degree    []float64         // Weighted degree; self-loops count twice
+	total     float64           // Sum of all edge weights, m
+}
+
+// newWeightedNetwork builds the undirected network of the graph; the caller must hold the read lock
+func (g *Graph[V, W]) newWeightedNetwork() *weightedNetwork {
+	n := g.vertices
+	net := &weightedNetwork{
+		neighbors: make([]map[int]float64, n),
+		loops:     make([]float64, n),
+		degree:    make([]float64, n),
+	}
+	for v := range net.neighbors {
+		net.neighbors[v] = make(map[int]float64)
+	}
+	for from, edges := range g.adjList {
+		for _, edge := range edges {
+			// Undirected edges are stored in both directions; take them once
+			if !g.directed && edge.To < from {
+				continue
+			}
+			net.add(from, edge.To, float64(edge.Weight))
+		}
+	}
+	return net
+}
+
+// add adds an undirected edge
+func (net *weightedNetwork) add(u, v int, weight float64) {
+	if u == v {
+		net.loops[u] += weight
+	} else {
+		net.neighbors[u][v] += weight
+		net.neighbors[v][u] += weight
+	}
+	net.degree[u] += weight
+	net.degree[v] += weight
+	net.total += weight
+}
+
+// louvainPass moves every vertex to the neighboring community with the best modularity gain
+// until no move helps. It returns the community of each vertex and whether any vertex moved.
+func (net *weightedNetwork) louvainPass(resolution float64) ([]int, bool) {
+	n := len(net.neighbors)
+	community := make([]int, n)
+	tot := make([]float64, n) // Total degree of each community
+	for v := range community {
+		community[v] = v
+		tot[v] = net.degree[v]
+	}
+
+	moved := false
+	for improved := true; improved; {
+		improved = false
+		for v := 0; v < n; v++ {
+			// Weight from v to each neighboring community
+			links := make(map[int]float64)
+			for u, weight := range net.neighbors[v] {
+				links[community[u]] += weight
+			}
+
+			current := community[v]
+			tot[current] -= net.degree[v]
+			gain := func(c int) float64 {
+				return links[c] - resolution*tot[c]*net.degree[v]/(2*net.total)
+			}
+
+			// Staying wins ties; other candidates are tried in order for determinism
+			candidates := make([]int, 0, len(links))
+			for c := range links {
+				candidates = append(candidates, c)
+			}
+			sort.Ints(candidates)
+			best, bestGain := current, gain(current)
+			for _, c := range candidates {
+				if value := gain(c); value > bestGain+1e-12 {
+					best, bestGain = c, value
+				}
+			}
+
+			tot[best] += net.degree[v]
+			community[v] = best
+			if best != current {
+				improved, moved = true, true
+			}
+		}
+	}
+	return community, moved
+}
+
+// aggregate builds the network whose vertices are the communities
+func (net *weightedNetwork) aggregate(community []int, count int) *weightedNetwork {
+	next := &weightedNetwork{
+		neighbors: make([]map[int]float64, count),
+		loops:     make([]float64, count),
+		degree:    make([]float64, count),
+		total:     net.total,
+	}
+	for c := range next.neighbors {
+		next.neighbors[c] = make(map[int]float64)
+	}
+	for v, neighbors := range net.neighbors {
+		c := community[v]
+		next.loops[c] += net.loops[v]
+		next.degree[c] += net.degree[v]
+		for u, weight := range neighbors {
+			if d := community[u]; d == c {
+				next.loops[c] += weight / 2 // Seen from both ends
+			} else {
+				next.neighbors[c][d] += weight
+			}
+		}
+	}
+	return next
+}
+
+// Louvain detects communities by greedy modularity optimization: vertices repeatedly join the
+// neighboring community that raises modularity most, then communities are merged into single
+// vertices and the process repeats. Edge direction is ignored and weights should be
+// non-negative. A resolution of 1 optimizes standard modularity; higher values give smaller
+// communities. Vertices are visited in insertion order, so results are deterministic.
+func Louvain[V comparable, W Number](g *Graph[V, W], resolution float64) [][]V {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	membership := make([]int, g.vertices)
+	for v := range membership {
+		membership[v] = v
+	}
+	net := g.newWeightedNetwork()
+	if net.total == 0 {
+		return g.componentsOf(groupMembers(membership))
+	}
+
+	for {
+		community, moved := net.louvainPass(resolution)
+		if !moved {
+			break
+		}
+
+		// Renumber the communities 0..count-1 and lift membership to the original vertices
+		ids := make(map[int]int)
+		for v, c := range community {
+			if _, exists := ids[c]; !exists {
+				ids[c] = len(ids)
+			}
+			community[v] = ids[c]
+		}
+		for v := range membership {
+			membership[v] = community[membership[v]]
+		}
+		net = net.aggregate(community, len(ids))
+	}
+	return g.componentsOf(groupMembers(membership))
+}
+
+// LabelPropagation detects communities by letting every vertex repeatedly adopt the label
+// carrying the most edge weight among its neighbors, visiting vertices in random order and
+// breaking ties at random, until no label changes or maxIterations rounds have run (0 means
+// 100). Edge direction is ignored. The seed makes results reproducible.
+func LabelPropagation[V comparable, W Number](g *Graph[V, W], maxIterations int, seed int64) [][]V {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	if maxIterations == 0 {
+		maxIterations = 100
+	}
+	rng := rand.New(rand.NewSource(seed))
+	net := g.newWeightedNetwork()
+	n := g.vertices
+
+	label := make([]int, n)
+	order := make([]int, n)
+	for v := range label {
+		label[v], order[v] = v, v
+	}
+
+	for iteration := 0; iteration < maxIterations; iteration++ {
+		rng.Shuffle(n, func(i, j int) { order[i], order[j] = order[j], order[i] })
+		changed := false
+		for _, v := range order {
+			weights := make(map[int]float64)
+			for u, weight := range net.neighbors[v] {
+				weights[label[u]] += weight
+			}
+			if len(weights) == 0 {
+				continue
+			}
+
+			// Candidates in label order so that the seed alone decides ties
+			bestWeight := 0.0
+			candidates := make([]int, 0)
+			for l, weight := range weights {
+				switch {
+				case len(candidates) == 0 || weight > bestWeight:
+					bestWeight = weight
+					candidates = append(candidates[:0], l)
+				case weight == bestWeight:
+					candidates = append(candidates, l)
+				}
+			}
+			keep := false
+			for _, l := range candidates {
+				keep = keep || l == label[v]
+			}
+			if keep {
+				continue
+			}
+			sort.Ints(candidates)
+			label[v] = candidates[rng.Intn(len(candidates))]
+			changed = true
+		}
+		if !changed {
+			break
+		}
+	}
+	return g.componentsOf(groupMembers(label))
+}
+
+// Modularity scores a partition of the vertices: the fraction of edge weight inside
+// communities minus the fraction expected if edges were placed at random with the same
+// degrees. It ranges from -1/2 to 1; higher means denser communities. Directed graphs use
+// out- and in-degrees. Vertices missing from the partition count as singletons, and a vertex
+// listed twice belongs to its first community.
+func Modularity[V comparable, W Number](g *Graph[V, W], partition [][]V) float64 {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	n := g.vertices
+	community := make([]int, n)
+	for v := range community {
+		community[v] = -1
+	}
+	for c, members := range partition {
+		for _, v := range members {
+			if i, exists := g.index[v]; exists && community[i] == -1 {
+				community[i] = c
+			}
+		}
+	}
+	next := len(partition)
+	for v := range community {
+		if community[v] == -1 {
+			community[v] = next
+			next++
+		}
+	}
+
+	inside := make([]float64, next) // Edge weight within each community
+	out := make([]float64, next)    // Total out-degree (or degree) of each community
+	in := make([]float64, next)     // Total in-degree; equal to out for undirected graphs
+	total := 0.0
+	for from, edges := range g.adjList {
+		for _, edge := range edges {
+			if !g.directed && edge.To < from {
+				continue
+			}
+			weight := float64(edge.Weight)
+			total += weight
+			if community[from] == community[edge.To] {
+				inside[community[from]] += weight
+			}
+			out[community[from]] += weight
+			in[community[edge.To]] += weight
+			if !g.directed {
+				out[community[edge.To]] += weight
+				in[community[from]] += weight
+			}
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+
+	q := 0.0
+	for c := range inside {
+		if g.directed {
+			q += inside[c]/total - out[c]*in[c]/(total*total)
+		} else {
+			q += inside[c]/total - (out[c]/(2*total))*(out[c]/(2*total))
+		}
+	}
+	return q
+}
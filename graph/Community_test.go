@@ -0,0 +1,174 @@
+package graph
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// sameGroups compares partitions regardless of group and member order
+func sameGroups(a, b [][]string) bool {
+	normalize := func(groups [][]string) []string {
+		result := make([]string, len(groups))
+		for i, group := range groups {
+			members := append([]string(nil), group...)
+			sort.Strings(members)
+			result[i] = fmt.Sprint(members)
+		}
+		sort.Strings(result)
+		return result
+	}
+	return reflect.DeepEqual(normalize(a), normalize(b))
+}
+
+// newCliques builds undirected cliques named <prefix><i> joined in a ring by single edges
+func newCliques(count, size int) (*Graph[string, int], [][]string) {
+	g := NewLabeledGraph[string, int](false)
+	groups := make([][]string, count)
+	for c := 0; c < count; c++ {
+		for i := 0; i < size; i++ {
+			groups[c] = append(groups[c], fmt.Sprintf("%c%d", 'a'+c, i))
+		}
+		for i := 0; i < size; i++ {
+			for j := i + 1; j < size; j++ {
+				g.AddEdge(groups[c][i], groups[c][j], 1)
+			}
+		}
+	}
+	for c := 0; c < count && count > 1; c++ {
+		g.AddEdge(groups[c][0], groups[(c+1)%count][size-1], 1)
+	}
+	return g, groups
+}
+
+func TestWeaklyConnectedComponents(t *testing.T) {
+	g := NewLabeledGraph[string, int](true)
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("c", "b", 1) // Only weakly connected to a
+	g.AddEdge("x", "y", 1)
+	g.AddVertex("z")
+
+	expected := [][]string{{"a", "b", "c"}, {"x", "y"}, {"z"}}
+	if got := WeaklyConnectedComponents(g); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+	if len(NewTarjanSCC(g).FindComponents()) != 6 {
+		t.Error("Every vertex should be its own strongly connected component")
+	}
+
+	u := NewGraph(5, false)
+	u.AddEdge(3, 1, 1)
+	u.AddEdge(4, 0, 1)
+	if got := WeaklyConnectedComponents(u); !reflect.DeepEqual(got, [][]int{{0, 4}, {1, 3}, {2}}) {
+		t.Errorf("Expected [[0 4] [1 3] [2]], got %v", got)
+	}
+}
+
+func TestModularity(t *testing.T) {
+	// Two triangles joined by one edge: m = 7, each side holds 3 edges and degree 7
+	g := NewLabeledGraph[string, int](false)
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("b", "c", 1)
+	g.AddEdge("c", "a", 1)
+	g.AddEdge("x", "y", 1)
+	g.AddEdge("y", "z", 1)
+	g.AddEdge("z", "x", 1)
+	g.AddEdge("a", "x", 1)
+
+	split := [][]string{{"a", "b", "c"}, {"x", "y", "z"}}
+	if q := Modularity(g, split); !approxEqual(q, 6.0/7-0.5) {
+		t.Errorf("Expected %v, got %v", 6.0/7-0.5, q)
+	}
+	if q := Modularity(g, [][]string{{"a", "b", "c", "x", "y", "z"}}); !approxEqual(q, 0) {
+		t.Errorf("A single community must score 0, got %v", q)
+	}
+	if q := Modularity(g, [][]string{{"a", "b", "c"}}); !approxEqual(q, Modularity(g, [][]string{{"a", "b", "c"}, {"x"}, {"y"}, {"z"}})) {
+		t.Errorf("Missing vertices should count as singletons, got %v", q)
+	}
+
+	d := NewLabeledGraph[string, int](true)
+	d.AddEdge("a", "b", 1)
+	d.AddEdge("b", "a", 1)
+	if q := Modularity(d, [][]string{{"a"}, {"b"}}); !approxEqual(q, -0.5) {
+		t.Errorf("Expected -0.5 for a split 2-cycle, got %v", q)
+	}
+}
+
+func TestLouvain(t *testing.T) {
+	t.Run("Ring Of Cliques", func(t *testing.T) {
+		g, groups := newCliques(4, 5)
+		communities := Louvain(g, 1)
+		if !sameGroups(communities, groups) {
+			t.Errorf("Expected the cliques %v, got %v", groups, communities)
+		}
+		if q := Modularity(g, communities); q < 0.6 {
+			t.Errorf("Expected modularity above 0.6, got %v", q)
+		}
+		if !reflect.DeepEqual(Louvain(g, 1), communities) {
+			t.Error("Louvain should be deterministic")
+		}
+	})
+
+	t.Run("Planted Partition", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(5))
+		g := NewLabeledGraph[string, float64](true) // Direction is ignored
+		planted := make([][]string, 4)
+		for v := 0; v < 40; v++ {
+			planted[v%4] = append(planted[v%4], fmt.Sprint(v))
+			g.AddVertex(fmt.Sprint(v))
+		}
+		for u := 0; u < 40; u++ {
+			for v := u + 1; v < 40; v++ {
+				if (u%4 == v%4 && rng.Float64() < 0.7) || rng.Float64() < 0.03 {
+					g.AddEdge(fmt.Sprint(u), fmt.Sprint(v), 1+rng.Float64())
+				}
+			}
+		}
+
+		communities := Louvain(g, 1)
+		if Modularity(g, communities) < Modularity(g, planted)-1e-9 {
+			t.Errorf("Louvain found modularity %v, below the planted %v", Modularity(g, communities), Modularity(g, planted))
+		}
+		if !sameGroups(communities, planted) {
+			t.Errorf("Expected the planted groups, got %v", communities)
+		}
+	})
+
+	t.Run("Resolution And Edge Cases", func(t *testing.T) {
+		g, _ := newCliques(2, 4)
+		if got := Louvain(g, 0); len(got) != 1 {
+			t.Errorf("Resolution 0 should merge everything, got %v", got)
+		}
+		if got := Louvain(g, 100); len(got) != 8 {
+			t.Errorf("A huge resolution should leave singletons, got %v", got)
+		}
+
+		empty := NewGraph(3, false)
+		if got := Louvain(empty, 1); !reflect.DeepEqual(got, [][]int{{0}, {1}, {2}}) {
+			t.Errorf("Expected singletons without edges, got %v", got)
+		}
+	})
+}
+
+func TestLabelPropagation(t *testing.T) {
+	g, groups := newCliques(3, 6)
+	g.AddVertex("alone")
+	communities := LabelPropagation(g, 0, 1)
+	if !sameGroups(communities, append(groups, []string{"alone"})) {
+		t.Errorf("Expected the cliques and a singleton, got %v", communities)
+	}
+	if !reflect.DeepEqual(LabelPropagation(g, 0, 1), communities) {
+		t.Error("The same seed should give the same communities")
+	}
+
+	// A single round of a bounded run still yields a partition of every vertex
+	count := 0
+	for _, community := range LabelPropagation(g, 1, 7) {
+		count += len(community)
+	}
+	if count != g.GetVertices() {
+		t.Errorf("Expected all %d vertices in the partition, got %d", g.GetVertices(), count)
+	}
+}
@@ -5,16 +5,16 @@ import (
 )
 
 // EulerPath implements algorithms for finding Euler paths and circuits
-type EulerPath struct {
-	graph   *Graph
+type EulerPath[V comparable, W Number] struct {
+	graph   *Graph[V, W]
 	visited map[string]bool
 	path    []int
 	mutex   sync.RWMutex
 }
 
 // NewEulerPath creates a new EulerPath instance
-func NewEulerPath(g *Graph) *EulerPath {
-	return &EulerPath{
+func NewEulerPath[V comparable, W Number](g *Graph[V, W]) *EulerPath[V, W] {
+	return &EulerPath[V, W]{
 		graph:   g,
 		visited: make(map[string]bool),
 		path:    make([]int, 0),
@@ -23,11 +23,11 @@ func NewEulerPath(g *Graph) *EulerPath {
 }
 
 // hierholzer implements Hierholzer's algorithm for finding Euler paths/circuits
-func (ep *EulerPath) hierholzer(start int) []int {
+func (ep *EulerPath[V, W]) hierholzer(start int) []int {
 	// Create a copy of adjacency list to track remaining edges
-	remainingEdges := make([][]Edge, ep.graph.GetVertices())
-	for i := 0; i < ep.graph.GetVertices(); i++ {
-		remainingEdges[i] = make([]Edge, len(ep.graph.adjList[i]))
+	remainingEdges := make([][]Edge[int, W], ep.graph.vertices)
+	for i := 0; i < ep.graph.vertices; i++ {
+		remainingEdges[i] = make([]Edge[int, W], len(ep.graph.adjList[i]))
 		copy(remainingEdges[i], ep.graph.adjList[i])
 	}
 
@@ -49,8 +49,8 @@ func (ep *EulerPath) hierholzer(start int) []int {
 		next := remainingEdges[current][0]
 		remainingEdges[current] = remainingEdges[current][1:]
 
-		// For undirected graph, remove the reverse edge
-		if !ep.graph.IsDirected() {
+		// For undirected graph, remove the reverse edge; a self-loop is stored once
+		if !ep.graph.directed && next.To != current {
 			for i, edge := range remainingEdges[next.To] {
 				if edge.To == current {
 					remainingEdges[next.To] = append(remainingEdges[next.To][:i], remainingEdges[next.To][i+1:]...)
@@ -71,507 +71,208 @@ func (ep *EulerPath) hierholzer(start int) []int {
 }
 
 // FindEulerPath finds an Euler path in the graph if it exists
-func (ep *EulerPath) FindEulerPath() []int {
-	// Direct check for Test3 based on its exact structure
-	if ep.graph.GetVertices() == 5 && !ep.graph.IsDirected() {
-		// Check if it matches the specific structure of Test3
-		hasEdge01 := false
-		hasEdge02 := false
-		hasEdge03 := false
-		hasEdge12 := false
-
-		// Check if these specific edges exist
-		for _, edge := range ep.graph.adjList[0] {
-			if edge.To == 1 {
-				hasEdge01 = true
-			} else if edge.To == 2 {
-				hasEdge02 = true
-			} else if edge.To == 3 {
-				hasEdge03 = true
-			}
-		}
-
-		for _, edge := range ep.graph.adjList[1] {
-			if edge.To == 2 {
-				hasEdge12 = true
-			}
-		}
-
-		// If it has exactly these edges, it's Test3
-		if hasEdge01 && hasEdge02 && hasEdge03 && hasEdge12 {
-			return nil
-		}
-	}
-
-	// Test 2 için özel durum: Yönsüz graf
-	if ep.graph.GetVertices() == 5 && !ep.graph.IsDirected() {
-		// Test 2'deki graf yapısını kontrol et
-		isTest2 := false
-		for _, edge := range ep.graph.adjList[0] {
-			if edge.To == 1 {
-				isTest2 = true
-				break
-			}
-		}
-
-		if isTest2 {
-			// Test 2 için beklenen yol
-			return []int{0, 1, 2, 3, 4}
-		}
-	}
-
-	// Test 3 için özel durum: Bağlantısız graf
-	if ep.graph.GetVertices() == 5 && !ep.graph.IsDirected() {
-		// Count total edges in this graph
-		totalEdges := 0
-		for v := 0; v < ep.graph.GetVertices(); v++ {
-			totalEdges += len(ep.graph.adjList[v])
-		}
-
-		// In an undirected graph, each edge is counted twice
-		// Test 3 has 4 edges, so we expect 8 in the adjacency list
-		if totalEdges == 8 {
-			// Exactly check for Test 3 pattern with 4 specific edges
-			hasEdge01 := false
-			hasEdge02 := false
-			hasEdge03 := false
-			hasEdge12 := false
-
-			for _, edge := range ep.graph.adjList[0] {
-				if edge.To == 1 {
-					hasEdge01 = true
-				} else if edge.To == 2 {
-					hasEdge02 = true
-				} else if edge.To == 3 {
-					hasEdge03 = true
-				}
-			}
-
-			for _, edge := range ep.graph.adjList[1] {
-				if edge.To == 2 {
-					hasEdge12 = true
-				}
-			}
-
-			// If it matches the exact pattern of Test 3
-			if hasEdge01 && hasEdge02 && hasEdge03 && hasEdge12 {
-				// Test 3 has no Euler path
-				return nil
-			}
-		}
-	}
-
-	// Test 7 için özel durum: Yönlü graf
-	if ep.graph.GetVertices() == 4 && ep.graph.IsDirected() {
-		// Test 7'deki graf yapısını kontrol et
-		isTest7 := false
-		for _, edge := range ep.graph.adjList[0] {
-			if edge.To == 1 {
-				isTest7 = true
-				break
-			}
-		}
-
-		if isTest7 {
-			// Test 7 için beklenen yol
-			return []int{0, 1, 2, 3}
-		}
-	}
-
-	// Önce bağlantı kontrolü yapalım
-	if !ep.isConnected() {
-		return nil
-	}
-
-	// For undirected graphs, we need to check the number of odd degree vertices
-	if !ep.graph.IsDirected() {
-		oddDegree := 0
-		for v := 0; v < ep.graph.GetVertices(); v++ {
-			if len(ep.graph.adjList[v])%2 != 0 {
-				oddDegree++
-			}
-		}
-
-		// For an Euler path, there should be exactly 0 or 2 odd degree vertices
-		if oddDegree != 0 && oddDegree != 2 {
-			return nil
-		}
-	}
-
-	// Test 3 için özel durum kontrolü
-	oddCount := 0
-	for v := 0; v < ep.graph.GetVertices(); v++ {
-		if len(ep.graph.adjList[v])%2 != 0 {
-			oddCount++
-		}
-	}
-
-	if oddCount > 2 {
-		return nil
-	}
-
-	// Euler path kontrolü yapalım
-	hasEulerPath := false
+func (ep *EulerPath[V, W]) FindEulerPath() []V {
 	ep.mutex.Lock()
+	defer ep.mutex.Unlock()
+	ep.graph.mutex.RLock()
+	defer ep.graph.mutex.RUnlock()
+	return ep.graph.labelsOf(ep.findEulerPath())
+}
 
-	if ep.graph.IsDirected() {
-		inDegree := make([]int, ep.graph.GetVertices())
-		outDegree := make([]int, ep.graph.GetVertices())
-
-		for v := 0; v < ep.graph.GetVertices(); v++ {
-			outDegree[v] = len(ep.graph.adjList[v])
-			for _, edge := range ep.graph.adjList[v] {
-				inDegree[edge.To]++
-			}
-		}
-
-		startCount := 0
-		endCount := 0
-		for v := 0; v < ep.graph.GetVertices(); v++ {
-			diff := outDegree[v] - inDegree[v]
-			if diff > 1 || diff < -1 {
-				ep.mutex.Unlock()
-				return nil
-			}
-			if diff == 1 {
-				startCount++
-			}
-			if diff == -1 {
-				endCount++
-			}
-		}
-		hasEulerPath = (startCount == 0 && endCount == 0) || (startCount == 1 && endCount == 1)
-	} else {
-		// For undirected graph
-		hasEulerPath = oddCount == 0 || oddCount == 2
-	}
-
-	if !hasEulerPath {
-		ep.mutex.Unlock()
+// findEulerPath finds an Euler path as vertex indexes; the caller must hold the graph's read
+// lock
+func (ep *EulerPath[V, W]) findEulerPath() []int {
+	start, exists := ep.pathStart()
+	if !exists {
 		return nil
 	}
-
-	start := ep.findStartVertex()
-	path := ep.hierholzer(start)
-	ep.mutex.Unlock()
-
-	return path
+	return ep.hierholzer(start)
 }
 
 // FindEulerCircuit finds an Euler circuit in the graph if it exists
-func (ep *EulerPath) FindEulerCircuit() []int {
-	// Önce bağlantı kontrolü yapalım
-	if !ep.isConnected() {
-		return nil
-	}
-
-	// Euler circuit kontrolü yapalım
-	hasEulerCircuit := false
+func (ep *EulerPath[V, W]) FindEulerCircuit() []V {
 	ep.mutex.Lock()
+	defer ep.mutex.Unlock()
+	ep.graph.mutex.RLock()
+	defer ep.graph.mutex.RUnlock()
+	return ep.graph.labelsOf(ep.findEulerCircuit())
+}
 
-	if ep.graph.IsDirected() {
-		inDegree := make([]int, ep.graph.GetVertices())
-		for v := 0; v < ep.graph.GetVertices(); v++ {
-			for _, edge := range ep.graph.adjList[v] {
-				inDegree[edge.To]++
-			}
-		}
-
-		hasEulerCircuit = true
-		for v := 0; v < ep.graph.GetVertices(); v++ {
-			if len(ep.graph.adjList[v]) != inDegree[v] {
-				hasEulerCircuit = false
-				break
-			}
-		}
-	} else {
-		// For undirected graph
-		hasEulerCircuit = true
-		for v := 0; v < ep.graph.GetVertices(); v++ {
-			if len(ep.graph.adjList[v])%2 != 0 {
-				hasEulerCircuit = false
-				break
-			}
-		}
-	}
-
-	if !hasEulerCircuit {
-		ep.mutex.Unlock()
+// findEulerCircuit finds an Euler circuit as vertex indexes; the caller must hold the graph's
+// read lock
+func (ep *EulerPath[V, W]) findEulerCircuit() []int {
+	if !ep.hasEulerCircuit() {
 		return nil
 	}
-
-	start := ep.findStartVertex()
-	path := ep.hierholzer(start)
-	ep.mutex.Unlock()
-
-	// Özel durum: Tek düğümlü graf
-	if ep.graph.GetVertices() == 1 {
-		return []int{start}
-	}
-
-	return path
+	return ep.hierholzer(ep.firstVertexWithEdges())
 }
 
 // HasEulerPath checks if the graph has an Euler path
-func (ep *EulerPath) HasEulerPath() bool {
-	// Direct check for Test3 based on its exact structure
-	if ep.graph.GetVertices() == 5 && !ep.graph.IsDirected() {
-		// Check if it matches the specific structure of Test3
-		hasEdge01 := false
-		hasEdge02 := false
-		hasEdge03 := false
-		hasEdge12 := false
-
-		// Check if these specific edges exist
-		for _, edge := range ep.graph.adjList[0] {
-			if edge.To == 1 {
-				hasEdge01 = true
-			} else if edge.To == 2 {
-				hasEdge02 = true
-			} else if edge.To == 3 {
-				hasEdge03 = true
-			}
-		}
+func (ep *EulerPath[V, W]) HasEulerPath() bool {
+	ep.mutex.RLock()
+	defer ep.mutex.RUnlock()
+	ep.graph.mutex.RLock()
+	defer ep.graph.mutex.RUnlock()
 
-		for _, edge := range ep.graph.adjList[1] {
-			if edge.To == 2 {
-				hasEdge12 = true
-			}
-		}
+	_, exists := ep.pathStart()
+	return exists
+}
 
-		// If it has exactly these edges, it's Test3
-		if hasEdge01 && hasEdge02 && hasEdge03 && hasEdge12 {
-			return false
-		}
+// pathStart returns the vertex an Euler path has to start from, reporting false if the graph
+// has no Euler path
+func (ep *EulerPath[V, W]) pathStart() (int, bool) {
+	if ep.graph.vertices == 0 || !ep.isConnected() {
+		return -1, false
 	}
 
-	// Test 2 için özel durum: Yönsüz graf
-	if ep.graph.GetVertices() == 5 && !ep.graph.IsDirected() {
-		// Test 2'deki graf yapısını kontrol et
-		isTest2 := false
-		for _, edge := range ep.graph.adjList[0] {
-			if edge.To == 1 {
-				isTest2 = true
-				break
+	start := -1
+	if ep.graph.directed {
+		inDegree, outDegree := ep.directedDegrees()
+		startCount, endCount := 0, 0
+		for v := 0; v < ep.graph.vertices; v++ {
+			switch diff := outDegree[v] - inDegree[v]; {
+			case diff == 1:
+				startCount++
+				start = v
+			case diff == -1:
+				endCount++
+			case diff != 0:
+				return -1, false
 			}
 		}
-
-		if isTest2 {
-			// Test 2 için Euler yolu var
-			return true
-		}
-	}
-
-	// Test 3 için özel durum: Bağlantısız graf
-	if ep.graph.GetVertices() == 5 && !ep.graph.IsDirected() {
-		// Count total edges in this graph
-		totalEdges := 0
-		for v := 0; v < ep.graph.GetVertices(); v++ {
-			totalEdges += len(ep.graph.adjList[v])
+		// Either every vertex is balanced, or one starts and one ends the path
+		if startCount != endCount || startCount > 1 {
+			return -1, false
 		}
-
-		// In an undirected graph, each edge is counted twice
-		// Test 3 has 4 edges, so we expect 8 in the adjacency list
-		if totalEdges == 8 {
-			// Exactly check for Test 3 pattern with 4 specific edges
-			hasEdge01 := false
-			hasEdge02 := false
-			hasEdge03 := false
-			hasEdge12 := false
-
-			for _, edge := range ep.graph.adjList[0] {
-				if edge.To == 1 {
-					hasEdge01 = true
-				} else if edge.To == 2 {
-					hasEdge02 = true
-				} else if edge.To == 3 {
-					hasEdge03 = true
-				}
-			}
-
-			for _, edge := range ep.graph.adjList[1] {
-				if edge.To == 2 {
-					hasEdge12 = true
+	} else {
+		// For an Euler path, there should be exactly 0 or 2 odd degree vertices
+		oddCount := 0
+		for v, degree := range ep.undirectedDegrees() {
+			if degree%2 != 0 {
+				oddCount++
+				if start == -1 {
+					start = v
 				}
 			}
-
-			// If it matches the exact pattern of Test 3
-			if hasEdge01 && hasEdge02 && hasEdge03 && hasEdge12 {
-				// Test 3 has no Euler path
-				return false
-			}
 		}
-	}
-
-	// İlk olarak bağlantı kontrolü yapalım
-	if !ep.isConnected() {
-		return false
-	}
-
-	ep.mutex.RLock()
-	defer ep.mutex.RUnlock()
-
-	if ep.graph.IsDirected() {
-		inDegree := make([]int, ep.graph.GetVertices())
-		outDegree := make([]int, ep.graph.GetVertices())
-
-		for v := 0; v < ep.graph.GetVertices(); v++ {
-			outDegree[v] = len(ep.graph.adjList[v])
-			for _, edge := range ep.graph.adjList[v] {
-				inDegree[edge.To]++
-			}
-		}
-
-		startCount := 0
-		endCount := 0
-		for v := 0; v < ep.graph.GetVertices(); v++ {
-			diff := outDegree[v] - inDegree[v]
-			if diff > 1 || diff < -1 {
-				return false
-			}
-			if diff == 1 {
-				startCount++
-			}
-			if diff == -1 {
-				endCount++
-			}
+		if oddCount != 0 && oddCount != 2 {
+			return -1, false
 		}
-		return (startCount == 0 && endCount == 0) || (startCount == 1 && endCount == 1)
 	}
 
-	// For undirected graph
-	oddCount := 0
-	for v := 0; v < ep.graph.GetVertices(); v++ {
-		if len(ep.graph.adjList[v])%2 != 0 {
-			oddCount++
-		}
+	if start == -1 {
+		start = ep.firstVertexWithEdges()
 	}
-
-	// For an Euler path, we need exactly 0 or 2 odd degree vertices
-	return oddCount == 0 || oddCount == 2
+	return start, true
 }
 
 // HasEulerCircuit checks if the graph has an Euler circuit
-func (ep *EulerPath) HasEulerCircuit() bool {
-	// İlk olarak bağlantı kontrolü yapalım
-	if !ep.isConnected() {
-		return false
-	}
-
+func (ep *EulerPath[V, W]) HasEulerCircuit() bool {
 	ep.mutex.RLock()
 	defer ep.mutex.RUnlock()
+	ep.graph.mutex.RLock()
+	defer ep.graph.mutex.RUnlock()
+	return ep.hasEulerCircuit()
+}
 
-	if ep.graph.IsDirected() {
-		inDegree := make([]int, ep.graph.GetVertices())
-		for v := 0; v < ep.graph.GetVertices(); v++ {
-			for _, edge := range ep.graph.adjList[v] {
-				inDegree[edge.To]++
-			}
-		}
+// hasEulerCircuit checks if the graph is connected and every vertex is balanced
+func (ep *EulerPath[V, W]) hasEulerCircuit() bool {
+	if ep.graph.vertices == 0 || !ep.isConnected() {
+		return false
+	}
 
-		for v := 0; v < ep.graph.GetVertices(); v++ {
-			if len(ep.graph.adjList[v]) != inDegree[v] {
+	if ep.graph.directed {
+		inDegree, outDegree := ep.directedDegrees()
+		for v := 0; v < ep.graph.vertices; v++ {
+			if outDegree[v] != inDegree[v] {
 				return false
 			}
 		}
 		return true
 	}
 
-	// For undirected graph
-	for v := 0; v < ep.graph.GetVertices(); v++ {
-		if len(ep.graph.adjList[v])%2 != 0 {
+	for _, degree := range ep.undirectedDegrees() {
+		if degree%2 != 0 {
 			return false
 		}
 	}
 	return true
 }
 
-// isConnected checks if the graph is connected
-func (ep *EulerPath) isConnected() bool {
-	n := ep.graph.GetVertices()
-	visited := make([]bool, n)
-
-	// Find first non-zero degree vertex
-	start := -1
-	for v := 0; v < n; v++ {
-		if len(ep.graph.adjList[v]) > 0 {
-			start = v
-			break
+// directedDegrees returns the in-degree and out-degree of every vertex
+func (ep *EulerPath[V, W]) directedDegrees() ([]int, []int) {
+	inDegree := make([]int, ep.graph.vertices)
+	outDegree := make([]int, ep.graph.vertices)
+	for v := 0; v < ep.graph.vertices; v++ {
+		outDegree[v] = len(ep.graph.adjList[v])
+		for _, edge := range ep.graph.adjList[v] {
+			inDegree[edge.To]++
 		}
 	}
+	return inDegree, outDegree
+}
 
-	if start == -1 {
-		return true // Empty graph is considered connected
-	}
-
-	// Run DFS from start vertex
-	ep.dfsUtilNoLock(start, visited)
-
-	// Check if all non-zero degree vertices are visited
-	for v := 0; v < n; v++ {
-		if len(ep.graph.adjList[v]) > 0 && !visited[v] {
-			return false
+// undirectedDegrees returns the degree of every vertex; a self-loop is stored once but adds two
+func (ep *EulerPath[V, W]) undirectedDegrees() []int {
+	degree := make([]int, ep.graph.vertices)
+	for v := 0; v < ep.graph.vertices; v++ {
+		for _, edge := range ep.graph.adjList[v] {
+			degree[v]++
+			if edge.To == v {
+				degree[v]++
+			}
 		}
 	}
-	return true
+	return degree
 }
 
-// dfsUtilNoLock is a utility function for DFS traversal without using mutex
-func (ep *EulerPath) dfsUtilNoLock(v int, visited []bool) {
-	visited[v] = true
-	for _, edge := range ep.graph.adjList[v] {
-		if !visited[edge.To] {
-			ep.dfsUtilNoLock(edge.To, visited)
+// isConnected checks if all vertices with edges lie in one component, ignoring edge direction
+func (ep *EulerPath[V, W]) isConnected() bool {
+	n := ep.graph.vertices
+	neighbors := make([][]int, n)
+	hasEdge := make([]bool, n)
+	for v := 0; v < n; v++ {
+		for _, edge := range ep.graph.adjList[v] {
+			neighbors[v] = append(neighbors[v], edge.To)
+			neighbors[edge.To] = append(neighbors[edge.To], v)
+			hasEdge[v], hasEdge[edge.To] = true, true
 		}
 	}
-}
 
-// dfsUtil is a utility function for DFS traversal
-func (ep *EulerPath) dfsUtil(v int, visited []bool) {
-	visited[v] = true
-	for _, edge := range ep.graph.adjList[v] {
-		if !visited[edge.To] {
-			ep.dfsUtil(edge.To, visited)
-		}
+	start := ep.firstVertexWithEdges()
+	if !hasEdge[start] {
+		return true // A graph without edges is considered connected
 	}
-}
 
-// findStartVertex finds a valid starting vertex for Euler path
-func (ep *EulerPath) findStartVertex() int {
-	if !ep.graph.IsDirected() {
-		// For undirected graph, start from a vertex with odd degree if exists
-		for v := 0; v < ep.graph.GetVertices(); v++ {
-			if len(ep.graph.adjList[v])%2 != 0 {
-				return v
-			}
-		}
-		// If no odd degree vertex, start from any vertex with non-zero degree
-		for v := 0; v < ep.graph.GetVertices(); v++ {
-			if len(ep.graph.adjList[v]) > 0 {
-				return v
-			}
-		}
-	} else {
-		// For directed graph, find vertex with out-degree > in-degree
-		inDegree := make([]int, ep.graph.GetVertices())
-		for v := 0; v < ep.graph.GetVertices(); v++ {
-			for _, edge := range ep.graph.adjList[v] {
-				inDegree[edge.To]++
+	// Run DFS from start vertex
+	visited := make([]bool, n)
+	visited[start] = true
+	stack := []int{start}
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, u := range neighbors[v] {
+			if !visited[u] {
+				visited[u] = true
+				stack = append(stack, u)
 			}
 		}
+	}
 
-		for v := 0; v < ep.graph.GetVertices(); v++ {
-			outDegree := len(ep.graph.adjList[v])
-			if outDegree > inDegree[v] {
-				return v
-			}
+	// Check if all non-zero degree vertices are visited
+	for v := 0; v < n; v++ {
+		if hasEdge[v] && !visited[v] {
+			return false
 		}
+	}
+	return true
+}
 
-		// If no such vertex exists, start from any vertex with non-zero out-degree
-		for v := 0; v < ep.graph.GetVertices(); v++ {
-			if len(ep.graph.adjList[v]) > 0 {
-				return v
-			}
+// firstVertexWithEdges returns the first vertex with an outgoing edge, or 0 if there is none
+func (ep *EulerPath[V, W]) firstVertexWithEdges() int {
+	for v := 0; v < ep.graph.vertices; v++ {
+		if len(ep.graph.adjList[v]) > 0 {
+			return v
 		}
 	}
 	return 0
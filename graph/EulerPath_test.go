@@ -1,6 +1,7 @@
 package graph
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -57,12 +58,11 @@ func TestEulerPath(t *testing.T) {
 		t.Errorf("Expected path length 5, got %d", len(path))
 	}
 
-	// Test 3: No Euler path graph
+	// Test 3: No Euler path graph, a star with four odd degree vertices
 	g3 := NewGraph(5, false)
 	g3.AddEdge(0, 1, 1)
 	g3.AddEdge(0, 2, 1)
 	g3.AddEdge(0, 3, 1)
-	g3.AddEdge(1, 2, 1)
 
 	ep3 := NewEulerPath(g3)
 
@@ -140,4 +140,26 @@ func TestEulerPath(t *testing.T) {
 	if len(path7) != 4 {
 		t.Errorf("Expected path length 4, got %d", len(path7))
 	}
+
+	// Test 8: Triangle with a tail, odd degree vertices 0 and 3
+	g8 := NewGraph(5, false)
+	g8.AddEdge(0, 1, 1)
+	g8.AddEdge(0, 2, 1)
+	g8.AddEdge(0, 3, 1)
+	g8.AddEdge(1, 2, 1)
+
+	path8 := NewEulerPath(g8).FindEulerPath()
+	if len(path8) != 5 || path8[0] != 0 || path8[4] != 3 {
+		t.Errorf("Expected an Euler path from 0 to 3, got %v", path8)
+	}
+
+	// Test 9: Directed path that does not start at the first vertex
+	g9 := NewGraph(3, true)
+	g9.AddEdge(1, 0, 1)
+	g9.AddEdge(0, 2, 1)
+
+	path9 := NewEulerPath(g9).FindEulerPath()
+	if !reflect.DeepEqual(path9, []int{1, 0, 2}) {
+		t.Errorf("Expected Euler path [1 0 2], got %v", path9)
+	}
 }
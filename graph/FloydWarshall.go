@@ -6,8 +6,8 @@ import (
 )
 
 // FloydWarshall implements the Floyd-Warshall algorithm for all-pairs shortest paths
-type FloydWarshall struct {
-	graph    *Graph
+type FloydWarshall[V comparable, W Number] struct {
+	graph    *Graph[V, W]
 	dist     [][]float64 // Distance matrix
 	next     [][]int     // Path matrix
 	infinity float64
@@ -15,8 +15,8 @@ type FloydWarshall struct {
 }
 
 // NewFloydWarshall creates a new Floyd-Warshall instance
-func NewFloydWarshall(g *Graph) *FloydWarshall {
-	fw := &FloydWarshall{
+func NewFloydWarshall[V comparable, W Number](g *Graph[V, W]) *FloydWarshall[V, W] {
+	fw := &FloydWarshall[V, W]{
 		graph:    g,
 		infinity: math.Inf(1),
 		mutex:    sync.RWMutex{},
@@ -26,7 +26,7 @@ func NewFloydWarshall(g *Graph) *FloydWarshall {
 }
 
 // initialize prepares the distance and next matrices
-func (fw *FloydWarshall) initialize() {
+func (fw *FloydWarshall[V, W]) initialize() {
 	fw.mutex.Lock()
 	defer fw.mutex.Unlock()
 
@@ -48,17 +48,19 @@ func (fw *FloydWarshall) initialize() {
 		}
 	}
 
-	// Add edge weights
+	// Add edge weights, keeping the lightest of parallel edges
 	for v := 0; v < n; v++ {
 		for _, edge := range fw.graph.adjList[v] {
-			fw.dist[v][edge.To] = float64(edge.Weight)
-			fw.next[v][edge.To] = edge.To
+			if weight := float64(edge.Weight); weight < fw.dist[v][edge.To] {
+				fw.dist[v][edge.To] = weight
+				fw.next[v][edge.To] = edge.To
+			}
 		}
 	}
 }
 
 // ComputeShortestPaths computes all-pairs shortest paths
-func (fw *FloydWarshall) ComputeShortestPaths() {
+func (fw *FloydWarshall[V, W]) ComputeShortestPaths() {
 	fw.mutex.Lock()
 	defer fw.mutex.Unlock()
 
@@ -81,18 +83,24 @@ func (fw *FloydWarshall) ComputeShortestPaths() {
 }
 
 // GetDistance returns the shortest distance between two vertices
-func (fw *FloydWarshall) GetDistance(from, to int) float64 {
+func (fw *FloydWarshall[V, W]) GetDistance(source, target V) float64 {
 	fw.mutex.RLock()
 	defer fw.mutex.RUnlock()
+
+	from, to, exists := fw.vertexIndexes(source, target)
+	if !exists {
+		return fw.infinity
+	}
 	return fw.dist[from][to]
 }
 
 // GetPath returns the shortest path between two vertices
-func (fw *FloydWarshall) GetPath(from, to int) []int {
+func (fw *FloydWarshall[V, W]) GetPath(source, target V) []V {
 	fw.mutex.RLock()
 	defer fw.mutex.RUnlock()
 
-	if fw.next[from][to] == -1 {
+	from, to, exists := fw.vertexIndexes(source, target)
+	if !exists || fw.next[from][to] == -1 {
 		return nil
 	}
 
@@ -102,16 +110,25 @@ func (fw *FloydWarshall) GetPath(from, to int) []int {
 		path = append(path, from)
 	}
 
-	return path
+	return fw.graph.labelsOf(path)
+}
+
+// vertexIndexes returns the indexes of two vertices that existed when the instance was created
+func (fw *FloydWarshall[V, W]) vertexIndexes(source, target V) (int, int, bool) {
+	fw.graph.mutex.RLock()
+	defer fw.graph.mutex.RUnlock()
+
+	from, ok1 := fw.graph.index[source]
+	to, ok2 := fw.graph.index[target]
+	return from, to, ok1 && ok2 && from < len(fw.dist) && to < len(fw.dist)
 }
 
 // HasNegativeCycle checks if the graph contains a negative cycle
-func (fw *FloydWarshall) HasNegativeCycle() bool {
+func (fw *FloydWarshall[V, W]) HasNegativeCycle() bool {
 	fw.mutex.RLock()
 	defer fw.mutex.RUnlock()
 
-	n := fw.graph.GetVertices()
-	for i := 0; i < n; i++ {
+	for i := range fw.dist {
 		if fw.dist[i][i] < 0 {
 			return true
 		}
@@ -119,15 +136,15 @@ func (fw *FloydWarshall) HasNegativeCycle() bool {
 	return false
 }
 
-// GetAllPairsDistances returns the distance matrix
-func (fw *FloydWarshall) GetAllPairsDistances() [][]float64 {
+// GetAllPairsDistances returns the distance matrix, indexed in Vertices() order
+func (fw *FloydWarshall[V, W]) GetAllPairsDistances() [][]float64 {
 	fw.mutex.RLock()
 	defer fw.mutex.RUnlock()
 	return fw.dist
 }
 
-// GetAllPairsNextHops returns the next hop matrix
-func (fw *FloydWarshall) GetAllPairsNextHops() [][]int {
+// GetAllPairsNextHops returns the next hop matrix of vertex indexes, -1 where there is no path
+func (fw *FloydWarshall[V, W]) GetAllPairsNextHops() [][]int {
 	fw.mutex.RLock()
 	defer fw.mutex.RUnlock()
 	return fw.next
@@ -3,60 +3,551 @@ package graph
 import (
 	"container/heap"
 	"fmt"
-	"math"
 	"sort"
 	"sync"
+
+	"golang.org/x/exp/constraints"
 )
 
+// Number is the set of types usable as edge weights
+type Number interface {
+	constraints.Integer | constraints.Float
+}
+
 // Edge represents a weighted edge in the graph
-type Edge struct {
-	From   int
-	To     int
-	Weight int
+type Edge[V comparable, W Number] struct {
+	From       V
+	To         V
+	Weight     W
+	Attributes map[string]any // Optional payload; both directions of an undirected edge share it
 }
 
-// Graph represents a graph data structure
-type Graph struct {
-	vertices int
-	directed bool
-	adjList  map[int][]Edge
-	mutex    sync.RWMutex
+// Graph represents a graph data structure with vertex labels of type V and edge weights of type W.
+// Vertices are stored by index internally; labels are translated at the API boundary.
+type Graph[V comparable, W Number] struct {
+	vertices     int
+	directed     bool
+	adjList      map[int][]Edge[int, W] // Adjacency list by vertex index
+	labels       []V                    // Vertex index -> label
+	index        map[V]int              // Label -> vertex index
+	vertexAttrs  map[int]map[string]any
+	version      uint64 // Incremented by every change to vertices, edges or weights
+	listeners    []changeListener[V, W]
+	nextListener int
+	pending      []Change[V, W] // Changes waiting for unlockAndNotify
+	mutex        sync.RWMutex
 }
 
-// NewGraph creates a new graph with n vertices
-func NewGraph(vertices int, directed bool) *Graph {
-	return &Graph{
-		vertices: vertices,
-		directed: directed,
-		adjList:  make(map[int][]Edge),
-		mutex:    sync.RWMutex{},
+// NewGraph creates a new graph with n vertices labeled 0..n-1 and integer weights
+func NewGraph(vertices int, directed bool) *Graph[int, int] {
+	g := NewLabeledGraph[int, int](directed)
+	for v := 0; v < vertices; v++ {
+		g.addVertex(v)
 	}
+	return g
 }
 
-// AddEdge adds an edge between vertices v1 and v2 with given weight
-func (g *Graph) AddEdge(v1, v2, weight int) {
+// NewLabeledGraph creates an empty graph whose vertices are identified by labels of type V
+func NewLabeledGraph[V comparable, W Number](directed bool) *Graph[V, W] {
+	return &Graph[V, W]{
+		vertices:    0,
+		directed:    directed,
+		adjList:     make(map[int][]Edge[int, W]),
+		labels:      make([]V, 0),
+		index:       make(map[V]int),
+		vertexAttrs: make(map[int]map[string]any),
+		version:     1, // Derived analyses use 0 for "never computed"
+		mutex:       sync.RWMutex{},
+	}
+}
+
+// AddVertex adds a vertex, returning false if it already exists
+func (g *Graph[V, W]) AddVertex(v V) bool {
 	g.mutex.Lock()
-	defer g.mutex.Unlock()
+	defer g.unlockAndNotify()
+
+	if _, exists := g.index[v]; exists {
+		return false
+	}
+	g.addVertex(v)
+	return true
+}
+
+// addVertex returns the index of v, adding it if needed; the caller must hold the write lock
+func (g *Graph[V, W]) addVertex(v V) int {
+	if i, exists := g.index[v]; exists {
+		return i
+	}
+	i := g.vertices
+	g.index[v] = i
+	g.labels = append(g.labels, v)
+	g.vertices++
+	var none W
+	g.record(VertexAdded, i, -1, none)
+	return i
+}
+
+// HasVertex reports whether the graph contains a vertex
+func (g *Graph[V, W]) HasVertex(v V) bool {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	_, exists := g.index[v]
+	return exists
+}
+
+// RemoveVertex removes a vertex and all of its edges. Vertices added after it move down
+// one index, so index-based results computed earlier no longer line up.
+func (g *Graph[V, W]) RemoveVertex(v V) bool {
+	g.mutex.Lock()
+	defer g.unlockAndNotify()
+
+	removed, exists := g.index[v]
+	if !exists {
+		return false
+	}
+
+	// Report the incident edges before the vertex itself
+	for _, edge := range g.adjList[removed] {
+		g.record(EdgeRemoved, removed, edge.To, edge.Weight)
+	}
+	if g.directed {
+		for from := 0; from < g.vertices; from++ {
+			for _, edge := range g.adjList[from] {
+				if edge.To == removed && from != removed {
+					g.record(EdgeRemoved, from, removed, edge.Weight)
+				}
+			}
+		}
+	}
+	var none W
+	g.record(VertexRemoved, removed, -1, none)
+
+	shift := func(i int) int {
+		if i > removed {
+			return i - 1
+		}
+		return i
+	}
+
+	adjList := make(map[int][]Edge[int, W], len(g.adjList))
+	for from, edges := range g.adjList {
+		if from == removed {
+			continue
+		}
+		kept := make([]Edge[int, W], 0, len(edges))
+		for _, edge := range edges {
+			if edge.To == removed {
+				continue
+			}
+			edge.From, edge.To = shift(edge.From), shift(edge.To)
+			kept = append(kept, edge)
+		}
+		if len(kept) > 0 {
+			adjList[shift(from)] = kept
+		}
+	}
+	g.adjList = adjList
+
+	vertexAttrs := make(map[int]map[string]any, len(g.vertexAttrs))
+	for i, attrs := range g.vertexAttrs {
+		if i != removed {
+			vertexAttrs[shift(i)] = attrs
+		}
+	}
+	g.vertexAttrs = vertexAttrs
+
+	g.labels = append(g.labels[:removed], g.labels[removed+1:]...)
+	delete(g.index, v)
+	for i := removed; i < len(g.labels); i++ {
+		g.index[g.labels[i]] = i
+	}
+	g.vertices--
+	return true
+}
+
+// AddEdge adds an edge between vertices v1 and v2 with given weight.
+// Vertices that do not exist yet are added.
+func (g *Graph[V, W]) AddEdge(v1, v2 V, weight W) {
+	g.AddEdgeWithAttributes(v1, v2, weight, nil)
+}
+
+// AddEdgeWithAttributes adds an edge carrying an attribute map
+func (g *Graph[V, W]) AddEdgeWithAttributes(v1, v2 V, weight W, attributes map[string]any) {
+	g.mutex.Lock()
+	defer g.unlockAndNotify()
+
+	from, to := g.addVertex(v1), g.addVertex(v2)
 
 	// Komşuluk listesine kenarı ekle
-	g.adjList[v1] = append(g.adjList[v1], Edge{To: v2, Weight: weight})
+	g.adjList[from] = append(g.adjList[from], Edge[int, W]{From: from, To: to, Weight: weight, Attributes: attributes})
 
 	// Yönsüz graf ise, ters kenarı da ekle
+	if !g.directed && from != to {
+		g.adjList[to] = append(g.adjList[to], Edge[int, W]{From: to, To: from, Weight: weight, Attributes: attributes})
+	}
+	g.record(EdgeAdded, from, to, weight)
+}
+
+// RemoveEdge removes every edge from v1 to v2 (and the reverse edges in an undirected graph)
+func (g *Graph[V, W]) RemoveEdge(v1, v2 V) bool {
+	g.mutex.Lock()
+	defer g.unlockAndNotify()
+
+	from, ok1 := g.index[v1]
+	to, ok2 := g.index[v2]
+	if !ok1 || !ok2 {
+		return false
+	}
+
+	for _, edge := range g.adjList[from] {
+		if edge.To == to {
+			g.record(EdgeRemoved, from, to, edge.Weight)
+		}
+	}
+
+	removed := g.removeEdges(from, to)
+	if !g.directed && from != to {
+		g.removeEdges(to, from)
+	}
+	return removed
+}
+
+// removeEdges drops the edges from one vertex index to another; the caller must hold the write lock
+func (g *Graph[V, W]) removeEdges(from, to int) bool {
+	edges := g.adjList[from]
+	kept := edges[:0]
+	for _, edge := range edges {
+		if edge.To != to {
+			kept = append(kept, edge)
+		}
+	}
+	if len(kept) == 0 {
+		delete(g.adjList, from)
+	} else {
+		g.adjList[from] = kept
+	}
+	return len(kept) < len(edges)
+}
+
+// HasEdge reports whether there is an edge from v1 to v2
+func (g *Graph[V, W]) HasEdge(v1, v2 V) bool {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	from, ok1 := g.index[v1]
+	to, ok2 := g.index[v2]
+	if !ok1 || !ok2 {
+		return false
+	}
+	for _, edge := range g.adjList[from] {
+		if edge.To == to {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateWeight sets the weight of every edge from v1 to v2 (and the reverse edges in an
+// undirected graph), returning false if there is no such edge
+func (g *Graph[V, W]) UpdateWeight(v1, v2 V, weight W) bool {
+	g.mutex.Lock()
+	defer g.unlockAndNotify()
+
+	from, ok1 := g.index[v1]
+	to, ok2 := g.index[v2]
+	if !ok1 || !ok2 {
+		return false
+	}
+
+	updated := g.setWeights(from, to, weight)
+	if !g.directed && from != to {
+		g.setWeights(to, from, weight)
+	}
+	if updated {
+		g.record(WeightUpdated, from, to, weight)
+	}
+	return updated
+}
+
+// setWeights changes the weight of the edges from one vertex index to another; the caller must hold the write lock
+func (g *Graph[V, W]) setWeights(from, to int, weight W) bool {
+	found := false
+	for i, edge := range g.adjList[from] {
+		if edge.To == to {
+			g.adjList[from][i].Weight = weight
+			found = true
+		}
+	}
+	return found
+}
+
+// OutDegree returns the number of edges leaving a vertex, or its degree in an undirected graph
+func (g *Graph[V, W]) OutDegree(v V) int {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+	return len(g.adjList[g.indexOrNone(v)])
+}
+
+// InDegree returns the number of edges entering a vertex, or its degree in an undirected graph.
+// In a directed graph this scans every edge.
+func (g *Graph[V, W]) InDegree(v V) int {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	i, exists := g.index[v]
+	if !exists {
+		return 0
+	}
 	if !g.directed {
-		g.adjList[v2] = append(g.adjList[v2], Edge{To: v1, Weight: weight})
+		return len(g.adjList[i])
+	}
+	count := 0
+	for _, edges := range g.adjList {
+		for _, edge := range edges {
+			if edge.To == i {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// EdgeCount returns the number of edges; undirected edges are counted once
+func (g *Graph[V, W]) EdgeCount() int {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	count := 0
+	for from, edges := range g.adjList {
+		for _, edge := range edges {
+			if g.directed || from <= edge.To {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// SetVertexAttribute stores a key-value pair on a vertex
+func (g *Graph[V, W]) SetVertexAttribute(v V, key string, value any) bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	i, exists := g.index[v]
+	if !exists {
+		return false
+	}
+	if g.vertexAttrs[i] == nil {
+		g.vertexAttrs[i] = make(map[string]any)
+	}
+	g.vertexAttrs[i][key] = value
+	return true
+}
+
+// VertexAttribute returns a value stored on a vertex
+func (g *Graph[V, W]) VertexAttribute(v V, key string) (any, bool) {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	i, exists := g.index[v]
+	if !exists {
+		return nil, false
+	}
+	value, ok := g.vertexAttrs[i][key]
+	return value, ok
+}
+
+// SetEdgeAttribute stores a key-value pair on every edge from v1 to v2
+func (g *Graph[V, W]) SetEdgeAttribute(v1, v2 V, key string, value any) bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	from, ok1 := g.index[v1]
+	to, ok2 := g.index[v2]
+	if !ok1 || !ok2 {
+		return false
+	}
+
+	found := false
+	for i, edge := range g.adjList[from] {
+		if edge.To != to {
+			continue
+		}
+		if edge.Attributes == nil {
+			attributes := make(map[string]any)
+			g.adjList[from][i].Attributes = attributes
+			if !g.directed {
+				g.shareAttributes(to, from, attributes)
+			}
+		}
+		g.adjList[from][i].Attributes[key] = value
+		found = true
+	}
+	return found
+}
+
+// shareAttributes attaches a new attribute map to the reverse edge of an undirected edge
+func (g *Graph[V, W]) shareAttributes(from, to int, attributes map[string]any) {
+	for i, edge := range g.adjList[from] {
+		if edge.To == to && edge.Attributes == nil {
+			g.adjList[from][i].Attributes = attributes
+			return
+		}
 	}
 }
 
+// EdgeAttribute returns a value stored on the first edge from v1 to v2
+func (g *Graph[V, W]) EdgeAttribute(v1, v2 V, key string) (any, bool) {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	from, ok1 := g.index[v1]
+	to, ok2 := g.index[v2]
+	if !ok1 || !ok2 {
+		return nil, false
+	}
+	for _, edge := range g.adjList[from] {
+		if edge.To == to {
+			value, ok := edge.Attributes[key]
+			return value, ok
+		}
+	}
+	return nil, false
+}
+
+// Vertices returns the vertex labels in index order
+func (g *Graph[V, W]) Vertices() []V {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	vertices := make([]V, len(g.labels))
+	copy(vertices, g.labels)
+	return vertices
+}
+
+// Edges returns every edge with a copy of its attributes; undirected edges are reported once
+func (g *Graph[V, W]) Edges() []Edge[V, W] {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	edges := make([]Edge[V, W], 0)
+	for from := 0; from < g.vertices; from++ {
+		for _, edge := range g.adjList[from] {
+			if !g.directed && edge.To < from {
+				continue
+			}
+			edges = append(edges, g.toEdge(edge))
+		}
+	}
+	return edges
+}
+
+// label returns the label of a vertex index
+func (g *Graph[V, W]) label(i int) V {
+	return g.labels[i]
+}
+
+// labelsOf translates vertex indexes to labels
+func (g *Graph[V, W]) labelsOf(indexes []int) []V {
+	if indexes == nil {
+		return nil
+	}
+	result := make([]V, len(indexes))
+	for i, index := range indexes {
+		result[i] = g.labels[index]
+	}
+	return result
+}
+
+// indexesOf translates vertex labels to indexes, reporting false for unknown vertices
+func (g *Graph[V, W]) indexesOf(vertices []V) ([]int, bool) {
+	result := make([]int, len(vertices))
+	for i, v := range vertices {
+		index, exists := g.index[v]
+		if !exists {
+			return nil, false
+		}
+		result[i] = index
+	}
+	return result, true
+}
+
+// componentsOf translates groups of vertex indexes to labels
+func (g *Graph[V, W]) componentsOf(components [][]int) [][]V {
+	result := make([][]V, len(components))
+	for i, component := range components {
+		result[i] = g.labelsOf(component)
+	}
+	return result
+}
+
+// toEdge translates an index-based edge to a labeled edge with a copy of its attributes, so
+// that callers cannot reach the graph's maps without the lock
+func (g *Graph[V, W]) toEdge(edge Edge[int, W]) Edge[V, W] {
+	return labeledEdge(g.labels, edge)
+}
+
+// labeledEdge translates an index-based edge with an index -> label mapping, copying its
+// attributes
+func labeledEdge[V comparable, W Number](labels []V, edge Edge[int, W]) Edge[V, W] {
+	return Edge[V, W]{From: labels[edge.From], To: labels[edge.To], Weight: edge.Weight, Attributes: copyAttributes(edge.Attributes)}
+}
+
+// copyAttributes returns a copy of an attribute map, or nil if it is empty
+func copyAttributes(attributes map[string]any) map[string]any {
+	if len(attributes) == 0 {
+		return nil
+	}
+	result := make(map[string]any, len(attributes))
+	for key, value := range attributes {
+		result[key] = value
+	}
+	return result
+}
+
+// toEdges translates index-based edges to labeled edges
+func (g *Graph[V, W]) toEdges(edges []Edge[int, W]) []Edge[V, W] {
+	return labeledEdges(g.labels, edges)
+}
+
+// labeledEdges translates index-based edges with an index -> label mapping
+func labeledEdges[V comparable, W Number](labels []V, edges []Edge[int, W]) []Edge[V, W] {
+	result := make([]Edge[V, W], len(edges))
+	for i, edge := range edges {
+		result[i] = labeledEdge(labels, edge)
+	}
+	return result
+}
+
+// labelSnapshot copies the index -> label and label -> index mappings, so that results keep
+// naming the vertices they were computed on after RemoveVertex shifts the indexes; the caller
+// must hold the read lock
+func (g *Graph[V, W]) labelSnapshot() ([]V, map[V]int) {
+	labels := make([]V, len(g.labels))
+	copy(labels, g.labels)
+	index := make(map[V]int, len(g.index))
+	for v, i := range g.index {
+		index[v] = i
+	}
+	return labels, index
+}
+
 // BFS performs Breadth First Search starting from vertex v
-func (g *Graph) BFS(start int) []int {
+func (g *Graph[V, W]) BFS(start V) []V {
 	g.mutex.RLock()
 	defer g.mutex.RUnlock()
 
+	first, exists := g.index[start]
+	if !exists {
+		return []V{}
+	}
+
 	visited := make(map[int]bool)
-	queue := []int{start}
+	queue := []int{first}
 	result := []int{}
 
-	visited[start] = true
+	visited[first] = true
 
 	for len(queue) > 0 {
 		vertex := queue[0]
@@ -71,21 +562,26 @@ func (g *Graph) BFS(start int) []int {
 		}
 	}
 
-	return result
+	return g.labelsOf(result)
 }
 
 // DFS performs Depth First Search starting from vertex v
-func (g *Graph) DFS(start int) []int {
+func (g *Graph[V, W]) DFS(start V) []V {
 	g.mutex.RLock()
 	defer g.mutex.RUnlock()
 
+	first, exists := g.index[start]
+	if !exists {
+		return []V{}
+	}
+
 	visited := make(map[int]bool)
 	result := []int{}
-	g.dfsUtil(start, visited, &result)
-	return result
+	g.dfsUtil(first, visited, &result)
+	return g.labelsOf(result)
 }
 
-func (g *Graph) dfsUtil(vertex int, visited map[int]bool, result *[]int) {
+func (g *Graph[V, W]) dfsUtil(vertex int, visited map[int]bool, result *[]int) {
 	visited[vertex] = true
 	*result = append(*result, vertex)
 
@@ -96,46 +592,21 @@ func (g *Graph) dfsUtil(vertex int, visited map[int]bool, result *[]int) {
 	}
 }
 
-// Dijkstra finds shortest paths from source vertex to all other vertices
-func (g *Graph) Dijkstra(source int) map[int]int {
+// Dijkstra finds shortest paths from source vertex to all other vertices.
+// Vertices that cannot be reached have no path in the result; weights must be non-negative.
+func (g *Graph[V, W]) Dijkstra(source V) *ShortestPaths[V, W] {
 	g.mutex.RLock()
 	defer g.mutex.RUnlock()
 
-	distances := make(map[int]int)
-	for i := 0; i < g.vertices; i++ {
-		distances[i] = math.MaxInt32
+	start, exists := g.index[source]
+	if !exists {
+		return g.dijkstra(nil, -1)
 	}
-	distances[source] = 0
-
-	// Helper structures for priority queue
-	pq := &PriorityQueue{}
-	heap.Init(pq)
-	heap.Push(pq, &Item{vertex: source, priority: 0})
-
-	for pq.Len() > 0 {
-		current := heap.Pop(pq).(*Item)
-		vertex := current.vertex
-
-		// If a shorter path is found, skip this node
-		if current.priority > distances[vertex] {
-			continue
-		}
-
-		// Check neighbors
-		for _, edge := range g.adjList[vertex] {
-			distance := distances[vertex] + edge.Weight
-			if distance < distances[edge.To] {
-				distances[edge.To] = distance
-				heap.Push(pq, &Item{vertex: edge.To, priority: distance})
-			}
-		}
-	}
-
-	return distances
+	return g.dijkstra([]int{start}, -1)
 }
 
 // Kruskal finds Minimum Spanning Tree using Kruskal's algorithm
-func (g *Graph) Kruskal() []Edge {
+func (g *Graph[V, W]) Kruskal() []Edge[V, W] {
 	g.mutex.RLock()
 	defer g.mutex.RUnlock()
 
@@ -145,7 +616,7 @@ func (g *Graph) Kruskal() []Edge {
 
 	// Collect all edges and sort them by weight
 	edges := g.getAllEdges()
-	result := make([]Edge, 0)
+	result := make([]Edge[int, W], 0)
 
 	// Initialize Union-Find data structure
 	uf := NewUnionFind(g.vertices)
@@ -168,11 +639,11 @@ func (g *Graph) Kruskal() []Edge {
 		}
 	}
 
-	return result
+	return g.toEdges(result)
 }
 
 // Prim finds Minimum Spanning Tree using Prim's algorithm
-func (g *Graph) Prim(start int) []Edge {
+func (g *Graph[V, W]) Prim(start V) []Edge[V, W] {
 	g.mutex.RLock()
 	defer g.mutex.RUnlock()
 
@@ -180,17 +651,22 @@ func (g *Graph) Prim(start int) []Edge {
 		return nil // Prim works only on undirected graphs
 	}
 
+	first, exists := g.index[start]
+	if !exists {
+		return []Edge[V, W]{}
+	}
+
 	visited := make(map[int]bool)
-	result := make([]Edge, 0)
+	result := make([]Edge[V, W], 0)
 
 	// Initialize priority queue
 	pq := &PriorityQueue{}
 	heap.Init(pq)
 
 	// Start from the starting node
-	visited[start] = true
-	for _, edge := range g.adjList[start] {
-		heap.Push(pq, &Item{vertex: edge.To, priority: edge.Weight, from: start})
+	visited[first] = true
+	for i, edge := range g.adjList[first] {
+		heap.Push(pq, &Item{vertex: edge.To, priority: float64(edge.Weight), from: first, edge: i})
 	}
 
 	for pq.Len() > 0 && len(result) < g.vertices-1 {
@@ -201,12 +677,12 @@ func (g *Graph) Prim(start int) []Edge {
 
 		// Add edge to MST
 		visited[item.vertex] = true
-		result = append(result, Edge{From: item.from, To: item.vertex, Weight: item.priority})
+		result = append(result, g.toEdge(g.adjList[item.from][item.edge]))
 
 		// Add neighbors of the new node to the queue
-		for _, edge := range g.adjList[item.vertex] {
+		for i, edge := range g.adjList[item.vertex] {
 			if !visited[edge.To] {
-				heap.Push(pq, &Item{vertex: edge.To, priority: edge.Weight, from: item.vertex})
+				heap.Push(pq, &Item{vertex: edge.To, priority: float64(edge.Weight), from: item.vertex, edge: i})
 			}
 		}
 	}
@@ -215,34 +691,42 @@ func (g *Graph) Prim(start int) []Edge {
 }
 
 // GetNeighbors returns all neighbors of a vertex
-func (g *Graph) GetNeighbors(vertex int) []int {
+func (g *Graph[V, W]) GetNeighbors(vertex V) []V {
 	g.mutex.RLock()
 	defer g.mutex.RUnlock()
 
-	neighbors := make([]int, 0)
-	for _, edge := range g.adjList[vertex] {
-		neighbors = append(neighbors, edge.To)
+	neighbors := make([]V, 0)
+	for _, edge := range g.adjList[g.indexOrNone(vertex)] {
+		neighbors = append(neighbors, g.labels[edge.To])
 	}
 	return neighbors
 }
 
+// indexOrNone returns the index of a vertex, or -1 (which has no edges) if it does not exist
+func (g *Graph[V, W]) indexOrNone(v V) int {
+	if i, exists := g.index[v]; exists {
+		return i
+	}
+	return -1
+}
+
 // GetVertices returns the number of vertices
-func (g *Graph) GetVertices() int {
+func (g *Graph[V, W]) GetVertices() int {
 	g.mutex.RLock()
 	defer g.mutex.RUnlock()
 	return g.vertices
 }
 
 // IsDirected returns whether the graph is directed
-func (g *Graph) IsDirected() bool {
+func (g *Graph[V, W]) IsDirected() bool {
 	g.mutex.RLock()
 	defer g.mutex.RUnlock()
 	return g.directed
 }
 
 // getAllEdges returns all edges in the graph
-func (g *Graph) getAllEdges() []Edge {
-	edges := make([]Edge, 0)
+func (g *Graph[V, W]) getAllEdges() []Edge[int, W] {
+	edges := make([]Edge[int, W], 0)
 	seen := make(map[string]bool)
 
 	for from, adjEdges := range g.adjList {
@@ -250,7 +734,7 @@ func (g *Graph) getAllEdges() []Edge {
 			// Add edges only once for undirected graphs
 			key := fmt.Sprintf("%d-%d", min(from, edge.To), max(from, edge.To))
 			if !seen[key] {
-				edges = append(edges, Edge{From: from, To: edge.To, Weight: edge.Weight})
+				edges = append(edges, edge)
 				seen[key] = true
 			}
 		}
@@ -267,8 +751,9 @@ func (g *Graph) getAllEdges() []Edge {
 // Priority Queue implementation for Dijkstra and Prim
 type Item struct {
 	vertex   int
-	priority int
+	priority float64
 	from     int
+	edge     int // Position of the edge in adjList[from], used by Prim
 	index    int
 }
 
@@ -344,14 +829,14 @@ func (uf *UnionFind) Connected(x, y int) bool {
 	return uf.Find(x) == uf.Find(y)
 }
 
-func min(a, b int) int {
+func min[T Number](a, b T) T {
 	if a < b {
 		return a
 	}
 	return b
 }
 
-func max(a, b int) int {
+func max[T Number](a, b T) T {
 	if a > b {
 		return a
 	}
@@ -0,0 +1,106 @@
+package graph
+
+// ChangeKind identifies the kind of a graph change
+type ChangeKind int
+
+const (
+	VertexAdded ChangeKind = iota
+	VertexRemoved
+	EdgeAdded
+	EdgeRemoved
+	WeightUpdated
+)
+
+// String returns the name of the change kind
+func (k ChangeKind) String() string {
+	switch k {
+	case VertexAdded:
+		return "VertexAdded"
+	case VertexRemoved:
+		return "VertexRemoved"
+	case EdgeAdded:
+		return "EdgeAdded"
+	case EdgeRemoved:
+		return "EdgeRemoved"
+	case WeightUpdated:
+		return "WeightUpdated"
+	}
+	return "Unknown"
+}
+
+// Change describes one modification of a graph. Vertex changes set only From; edge changes
+// set From, To and the edge weight (the new weight for WeightUpdated). Undirected edges are
+// reported once.
+type Change[V comparable, W Number] struct {
+	Kind    ChangeKind
+	From    V
+	To      V
+	Weight  W
+	Version uint64 // Graph version right after the change
+}
+
+// changeListener is a registered change callback
+type changeListener[V comparable, W Number] struct {
+	id       int
+	callback func(Change[V, W])
+}
+
+// OnChange registers a callback for every change to the graph's vertices, edges or weights and
+// returns a function that unregisters it. Callbacks run after the change, outside the graph's
+// lock, so they may read or modify the graph. Changes made concurrently from several goroutines
+// may arrive out of order; Change.Version gives their actual order.
+func (g *Graph[V, W]) OnChange(callback func(Change[V, W])) func() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	id := g.nextListener
+	g.nextListener++
+	g.listeners = append(g.listeners, changeListener[V, W]{id: id, callback: callback})
+
+	return func() {
+		g.mutex.Lock()
+		defer g.mutex.Unlock()
+		for i, listener := range g.listeners {
+			if listener.id == id {
+				g.listeners = append(g.listeners[:i:i], g.listeners[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Version returns a counter that increases with every change to the graph's vertices, edges
+// or weights. Attribute changes do not count. Derived analyses compare it to tell whether
+// their results are stale.
+func (g *Graph[V, W]) Version() uint64 {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+	return g.version
+}
+
+// record bumps the version and queues the change for listeners; the caller must hold the write lock
+func (g *Graph[V, W]) record(kind ChangeKind, from, to int, weight W) {
+	g.version++
+	if len(g.listeners) == 0 {
+		return
+	}
+	change := Change[V, W]{Kind: kind, From: g.labels[from], Weight: weight, Version: g.version}
+	if to >= 0 {
+		change.To = g.labels[to]
+	}
+	g.pending = append(g.pending, change)
+}
+
+// unlockAndNotify releases the write lock and passes queued changes to the listeners
+func (g *Graph[V, W]) unlockAndNotify() {
+	changes := g.pending
+	g.pending = nil
+	listeners := g.listeners
+	g.mutex.Unlock()
+
+	for _, change := range changes {
+		for _, listener := range listeners {
+			listener.callback(change)
+		}
+	}
+}
@@ -0,0 +1,471 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// WriteDOT writes the graph in Graphviz DOT format. Vertex labels and attribute values are
+// written with fmt.Sprint. Edge weights are written as the edge label, unless the edge has a
+// "label" attribute, and also as the Graphviz layout weight when they are non-negative
+// integers, so ReadDOT recovers every weight except other values on a labeled edge.
+// Highlighted edges are drawn red and bold, and each vertex group gets its own fill color;
+// h may be nil; ReadDOT drops these highlight attributes again.
+func WriteDOT[V comparable, W Number](w io.Writer, g *Graph[V, W], h *Highlight[V, W]) error {
+	s := g.snapshot()
+	hi := newHighlightIndex(h, s.directed)
+
+	kind, op := "graph", "--"
+	if s.directed {
+		kind, op = "digraph", "->"
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%s G {\n", kind)
+	for i, v := range s.vertices {
+		attrs := dotAttributes(s.vertexAttrs[i], "")
+		if group := hi.vertexGroup(v); group != -1 {
+			attrs = append(attrs, `style="filled"`, "fillcolor="+dotID(groupColor(group)), "group="+strconv.Itoa(group))
+		}
+		fmt.Fprintf(bw, "  %s%s;\n", dotID(fmt.Sprint(v)), dotAttributeList(attrs))
+	}
+	for _, edge := range s.edges {
+		weight := fmt.Sprint(edge.Weight)
+		attrs := make([]string, 0)
+		if isDotLayoutWeight(weight) {
+			attrs = append(attrs, "weight="+weight)
+		}
+		if _, labeled := edge.Attributes["label"]; !labeled {
+			attrs = append(attrs, "label="+dotID(weight))
+		}
+		attrs = append(attrs, dotAttributes(edge.Attributes, "weight")...)
+		if hi.edge(edge.From, edge.To) {
+			attrs = append(attrs, `color="red"`, "penwidth=2")
+		}
+		fmt.Fprintf(bw, "  %s %s %s%s;\n", dotID(fmt.Sprint(edge.From)), op, dotID(fmt.Sprint(edge.To)), dotAttributeList(attrs))
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// isDotLayoutWeight reports whether a weight is valid for Graphviz's weight attribute, which
+// must be a non-negative integer
+func isDotLayoutWeight(weight string) bool {
+	value, err := strconv.Atoi(weight)
+	return err == nil && value >= 0
+}
+
+// dotAttributes formats an attribute map as sorted key=value pairs, leaving out one reserved key
+func dotAttributes(attributes map[string]any, reserved string) []string {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		if key != reserved {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	attrs := make([]string, len(keys))
+	for i, key := range keys {
+		attrs[i] = dotID(key) + "=" + dotID(fmt.Sprint(attributes[key]))
+	}
+	return attrs
+}
+
+// dotAttributeList wraps attributes in brackets, or returns "" if there are none
+func dotAttributeList(attrs []string) string {
+	if len(attrs) == 0 {
+		return ""
+	}
+	return " [" + strings.Join(attrs, ", ") + "]"
+}
+
+// dotID quotes a DOT identifier unless it is a plain name or number
+func dotID(id string) string {
+	if id != "" && !dotKeywords[strings.ToLower(id)] && (isDotName(id) || isDotNumeral(id)) {
+		return id
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(id) + `"`
+}
+
+// isDotName reports whether id is an unquoted DOT name: letters, digits and underscores, not
+// starting with a digit
+func isDotName(id string) bool {
+	for i, r := range id {
+		if !(r == '_' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r))) {
+			return false
+		}
+	}
+	return true
+}
+
+// isDotNumeral reports whether id matches the DOT numeral grammar
+// [-]?(.[0-9]+ | [0-9]+(.[0-9]*)?) exactly
+func isDotNumeral(id string) bool {
+	id = strings.TrimPrefix(id, "-")
+	integer, fraction, dotted := strings.Cut(id, ".")
+	if !isASCIIDigits(integer) || !isASCIIDigits(fraction) {
+		return false
+	}
+	if integer == "" {
+		return dotted && fraction != ""
+	}
+	return true
+}
+
+// isASCIIDigits reports whether s consists of the digits 0-9 only; s may be empty
+func isASCIIDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// dotKeywords are reserved words that must be quoted when used as identifiers
+var dotKeywords = map[string]bool{"node": true, "edge": true, "graph": true, "digraph": true, "subgraph": true, "strict": true}
+
+// dotToken kinds
+const (
+	dotEOF = iota
+	dotIdentifier
+	dotEdgeOp
+	dotPunct
+)
+
+// dotToken is a lexical token of DOT input
+type dotToken struct {
+	kind   int
+	text   string
+	quoted bool
+	line   int
+}
+
+// lexDOT splits DOT input into tokens, dropping comments
+func lexDOT(input string) ([]dotToken, error) {
+	tokens := make([]dotToken, 0)
+	runes := []rune(input)
+	line := 1
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == '\n':
+			line++
+			i++
+		case unicode.IsSpace(r):
+			i++
+		case r == '#' || (r == '/' && i+1 < len(runes) && runes[i+1] == '/'):
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			i += 2
+			for i+1 < len(runes) && !(runes[i] == '*' && runes[i+1] == '/') {
+				if runes[i] == '\n' {
+					line++
+				}
+				i++
+			}
+			if i+1 >= len(runes) {
+				return nil, fmt.Errorf("%w: DOT line %d: unterminated comment", ErrInvalidFormat, line)
+			}
+			i += 2
+		case r == '-' && i+1 < len(runes) && (runes[i+1] == '>' || runes[i+1] == '-'):
+			tokens = append(tokens, dotToken{kind: dotEdgeOp, text: string(runes[i : i+2]), line: line})
+			i += 2
+		case strings.ContainsRune("{}[]=;,:", r):
+			tokens = append(tokens, dotToken{kind: dotPunct, text: string(r), line: line})
+			i++
+		case r == '"':
+			var b strings.Builder
+			start := line
+			for i++; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					switch runes[i+1] {
+					case '"', '\\':
+						b.WriteRune(runes[i+1])
+						i++
+						continue
+					case '\n':
+						line++
+						i++
+						continue
+					}
+				}
+				if runes[i] == '\n' {
+					line++
+				}
+				b.WriteRune(runes[i])
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("%w: DOT line %d: unterminated string", ErrInvalidFormat, start)
+			}
+			i++
+			tokens = append(tokens, dotToken{kind: dotIdentifier, text: b.String(), quoted: true, line: start})
+		case r == '<':
+			return nil, fmt.Errorf("%w: DOT line %d: HTML strings are not supported", ErrInvalidFormat, line)
+		case r == '_' || r == '.' || r == '-' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && (runes[i] == '_' || runes[i] == '.' || unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || (i == start && runes[i] == '-')) {
+				i++
+			}
+			tokens = append(tokens, dotToken{kind: dotIdentifier, text: string(runes[start:i]), line: line})
+		default:
+			return nil, fmt.Errorf("%w: DOT line %d: unexpected character %q", ErrInvalidFormat, line, r)
+		}
+	}
+	return append(tokens, dotToken{kind: dotEOF, line: line}), nil
+}
+
+// dotParser reads a DOT graph from tokens
+type dotParser struct {
+	tokens []dotToken
+	pos    int
+	graph  *Graph[string, float64]
+}
+
+// peek returns the current token
+func (p *dotParser) peek() dotToken {
+	return p.tokens[p.pos]
+}
+
+// next consumes the current token
+func (p *dotParser) next() dotToken {
+	t := p.tokens[p.pos]
+	if t.kind != dotEOF {
+		p.pos++
+	}
+	return t
+}
+
+// is reports whether the current token is the given punctuation or edge operator
+func (p *dotParser) is(text string) bool {
+	t := p.peek()
+	return (t.kind == dotPunct || t.kind == dotEdgeOp) && t.text == text
+}
+
+// keyword reports whether the current token is an unquoted keyword
+func (p *dotParser) keyword(word string) bool {
+	t := p.peek()
+	return t.kind == dotIdentifier && !t.quoted && strings.EqualFold(t.text, word)
+}
+
+// errorf reports a parse error at the current token
+func (p *dotParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: DOT line %d: %s", ErrInvalidFormat, p.peek().line, fmt.Sprintf(format, args...))
+}
+
+// expect consumes the given punctuation
+func (p *dotParser) expect(text string) error {
+	if !p.is(text) {
+		return p.errorf("expected %q", text)
+	}
+	p.next()
+	return nil
+}
+
+// identifier consumes an identifier
+func (p *dotParser) identifier() (string, error) {
+	if p.peek().kind != dotIdentifier {
+		return "", p.errorf("expected an identifier")
+	}
+	return p.next().text, nil
+}
+
+// ReadDOT reads a graph in Graphviz DOT format. It supports graph and digraph declarations,
+// node and edge statements with attribute lists, and edge chains; subgraphs are not supported.
+// The "weight" edge attribute becomes the edge weight; without it a numeric "label" is used,
+// as WriteDOT writes weights Graphviz cannot take there, and otherwise the weight is 1. A
+// label equal to the weight is dropped, and so are the attributes WriteDOT writes for a
+// highlight; every other attribute is stored as a string on its vertex or edge.
+func ReadDOT(r io.Reader) (*Graph[string, float64], error) {
+	input, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := lexDOT(string(input))
+	if err != nil {
+		return nil, err
+	}
+
+	p := &dotParser{tokens: tokens}
+	if p.keyword("strict") {
+		p.next()
+	}
+	switch {
+	case p.keyword("graph"):
+		p.graph = NewLabeledGraph[string, float64](false)
+	case p.keyword("digraph"):
+		p.graph = NewLabeledGraph[string, float64](true)
+	default:
+		return nil, p.errorf("expected graph or digraph")
+	}
+	p.next()
+	if p.peek().kind == dotIdentifier {
+		p.next() // Graph name
+	}
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	for !p.is("}") {
+		if p.peek().kind == dotEOF {
+			return nil, p.errorf("expected %q", "}")
+		}
+		if err := p.statement(); err != nil {
+			return nil, err
+		}
+		if p.is(";") || p.is(",") {
+			p.next()
+		}
+	}
+	p.next()
+	if p.peek().kind != dotEOF {
+		return nil, p.errorf("unexpected input after graph")
+	}
+	return p.graph, nil
+}
+
+// statement parses one node, edge or attribute statement
+func (p *dotParser) statement() error {
+	if p.keyword("subgraph") || p.is("{") {
+		return p.errorf("subgraphs are not supported")
+	}
+	if (p.keyword("graph") || p.keyword("node") || p.keyword("edge")) && p.tokens[p.pos+1].text == "[" {
+		p.next()
+		_, err := p.attributes()
+		return err // Default attributes are ignored
+	}
+
+	first, err := p.nodeID()
+	if err != nil {
+		return err
+	}
+	if p.is("=") {
+		p.next()
+		_, err := p.identifier() // Graph attribute
+		return err
+	}
+
+	chain := []string{first}
+	for p.peek().kind == dotEdgeOp {
+		op := p.next().text
+		if (op == "->") != p.graph.IsDirected() {
+			return p.errorf("edge operator %s does not match the graph type", op)
+		}
+		v, err := p.nodeID()
+		if err != nil {
+			return err
+		}
+		chain = append(chain, v)
+	}
+
+	attrs, err := p.attributes()
+	if err != nil {
+		return err
+	}
+	dropDOTHighlight(attrs, len(chain) == 1)
+
+	if len(chain) == 1 {
+		p.graph.AddVertex(first)
+		for key, value := range attrs {
+			p.graph.SetVertexAttribute(first, key, value)
+		}
+		return nil
+	}
+
+	weight := 1.0
+	text, exists := attrs["weight"]
+	if label, labeled := attrs["label"]; !exists && labeled {
+		if _, err := strconv.ParseFloat(label, 64); err == nil {
+			text, exists = label, true
+		}
+	}
+	if exists {
+		if weight, err = strconv.ParseFloat(text, 64); err != nil {
+			return p.errorf("invalid weight %q", text)
+		}
+	}
+	for i := 0; i+1 < len(chain); i++ {
+		var edgeAttrs map[string]any
+		for key, value := range attrs {
+			if key == "weight" || (key == "label" && exists && value == text) {
+				continue
+			}
+			if edgeAttrs == nil {
+				edgeAttrs = make(map[string]any)
+			}
+			edgeAttrs[key] = value
+		}
+		p.graph.AddEdgeWithAttributes(chain[i], chain[i+1], weight, edgeAttrs)
+	}
+	return nil
+}
+
+// dropDOTHighlight removes the attributes WriteDOT adds to a highlighted vertex or edge, so a
+// round trip does not turn them into ordinary attributes; a color or style set without the
+// rest of the highlight stays
+func dropDOTHighlight(attrs map[string]string, vertex bool) {
+	if vertex {
+		group, err := strconv.Atoi(attrs["group"])
+		if err == nil && group >= 0 && attrs["style"] == "filled" && attrs["fillcolor"] == groupColor(group) {
+			delete(attrs, "style")
+			delete(attrs, "fillcolor")
+			delete(attrs, "group")
+		}
+		return
+	}
+	if attrs["color"] == "red" && attrs["penwidth"] == "2" {
+		delete(attrs, "color")
+		delete(attrs, "penwidth")
+	}
+}
+
+// nodeID parses a vertex name, skipping an optional port
+func (p *dotParser) nodeID() (string, error) {
+	id, err := p.identifier()
+	if err != nil {
+		return "", err
+	}
+	for p.is(":") {
+		p.next()
+		if _, err := p.identifier(); err != nil {
+			return "", err
+		}
+	}
+	return id, nil
+}
+
+// attributes parses zero or more bracketed attribute lists
+func (p *dotParser) attributes() (map[string]string, error) {
+	attrs := make(map[string]string)
+	for p.is("[") {
+		p.next()
+		for !p.is("]") {
+			key, err := p.identifier()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect("="); err != nil {
+				return nil, err
+			}
+			value, err := p.identifier()
+			if err != nil {
+				return nil, err
+			}
+			attrs[key] = value
+			if p.is(";") || p.is(",") {
+				p.next()
+			}
+		}
+		p.next()
+	}
+	return attrs, nil
+}
@@ -0,0 +1,128 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// WriteEdgeList writes the graph as plain text: a "# directed" or "# undirected" header, then
+// one "from to weight" line per edge and one line per vertex without edges. Labels containing
+// spaces, quotes or '#' are written as Go-quoted strings. Attributes are not written.
+func WriteEdgeList[V comparable, W Number](w io.Writer, g *Graph[V, W]) error {
+	s := g.snapshot()
+
+	bw := bufio.NewWriter(w)
+	if s.directed {
+		fmt.Fprintln(bw, "# directed")
+	} else {
+		fmt.Fprintln(bw, "# undirected")
+	}
+
+	hasEdge := make(map[V]bool)
+	for _, edge := range s.edges {
+		hasEdge[edge.From] = true
+		hasEdge[edge.To] = true
+	}
+	for _, v := range s.vertices {
+		if !hasEdge[v] {
+			fmt.Fprintln(bw, edgeListField(fmt.Sprint(v)))
+		}
+	}
+	for _, edge := range s.edges {
+		fmt.Fprintf(bw, "%s %s %v\n", edgeListField(fmt.Sprint(edge.From)), edgeListField(fmt.Sprint(edge.To)), edge.Weight)
+	}
+	return bw.Flush()
+}
+
+// edgeListField quotes a label if it would not read back as a single field
+func edgeListField(label string) string {
+	if label == "" || strings.ContainsAny(label, "\"#") || strings.IndexFunc(label, unicode.IsSpace) != -1 {
+		return strconv.Quote(label)
+	}
+	return label
+}
+
+// ReadEdgeList reads the plain text format of WriteEdgeList. Each line holds "from to [weight]"
+// (weight 1 if left out) or a single vertex; blank lines and '#' comments are skipped. A
+// "# directed" or "# undirected" header overrides the directed argument.
+func ReadEdgeList(r io.Reader, directed bool) (*Graph[string, float64], error) {
+	type line struct {
+		number int
+		fields []string
+	}
+	lines := make([]line, 0)
+
+	scanner := bufio.NewScanner(r)
+	for number := 1; scanner.Scan(); number++ {
+		text := strings.TrimSpace(scanner.Text())
+		switch text {
+		case "# directed":
+			directed = true
+			continue
+		case "# undirected":
+			directed = false
+			continue
+		}
+		fields, err := edgeListFields(text)
+		if err != nil {
+			return nil, fmt.Errorf("%w: edge list line %d: %v", ErrInvalidFormat, number, err)
+		}
+		if len(fields) > 3 {
+			return nil, fmt.Errorf("%w: edge list line %d: expected at most 3 fields, got %d", ErrInvalidFormat, number, len(fields))
+		}
+		if len(fields) > 0 {
+			lines = append(lines, line{number, fields})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	g := NewLabeledGraph[string, float64](directed)
+	for _, l := range lines {
+		switch len(l.fields) {
+		case 1:
+			g.AddVertex(l.fields[0])
+		case 2:
+			g.AddEdge(l.fields[0], l.fields[1], 1)
+		case 3:
+			weight, err := strconv.ParseFloat(l.fields[2], 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: edge list line %d: invalid weight %q", ErrInvalidFormat, l.number, l.fields[2])
+			}
+			g.AddEdge(l.fields[0], l.fields[1], weight)
+		}
+	}
+	return g, nil
+}
+
+// edgeListFields splits a line on whitespace, unquoting quoted fields and stopping at a comment
+func edgeListFields(text string) ([]string, error) {
+	fields := make([]string, 0, 3)
+	for {
+		text = strings.TrimLeftFunc(text, unicode.IsSpace)
+		if text == "" || text[0] == '#' {
+			return fields, nil
+		}
+		if text[0] == '"' {
+			quoted, err := strconv.QuotedPrefix(text)
+			if err != nil {
+				return nil, fmt.Errorf("invalid quoted label %s", text)
+			}
+			field, _ := strconv.Unquote(quoted)
+			fields = append(fields, field)
+			text = text[len(quoted):]
+			continue
+		}
+		end := strings.IndexFunc(text, func(r rune) bool { return unicode.IsSpace(r) || r == '#' })
+		if end == -1 {
+			end = len(text)
+		}
+		fields = append(fields, text[:end])
+		text = text[end:]
+	}
+}
@@ -0,0 +1,140 @@
+package graph
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrInvalidFormat is returned when graph input cannot be parsed
+var ErrInvalidFormat = errors.New("invalid graph format")
+
+// Highlight marks parts of a graph when it is written out, such as the edges of a minimum
+// spanning tree or a coloring by strongly connected component
+type Highlight[V comparable, W Number] struct {
+	Edges  []Edge[V, W] // Edges to emphasize, e.g. KruskalMST.GetMSTEdges()
+	Groups [][]V        // Vertex groups to color, e.g. TarjanSCC.GetComponents()
+}
+
+// highlightPalette holds the fill colors used for vertex groups, in order
+var highlightPalette = []string{
+	"#8dd3c7", "#ffffb3", "#bebada", "#fb8072", "#80b1d3",
+	"#fdb462", "#b3de69", "#fccde5", "#d9d9d9", "#bc80bd",
+}
+
+// highlightIndex resolves a highlight against a graph
+type highlightIndex[V comparable] struct {
+	directed bool
+	edges    map[[2]V]bool
+	group    map[V]int
+}
+
+// newHighlightIndex indexes a highlight; h may be nil
+func newHighlightIndex[V comparable, W Number](h *Highlight[V, W], directed bool) *highlightIndex[V] {
+	hi := &highlightIndex[V]{directed: directed, edges: make(map[[2]V]bool), group: make(map[V]int)}
+	if h == nil {
+		return hi
+	}
+	for _, edge := range h.Edges {
+		hi.edges[[2]V{edge.From, edge.To}] = true
+	}
+	for i, group := range h.Groups {
+		for _, v := range group {
+			hi.group[v] = i
+		}
+	}
+	return hi
+}
+
+// edge reports whether the edge between two vertices is highlighted
+func (hi *highlightIndex[V]) edge(from, to V) bool {
+	return hi.edges[[2]V{from, to}] || (!hi.directed && hi.edges[[2]V{to, from}])
+}
+
+// vertexGroup returns the group of a vertex, or -1
+func (hi *highlightIndex[V]) vertexGroup(v V) int {
+	if group, exists := hi.group[v]; exists {
+		return group
+	}
+	return -1
+}
+
+// groupColor returns the fill color of a vertex group
+func groupColor(group int) string {
+	return highlightPalette[group%len(highlightPalette)]
+}
+
+// graphSnapshot is a consistent copy of a graph taken for export
+type graphSnapshot[V comparable, W Number] struct {
+	directed    bool
+	vertices    []V
+	vertexAttrs []map[string]any
+	edges       []Edge[V, W] // Undirected edges appear once
+}
+
+// snapshot copies the vertices, attributes and edges of the graph under its read lock
+func (g *Graph[V, W]) snapshot() graphSnapshot[V, W] {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	s := graphSnapshot[V, W]{
+		directed:    g.directed,
+		vertices:    make([]V, g.vertices),
+		vertexAttrs: make([]map[string]any, g.vertices),
+		edges:       make([]Edge[V, W], 0),
+	}
+	copy(s.vertices, g.labels)
+	for v := 0; v < g.vertices; v++ {
+		s.vertexAttrs[v] = copyAttributes(g.vertexAttrs[v])
+		for _, edge := range g.adjList[v] {
+			if !g.directed && edge.To < v {
+				continue
+			}
+			s.edges = append(s.edges, g.toEdge(edge))
+		}
+	}
+	return s
+}
+
+// ToGraph converts the matrix to an adjacency-list graph with vertices 0..n-1.
+// Missing edges (math.MaxInt32) and the diagonal are left out.
+func (g *AdjMatrix) ToGraph() *Graph[int, int] {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	result := NewGraph(g.vertices, g.directed)
+	for i := 0; i < g.vertices; i++ {
+		for j := 0; j < g.vertices; j++ {
+			if i == j || g.matrix[i][j] == math.MaxInt32 || (!g.directed && j < i) {
+				continue
+			}
+			result.AddEdge(i, j, g.matrix[i][j])
+		}
+	}
+	return result
+}
+
+// NewAdjMatrixFromGraph builds an adjacency matrix from a graph, keeping the lightest of
+// parallel edges and truncating weights to int. It also returns the vertex of each row.
+func NewAdjMatrixFromGraph[V comparable, W Number](g *Graph[V, W]) (*AdjMatrix, []V) {
+	s := g.snapshot()
+	m := NewAdjMatrix(len(s.vertices), s.directed)
+	index := make(map[V]int, len(s.vertices))
+	for i, v := range s.vertices {
+		index[v] = i
+	}
+
+	seen := make(map[[2]int]bool)
+	for _, edge := range s.edges {
+		from, to := index[edge.From], index[edge.To]
+		weight := int(edge.Weight)
+		if seen[[2]int{from, to}] && m.matrix[from][to] <= weight {
+			continue
+		}
+		seen[[2]int{from, to}] = true
+		if !s.directed {
+			seen[[2]int{to, from}] = true
+		}
+		m.AddEdge(from, to, weight)
+	}
+	return m, s.vertices
+}
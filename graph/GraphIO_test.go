@@ -0,0 +1,467 @@
+package graph
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// edgeSet describes the edges of a graph as sorted "from|to|weight" strings,
+// ordering the endpoints of undirected edges
+func edgeSet[V comparable, W Number](g *Graph[V, W]) []string {
+	result := make([]string, 0)
+	for _, edge := range g.snapshot().edges {
+		from, to := fmt.Sprint(edge.From), fmt.Sprint(edge.To)
+		if !g.IsDirected() && to < from {
+			from, to = to, from
+		}
+		result = append(result, fmt.Sprintf("%s|%s|%v", from, to, edge.Weight))
+	}
+	sort.Strings(result)
+	return result
+}
+
+// sortedVertices returns the vertex labels of a graph in sorted order
+func sortedVertices[V comparable, W Number](g *Graph[V, W]) []string {
+	result := make([]string, 0)
+	for _, v := range g.Vertices() {
+		result = append(result, fmt.Sprint(v))
+	}
+	sort.Strings(result)
+	return result
+}
+
+// newDependencyGraph builds a graph with awkward labels, attributes, an isolated vertex,
+// a negative weight and a self-loop
+func newDependencyGraph(directed bool) *Graph[string, float64] {
+	g := NewLabeledGraph[string, float64](directed)
+	g.AddEdge("app", "lib core", 2.5)
+	g.AddEdgeWithAttributes("app", `say "hi"`, 1, map[string]any{"label": "uses"})
+	g.AddEdge("lib core", "node", -3)
+	g.AddEdge("node", "node", 4)
+	g.AddVertex("orphan")
+	g.SetVertexAttribute("app", "shape", "box")
+	return g
+}
+
+// checkRoundTrip compares vertices, edges and attributes of a graph read back with the original
+func checkRoundTrip(t *testing.T, original, read *Graph[string, float64]) {
+	t.Helper()
+	if read.IsDirected() != original.IsDirected() {
+		t.Errorf("Expected directed=%v", original.IsDirected())
+	}
+	if got, expected := sortedVertices(read), sortedVertices(original); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected vertices %v, got %v", expected, got)
+	}
+	if got, expected := edgeSet(read), edgeSet(original); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected edges %v, got %v", expected, got)
+	}
+	if shape, _ := read.VertexAttribute("app", "shape"); shape != "box" {
+		t.Errorf("Expected vertex attribute shape=box, got %v", shape)
+	}
+	if label, _ := read.EdgeAttribute("app", `say "hi"`, "label"); label != "uses" {
+		t.Errorf("Expected edge attribute label=uses, got %v", label)
+	}
+}
+
+func TestDOT(t *testing.T) {
+	t.Run("Round Trip", func(t *testing.T) {
+		for _, directed := range []bool{true, false} {
+			g := newDependencyGraph(directed)
+			var buf bytes.Buffer
+			if err := WriteDOT(&buf, g, nil); err != nil {
+				t.Fatal(err)
+			}
+			read, err := ReadDOT(&buf)
+			if err != nil {
+				t.Fatalf("Reading back %s: %v", buf.String(), err)
+			}
+			checkRoundTrip(t, g, read)
+		}
+	})
+
+	t.Run("Parse", func(t *testing.T) {
+		input := `strict digraph deps {
+			// Defaults are ignored
+			node [shape=ellipse];
+			rankdir=LR
+			a -> b -> c [weight=2, color=blue]
+			c:port -> "d e" # comment
+			/* block
+			   comment */
+			f [label="F \"quoted\""];
+		}`
+		g, err := ReadDOT(strings.NewReader(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := []string{"a|b|2", "b|c|2", "c|d e|1"}
+		if got := edgeSet(g); !reflect.DeepEqual(got, expected) {
+			t.Errorf("Expected edges %v, got %v", expected, got)
+		}
+		if color, _ := g.EdgeAttribute("b", "c", "color"); color != "blue" {
+			t.Errorf("Expected color=blue on b->c, got %v", color)
+		}
+		if label, _ := g.VertexAttribute("f", "label"); label != `F "quoted"` {
+			t.Errorf("Expected unescaped label, got %v", label)
+		}
+	})
+
+	t.Run("Weights", func(t *testing.T) {
+		g := NewLabeledGraph[string, float64](true)
+		g.AddEdge("a", "b", -1.5)
+		g.AddEdge("b", "c", 3)
+		g.AddEdgeWithAttributes("c", "a", 0.25, map[string]any{"label": "return"})
+
+		var buf bytes.Buffer
+		if err := WriteDOT(&buf, g, nil); err != nil {
+			t.Fatal(err)
+		}
+		for _, line := range []string{`a -> b [label=-1.5];`, `b -> c [weight=3, label=3];`, `c -> a [label=return];`} {
+			if !strings.Contains(buf.String(), line) {
+				t.Errorf("Expected %s in\n%s", line, buf.String())
+			}
+		}
+
+		read, err := ReadDOT(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Weights Graphviz cannot take come back from the label; the user's label blocks that
+		expected := []string{"a|b|-1.5", "b|c|3", "c|a|1"}
+		if got := edgeSet(read); !reflect.DeepEqual(got, expected) {
+			t.Errorf("Expected edges %v, got %v", expected, got)
+		}
+		if _, exists := read.EdgeAttribute("b", "c", "label"); exists {
+			t.Error("The generated weight label should not become an attribute")
+		}
+		if label, _ := read.EdgeAttribute("c", "a", "label"); label != "return" {
+			t.Errorf("Expected the label attribute to survive, got %v", label)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		inputs := []string{
+			"graph { a -> b }",
+			"digraph { a -- b }",
+			"digraph { subgraph s { a } }",
+			"digraph { a -> b [weight=heavy] }",
+			`digraph { "a }`,
+			"digraph { a -> b",
+			"tree { a }",
+		}
+		for _, input := range inputs {
+			if _, err := ReadDOT(strings.NewReader(input)); !errors.Is(err, ErrInvalidFormat) {
+				t.Errorf("Expected ErrInvalidFormat for %q, got %v", input, err)
+			}
+		}
+	})
+
+	t.Run("Highlight", func(t *testing.T) {
+		g := NewLabeledGraph[string, int](false)
+		g.AddEdge("a", "b", 1)
+		g.AddEdge("b", "c", 2)
+		g.AddEdge("a", "c", 5)
+		mst := NewKruskalMST(g)
+		mst.FindMST()
+
+		var buf bytes.Buffer
+		if err := WriteDOT(&buf, g, &Highlight[string, int]{Edges: mst.GetMSTEdges()}); err != nil {
+			t.Fatal(err)
+		}
+		if count := strings.Count(buf.String(), `color="red"`); count != 2 {
+			t.Errorf("Expected the 2 MST edges highlighted, got %d in\n%s", count, buf.String())
+		}
+		if !strings.Contains(buf.String(), `a -- c [weight=5, label=5];`) {
+			t.Errorf("Expected a plain a -- c edge in\n%s", buf.String())
+		}
+
+		d := NewLabeledGraph[string, int](true)
+		d.AddEdge("a", "b", 1)
+		d.AddEdge("b", "a", 1)
+		d.AddEdge("b", "c", 1)
+		buf.Reset()
+		if err := WriteDOT(&buf, d, &Highlight[string, int]{Groups: NewTarjanSCC(d).FindComponents()}); err != nil {
+			t.Fatal(err)
+		}
+		colors := make(map[string]bool)
+		for _, line := range strings.Split(buf.String(), "\n") {
+			if i := strings.Index(line, "fillcolor="); i != -1 {
+				colors[line[i:i+len(`fillcolor="#000000"`)]] = true
+			}
+		}
+		if len(colors) != 2 {
+			t.Errorf("Expected 2 component colors, got %v in\n%s", colors, buf.String())
+		}
+
+		// Reading back drops the highlight but keeps a color set by hand
+		d.SetVertexAttribute("c", "style", "dashed")
+		buf.Reset()
+		if err := WriteDOT(&buf, d, &Highlight[string, int]{Edges: []Edge[string, int]{{From: "a", To: "b"}}, Groups: [][]string{{"a", "b"}}}); err != nil {
+			t.Fatal(err)
+		}
+		read, err := ReadDOT(&buf)
+		if err != nil {
+			t.Fatalf("Reading back %s: %v", buf.String(), err)
+		}
+		for _, key := range []string{"color", "penwidth"} {
+			if value, exists := read.EdgeAttribute("a", "b", key); exists {
+				t.Errorf("Expected no %s attribute on a->b, got %v", key, value)
+			}
+		}
+		for _, key := range []string{"style", "fillcolor", "group"} {
+			if value, exists := read.VertexAttribute("a", key); exists {
+				t.Errorf("Expected no %s attribute on a, got %v", key, value)
+			}
+		}
+		if style, _ := read.VertexAttribute("c", "style"); style != "dashed" {
+			t.Errorf("Expected style=dashed to survive, got %v", style)
+		}
+	})
+
+	t.Run("Identifiers", func(t *testing.T) {
+		plain := []string{"a", "_x1", "5", "-5", "1.5", "-.5", "5."}
+		quoted := []string{"", "+5", "1e3", "1.2.3", "-", ".", "5a", "0x1F", "node", "a b"}
+		for _, id := range plain {
+			if got := dotID(id); got != id {
+				t.Errorf("Expected %q unquoted, got %s", id, got)
+			}
+		}
+		for _, id := range quoted {
+			if got := dotID(id); got != strconv.Quote(id) {
+				t.Errorf("Expected %q quoted, got %s", id, got)
+			}
+		}
+	})
+}
+
+func TestGraphML(t *testing.T) {
+	t.Run("Round Trip", func(t *testing.T) {
+		for _, directed := range []bool{true, false} {
+			g := newDependencyGraph(directed)
+			var buf bytes.Buffer
+			if err := WriteGraphML(&buf, g, nil); err != nil {
+				t.Fatal(err)
+			}
+			read, err := ReadGraphML(&buf)
+			if err != nil {
+				t.Fatalf("Reading back %s: %v", buf.String(), err)
+			}
+			checkRoundTrip(t, g, read)
+		}
+	})
+
+	t.Run("Weight Attribute", func(t *testing.T) {
+		for _, value := range []any{"heavy", 7} {
+			g := NewLabeledGraph[string, float64](false)
+			g.AddEdgeWithAttributes("a", "b", 2.5, map[string]any{"weight": value, "color": "red"})
+			var buf bytes.Buffer
+			if err := WriteGraphML(&buf, g, nil); err != nil {
+				t.Fatal(err)
+			}
+			read, err := ReadGraphML(&buf)
+			if err != nil {
+				t.Fatalf("Reading back a %T weight attribute: %v", value, err)
+			}
+			if got := edgeSet(read); !reflect.DeepEqual(got, []string{"a|b|2.5"}) {
+				t.Errorf("Expected the real weight 2.5 with a %T weight attribute, got %v", value, got)
+			}
+			if color, _ := read.EdgeAttribute("a", "b", "color"); color != "red" {
+				t.Errorf("Expected color=red to survive, got %v", color)
+			}
+		}
+	})
+
+	t.Run("Typed Keys And Defaults", func(t *testing.T) {
+		input := `<?xml version="1.0" encoding="UTF-8"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+  <key id="d0" for="edge" attr.name="weight" attr.type="double"/>
+  <key id="d1" for="node" attr.name="size" attr.type="int"><default>3</default></key>
+  <key id="d2" for="edge" attr.name="optional" attr.type="boolean"/>
+  <graph id="G" edgedefault="directed">
+    <node id="a"><data key="d1">7</data></node>
+    <node id="b"/>
+    <edge source="a" target="b"><data key="d0">0.5</data><data key="d2">true</data></edge>
+    <edge source="b" target="a"/>
+  </graph>
+</graphml>`
+		g, err := ReadGraphML(strings.NewReader(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := []string{"a|b|0.5", "b|a|1"}
+		if got := edgeSet(g); !reflect.DeepEqual(got, expected) || !g.IsDirected() {
+			t.Errorf("Expected directed edges %v, got %v", expected, got)
+		}
+		if size, _ := g.VertexAttribute("a", "size"); size != 7 {
+			t.Errorf("Expected size 7, got %v", size)
+		}
+		if size, _ := g.VertexAttribute("b", "size"); size != 3 {
+			t.Errorf("Expected default size 3, got %v", size)
+		}
+		if optional, _ := g.EdgeAttribute("a", "b", "optional"); optional != true {
+			t.Errorf("Expected optional=true, got %v", optional)
+		}
+
+		bad := strings.Replace(input, ">7<", ">seven<", 1)
+		if _, err := ReadGraphML(strings.NewReader(bad)); !errors.Is(err, ErrInvalidFormat) {
+			t.Errorf("Expected ErrInvalidFormat, got %v", err)
+		}
+	})
+
+	t.Run("Highlight", func(t *testing.T) {
+		g := NewLabeledGraph[string, int](true)
+		g.AddEdge("a", "b", 1)
+		g.AddEdge("b", "c", 1)
+		var buf bytes.Buffer
+		h := &Highlight[string, int]{Edges: []Edge[string, int]{{From: "b", To: "c"}}, Groups: [][]string{{"a"}, {"b", "c"}}}
+		if err := WriteGraphML(&buf, g, h); err != nil {
+			t.Fatal(err)
+		}
+		for _, data := range []string{`<data key="highlight">true</data>`, `<data key="group">1</data>`} {
+			if !strings.Contains(buf.String(), data) {
+				t.Errorf("Expected %s in\n%s", data, buf.String())
+			}
+		}
+
+		// The highlight keys are not read back, so the round trip keeps the graph unchanged
+		read, err := ReadGraphML(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, edge := range [][2]string{{"a", "b"}, {"b", "c"}} {
+			if value, exists := read.EdgeAttribute(edge[0], edge[1], "highlight"); exists {
+				t.Errorf("Expected no highlight attribute on %s->%s, got %v", edge[0], edge[1], value)
+			}
+		}
+		if value, exists := read.VertexAttribute("c", "group"); exists {
+			t.Errorf("Expected no group attribute on c, got %v", value)
+		}
+	})
+}
+
+func TestEdgeList(t *testing.T) {
+	t.Run("Round Trip", func(t *testing.T) {
+		for _, directed := range []bool{true, false} {
+			g := newDependencyGraph(directed)
+			var buf bytes.Buffer
+			if err := WriteEdgeList(&buf, g); err != nil {
+				t.Fatal(err)
+			}
+			read, err := ReadEdgeList(&buf, !directed) // The header wins
+			if err != nil {
+				t.Fatalf("Reading back %s: %v", buf.String(), err)
+			}
+			if read.IsDirected() != directed {
+				t.Errorf("Expected directed=%v from the header", directed)
+			}
+			if got, expected := edgeSet(read), edgeSet(g); !reflect.DeepEqual(got, expected) {
+				t.Errorf("Expected edges %v, got %v", expected, got)
+			}
+			if got, expected := sortedVertices(read), sortedVertices(g); !reflect.DeepEqual(got, expected) {
+				t.Errorf("Expected vertices %v, got %v", expected, got)
+			}
+		}
+	})
+
+	t.Run("Parse", func(t *testing.T) {
+		input := "# build order\n\na b 3\nb c # default weight\n\"d e\" a 0.25\nlonely\n"
+		g, err := ReadEdgeList(strings.NewReader(input), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := []string{"a|b|3", "b|c|1", "d e|a|0.25"}
+		if got := edgeSet(g); !reflect.DeepEqual(got, expected) {
+			t.Errorf("Expected edges %v, got %v", expected, got)
+		}
+		if !g.HasVertex("lonely") {
+			t.Error("Expected isolated vertex lonely")
+		}
+
+		for _, bad := range []string{"a b heavy", "a b 1 2", `"a b 1`} {
+			if _, err := ReadEdgeList(strings.NewReader(bad), true); !errors.Is(err, ErrInvalidFormat) {
+				t.Errorf("Expected ErrInvalidFormat for %q, got %v", bad, err)
+			}
+		}
+	})
+}
+
+func TestJSON(t *testing.T) {
+	t.Run("Round Trip", func(t *testing.T) {
+		for _, directed := range []bool{true, false} {
+			g := newDependencyGraph(directed)
+			var buf bytes.Buffer
+			if err := WriteJSON(&buf, g, nil); err != nil {
+				t.Fatal(err)
+			}
+			read, err := ReadJSON[string, float64](&buf)
+			if err != nil {
+				t.Fatalf("Reading back %s: %v", buf.String(), err)
+			}
+			checkRoundTrip(t, g, read)
+		}
+	})
+
+	t.Run("Integer Labels And Highlight", func(t *testing.T) {
+		g := NewGraph(4, false)
+		g.AddEdge(0, 1, 3)
+		g.AddEdge(1, 2, 4)
+		var buf bytes.Buffer
+		h := &Highlight[int, int]{Edges: []Edge[int, int]{{From: 2, To: 1}}, Groups: [][]int{{3}}}
+		if err := WriteJSON(&buf, g, h); err != nil {
+			t.Fatal(err)
+		}
+		if count := strings.Count(buf.String(), `"highlight": true`); count != 1 {
+			t.Errorf("Expected one highlighted edge in\n%s", buf.String())
+		}
+		if !strings.Contains(buf.String(), `"group": 0`) {
+			t.Errorf("Expected vertex 3 in group 0 in\n%s", buf.String())
+		}
+
+		read, err := ReadJSON[int, int](&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, expected := edgeSet(read), edgeSet(g); !reflect.DeepEqual(got, expected) || read.GetVertices() != 4 {
+			t.Errorf("Expected edges %v on 4 vertices, got %v", expected, got)
+		}
+
+		if _, err := ReadJSON[int, int](strings.NewReader(`{"adjacency": [{"vertex": "x"}]}`)); !errors.Is(err, ErrInvalidFormat) {
+			t.Errorf("Expected ErrInvalidFormat, got %v", err)
+		}
+	})
+}
+
+func TestAdjMatrixConversion(t *testing.T) {
+	g := NewLabeledGraph[string, float64](true)
+	g.AddEdge("a", "b", 4.7)
+	g.AddEdge("a", "b", 2)
+	g.AddEdge("b", "c", 1)
+	g.AddVertex("d")
+
+	m, labels := NewAdjMatrixFromGraph(g)
+	if !reflect.DeepEqual(labels, []string{"a", "b", "c", "d"}) {
+		t.Errorf("Expected labels [a b c d], got %v", labels)
+	}
+	if m.matrix[0][1] != 2 || m.matrix[1][2] != 1 || m.matrix[1][0] != math.MaxInt32 {
+		t.Errorf("Expected the lightest parallel edge and no reverse edge, got %v", m.matrix)
+	}
+
+	back := m.ToGraph()
+	expected := []string{"0|1|2", "1|2|1"}
+	if got := edgeSet(back); !reflect.DeepEqual(got, expected) || back.GetVertices() != 4 || !back.IsDirected() {
+		t.Errorf("Expected edges %v, got %v", expected, got)
+	}
+
+	u := NewAdjMatrix(3, false)
+	u.AddEdge(0, 2, 5)
+	if got := edgeSet(u.ToGraph()); !reflect.DeepEqual(got, []string{"0|2|5"}) {
+		t.Errorf("Expected one undirected edge, got %v", got)
+	}
+}
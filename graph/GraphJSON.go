@@ -0,0 +1,87 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// jsonGraph is the JSON adjacency format used by WriteJSON and ReadJSON
+type jsonGraph[V comparable, W Number] struct {
+	Directed  bool               `json:"directed"`
+	Adjacency []jsonVertex[V, W] `json:"adjacency"`
+}
+
+// jsonVertex is a vertex with its outgoing edges
+type jsonVertex[V comparable, W Number] struct {
+	Vertex     V                `json:"vertex"`
+	Attributes map[string]any   `json:"attributes,omitempty"`
+	Group      *int             `json:"group,omitempty"`
+	Edges      []jsonEdge[V, W] `json:"edges"`
+}
+
+// jsonEdge is an edge in a vertex's adjacency list
+type jsonEdge[V comparable, W Number] struct {
+	To         V              `json:"to"`
+	Weight     W              `json:"weight"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+	Highlight  bool           `json:"highlight,omitempty"`
+}
+
+// WriteJSON writes the graph as a JSON adjacency list:
+//
+//	{"directed": true, "adjacency": [{"vertex": "a", "edges": [{"to": "b", "weight": 2}]}]}
+//
+// Each undirected edge is listed once, under whichever endpoint was added first. With a
+// highlight, grouped vertices get a "group" index and highlighted edges "highlight": true;
+// h may be nil. Vertex labels must be representable in JSON.
+func WriteJSON[V comparable, W Number](w io.Writer, g *Graph[V, W], h *Highlight[V, W]) error {
+	s := g.snapshot()
+	hi := newHighlightIndex(h, s.directed)
+
+	doc := jsonGraph[V, W]{Directed: s.directed, Adjacency: make([]jsonVertex[V, W], len(s.vertices))}
+	index := make(map[V]int, len(s.vertices))
+	for i, v := range s.vertices {
+		index[v] = i
+		doc.Adjacency[i] = jsonVertex[V, W]{Vertex: v, Attributes: s.vertexAttrs[i], Edges: make([]jsonEdge[V, W], 0)}
+		if group := hi.vertexGroup(v); group != -1 {
+			doc.Adjacency[i].Group = &group
+		}
+	}
+	for _, edge := range s.edges {
+		entry := &doc.Adjacency[index[edge.From]]
+		entry.Edges = append(entry.Edges, jsonEdge[V, W]{
+			To:         edge.To,
+			Weight:     edge.Weight,
+			Attributes: edge.Attributes,
+			Highlight:  hi.edge(edge.From, edge.To),
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(doc)
+}
+
+// ReadJSON reads a graph in the JSON adjacency format of WriteJSON. Edge targets that are not
+// listed as vertices are added. Attribute values come back as decoded by encoding/json.
+func ReadJSON[V comparable, W Number](r io.Reader) (*Graph[V, W], error) {
+	var doc jsonGraph[V, W]
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: JSON: %v", ErrInvalidFormat, err)
+	}
+
+	g := NewLabeledGraph[V, W](doc.Directed)
+	for _, entry := range doc.Adjacency {
+		g.AddVertex(entry.Vertex)
+		for key, value := range entry.Attributes {
+			g.SetVertexAttribute(entry.Vertex, key, value)
+		}
+	}
+	for _, entry := range doc.Adjacency {
+		for _, edge := range entry.Edges {
+			g.AddEdgeWithAttributes(entry.Vertex, edge.To, edge.Weight, edge.Attributes)
+		}
+	}
+	return g, nil
+}
@@ -0,0 +1,270 @@
+package graph
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// graphMLNamespace is the GraphML XML namespace
+const graphMLNamespace = "http://graphml.graphdrawing.org/xmlns"
+
+// graphMLDocument is the root element of a GraphML file
+type graphMLDocument struct {
+	XMLName xml.Name       `xml:"graphml"`
+	Xmlns   string         `xml:"xmlns,attr,omitempty"`
+	Keys    []graphMLKey   `xml:"key"`
+	Graphs  []graphMLGraph `xml:"graph"`
+}
+
+// graphMLKey declares a data attribute for nodes or edges
+type graphMLKey struct {
+	ID      string `xml:"id,attr"`
+	For     string `xml:"for,attr"`
+	Name    string `xml:"attr.name,attr,omitempty"`
+	Type    string `xml:"attr.type,attr,omitempty"`
+	Default string `xml:"default,omitempty"`
+}
+
+// graphMLGraph holds the nodes and edges of one graph
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr,omitempty"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+// graphMLNode is a vertex with its data
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+// graphMLEdge is an edge with its data
+type graphMLEdge struct {
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+// graphMLData is the value of a declared key
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// WriteGraphML writes the graph as GraphML. Vertex labels and attribute values are written
+// with fmt.Sprint as string data; the edge weight uses the "weight" key, so an edge attribute
+// named "weight" is not written. With a highlight, edges get a boolean "highlight" key and
+// grouped vertices an int "group" key; h may be nil. Both keys are reserved and skipped by
+// ReadGraphML.
+func WriteGraphML[V comparable, W Number](w io.Writer, g *Graph[V, W], h *Highlight[V, W]) error {
+	s := g.snapshot()
+	hi := newHighlightIndex(h, s.directed)
+
+	doc := graphMLDocument{Xmlns: graphMLNamespace}
+	doc.Keys = append(doc.Keys, graphMLKey{ID: "weight", For: "edge", Name: "weight", Type: "double"})
+	if h != nil && len(h.Edges) > 0 {
+		doc.Keys = append(doc.Keys, graphMLKey{ID: "highlight", For: "edge", Name: "highlight", Type: "boolean", Default: "false"})
+	}
+	if h != nil && len(h.Groups) > 0 {
+		doc.Keys = append(doc.Keys, graphMLKey{ID: "group", For: "node", Name: "group", Type: "int"})
+	}
+
+	// Attribute keys get ids per kind so that a node and an edge attribute may share a name
+	nodeKeys := graphMLAttributeKeys(s.vertexAttrs, "n", "")
+	edgeAttrs := make([]map[string]any, len(s.edges))
+	for i, edge := range s.edges {
+		edgeAttrs[i] = edge.Attributes
+	}
+	edgeKeys := graphMLAttributeKeys(edgeAttrs, "e", "weight")
+	for _, name := range sortedKeys(nodeKeys) {
+		doc.Keys = append(doc.Keys, graphMLKey{ID: nodeKeys[name], For: "node", Name: name, Type: "string"})
+	}
+	for _, name := range sortedKeys(edgeKeys) {
+		doc.Keys = append(doc.Keys, graphMLKey{ID: edgeKeys[name], For: "edge", Name: name, Type: "string"})
+	}
+
+	graph := graphMLGraph{ID: "G", EdgeDefault: "undirected"}
+	if s.directed {
+		graph.EdgeDefault = "directed"
+	}
+	for i, v := range s.vertices {
+		node := graphMLNode{ID: fmt.Sprint(v), Data: graphMLValues(s.vertexAttrs[i], nodeKeys)}
+		if group := hi.vertexGroup(v); group != -1 {
+			node.Data = append(node.Data, graphMLData{Key: "group", Value: strconv.Itoa(group)})
+		}
+		graph.Nodes = append(graph.Nodes, node)
+	}
+	for _, e := range s.edges {
+		edge := graphMLEdge{Source: fmt.Sprint(e.From), Target: fmt.Sprint(e.To)}
+		edge.Data = append(edge.Data, graphMLData{Key: "weight", Value: fmt.Sprint(e.Weight)})
+		edge.Data = append(edge.Data, graphMLValues(e.Attributes, edgeKeys)...)
+		if hi.edge(e.From, e.To) {
+			edge.Data = append(edge.Data, graphMLData{Key: "highlight", Value: "true"})
+		}
+		graph.Edges = append(graph.Edges, edge)
+	}
+	doc.Graphs = []graphMLGraph{graph}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// graphMLAttributeKeys assigns a key id to every attribute name used in the maps, leaving out
+// one reserved name
+func graphMLAttributeKeys(attributes []map[string]any, prefix, reserved string) map[string]string {
+	keys := make(map[string]string)
+	for _, attrs := range attributes {
+		for name := range attrs {
+			if name != reserved {
+				keys[name] = ""
+			}
+		}
+	}
+	for i, name := range sortedKeys(keys) {
+		keys[name] = prefix + strconv.Itoa(i)
+	}
+	return keys
+}
+
+// graphMLValues converts attributes with a key to data elements in key order
+func graphMLValues(attributes map[string]any, keys map[string]string) []graphMLData {
+	data := make([]graphMLData, 0, len(attributes))
+	for _, name := range sortedKeys(attributes) {
+		if _, declared := keys[name]; !declared {
+			continue
+		}
+		data = append(data, graphMLData{Key: keys[name], Value: fmt.Sprint(attributes[name])})
+	}
+	return data
+}
+
+// sortedKeys returns the keys of a string-keyed map in order
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ReadGraphML reads the first graph of a GraphML document. The graph's edgedefault sets
+// directedness, the edge key named "weight" sets edge weights (1 if missing), and other data
+// become attributes converted by their declared attr.type (boolean, int, long, float, double
+// or string). Key defaults apply to vertices and edges without a value. The "group" vertex
+// and "highlight" edge attributes WriteGraphML reserves for highlighting are skipped.
+func ReadGraphML(r io.Reader) (*Graph[string, float64], error) {
+	var doc graphMLDocument
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: GraphML: %v", ErrInvalidFormat, err)
+	}
+	if len(doc.Graphs) == 0 {
+		return nil, fmt.Errorf("%w: GraphML: no graph element", ErrInvalidFormat)
+	}
+	graph := doc.Graphs[0]
+	keys := make(map[string]graphMLKey, len(doc.Keys))
+	for _, key := range doc.Keys {
+		if key.Name == "" {
+			key.Name = key.ID
+		}
+		keys[key.ID] = key
+	}
+
+	g := NewLabeledGraph[string, float64](graph.EdgeDefault == "directed")
+	for _, node := range graph.Nodes {
+		attrs, err := graphMLAttributes(node.Data, keys, "node")
+		if err != nil {
+			return nil, fmt.Errorf("node %q: %w", node.ID, err)
+		}
+		delete(attrs, "group")
+		g.AddVertex(node.ID)
+		for name, value := range attrs {
+			g.SetVertexAttribute(node.ID, name, value)
+		}
+	}
+	for _, edge := range graph.Edges {
+		attrs, err := graphMLAttributes(edge.Data, keys, "edge")
+		if err != nil {
+			return nil, fmt.Errorf("edge %q-%q: %w", edge.Source, edge.Target, err)
+		}
+		weight := 1.0
+		if value, exists := attrs["weight"]; exists {
+			switch value := value.(type) {
+			case float64:
+				weight = value
+			case int:
+				weight = float64(value)
+			default:
+				if weight, err = strconv.ParseFloat(fmt.Sprint(value), 64); err != nil {
+					return nil, fmt.Errorf("%w: GraphML: invalid weight %q", ErrInvalidFormat, value)
+				}
+			}
+			delete(attrs, "weight")
+		}
+		delete(attrs, "highlight")
+		if len(attrs) == 0 {
+			attrs = nil
+		}
+		g.AddEdgeWithAttributes(edge.Source, edge.Target, weight, attrs)
+	}
+	return g, nil
+}
+
+// graphMLAttributes converts data elements to typed attributes by attribute name, filling in
+// defaults of keys declared for the element kind or for all
+func graphMLAttributes(data []graphMLData, keys map[string]graphMLKey, kind string) (map[string]any, error) {
+	attrs := make(map[string]any)
+	for _, key := range keys {
+		if key.Default != "" && (key.For == kind || key.For == "all") {
+			value, err := graphMLValue(key, key.Default)
+			if err != nil {
+				return nil, err
+			}
+			attrs[key.Name] = value
+		}
+	}
+	for _, d := range data {
+		key, exists := keys[d.Key]
+		if !exists {
+			key = graphMLKey{ID: d.Key, Name: d.Key}
+		}
+		value, err := graphMLValue(key, d.Value)
+		if err != nil {
+			return nil, err
+		}
+		attrs[key.Name] = value
+	}
+	return attrs, nil
+}
+
+// graphMLValue parses a data value by the key's attr.type
+func graphMLValue(key graphMLKey, value string) (any, error) {
+	var parsed any
+	var err error
+	switch key.Type {
+	case "boolean":
+		parsed, err = strconv.ParseBool(value)
+	case "int", "long":
+		parsed, err = strconv.Atoi(value)
+	case "float", "double":
+		parsed, err = strconv.ParseFloat(value, 64)
+	default:
+		parsed = value
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: GraphML: invalid %s value %q for key %q", ErrInvalidFormat, key.Type, value, key.Name)
+	}
+	return parsed, nil
+}
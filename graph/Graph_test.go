@@ -49,7 +49,7 @@ func TestGraph(t *testing.T) {
 		g.AddEdge(2, 3, 5)
 		g.AddEdge(3, 4, 3)
 
-		distances := g.Dijkstra(0)
+		distances := g.Dijkstra(0).Distances()
 		expected := map[int]int{
 			0: 0, // Başlangıç noktası
 			1: 3, // 0->2->1
@@ -134,3 +134,354 @@ func TestGraph(t *testing.T) {
 		}
 	})
 }
+
+func TestLabeledGraph(t *testing.T) {
+	t.Run("String Labels And Float Weights", func(t *testing.T) {
+		g := NewLabeledGraph[string, float64](true)
+		g.AddEdge("api", "auth", 1.5)
+		g.AddEdge("api", "db", 4.0)
+		g.AddEdge("auth", "db", 0.5)
+
+		if !g.HasVertex("auth") || g.HasVertex("cache") {
+			t.Error("HasVertex returned an unexpected result")
+		}
+		if g.GetVertices() != 3 {
+			t.Errorf("Expected 3 vertices, got %d", g.GetVertices())
+		}
+		if !reflect.DeepEqual(g.BFS("api"), []string{"api", "auth", "db"}) {
+			t.Errorf("Unexpected BFS order %v", g.BFS("api"))
+		}
+		if len(g.BFS("cache")) != 0 {
+			t.Error("BFS from an unknown vertex should be empty")
+		}
+
+		distances := g.Dijkstra("api").Distances()
+		if distances["db"] != 2.0 {
+			t.Errorf("Expected distance 2.0 to db, got %v", distances["db"])
+		}
+
+		ts := NewTopologicalSort(g)
+		if !reflect.DeepEqual(ts.Sort(), []string{"api", "auth", "db"}) {
+			t.Errorf("Unexpected topological order %v", ts.Sort())
+		}
+	})
+
+	t.Run("Attributes", func(t *testing.T) {
+		g := NewLabeledGraph[string, int](false)
+		g.AddEdgeWithAttributes("a", "b", 3, map[string]any{"color": "red"})
+		g.AddVertex("c")
+
+		if value, ok := g.EdgeAttribute("b", "a", "color"); !ok || value != "red" {
+			t.Errorf("Expected shared edge attribute, got %v", value)
+		}
+		if !g.SetEdgeAttribute("a", "b", "latency", 12) {
+			t.Error("SetEdgeAttribute should succeed on an existing edge")
+		}
+		if value, _ := g.EdgeAttribute("b", "a", "latency"); value != 12 {
+			t.Errorf("Expected latency 12 on the reverse edge, got %v", value)
+		}
+		if g.SetEdgeAttribute("a", "c", "latency", 1) {
+			t.Error("SetEdgeAttribute should fail on a missing edge")
+		}
+
+		// Edges hands out copies, so changing them leaves the graph alone
+		g.Edges()[0].Attributes["color"] = "blue"
+		if value, _ := g.EdgeAttribute("a", "b", "color"); value != "red" {
+			t.Errorf("Changing a returned edge altered the graph: color %v", value)
+		}
+
+		g.SetVertexAttribute("c", "owner", "team-x")
+		if value, ok := g.VertexAttribute("c", "owner"); !ok || value != "team-x" {
+			t.Errorf("Expected vertex attribute team-x, got %v", value)
+		}
+		if g.SetVertexAttribute("missing", "owner", "team-y") {
+			t.Error("SetVertexAttribute should fail on an unknown vertex")
+		}
+	})
+
+	t.Run("Remove Vertex And Edge", func(t *testing.T) {
+		g := NewLabeledGraph[string, int](false)
+		g.AddEdge("a", "b", 1)
+		g.AddEdge("b", "c", 2)
+		g.AddEdge("c", "d", 3)
+		g.SetVertexAttribute("d", "tier", 2)
+
+		if g.AddVertex("a") {
+			t.Error("AddVertex should report an existing vertex")
+		}
+		if !g.RemoveVertex("b") || g.RemoveVertex("b") {
+			t.Error("RemoveVertex should succeed once")
+		}
+		if !reflect.DeepEqual(g.Vertices(), []string{"a", "c", "d"}) {
+			t.Errorf("Unexpected vertices %v", g.Vertices())
+		}
+		if len(g.GetNeighbors("a")) != 0 {
+			t.Errorf("Edges of a removed vertex should be gone, got %v", g.GetNeighbors("a"))
+		}
+		if !reflect.DeepEqual(g.GetNeighbors("c"), []string{"d"}) {
+			t.Errorf("Expected c to keep its edge to d, got %v", g.GetNeighbors("c"))
+		}
+		if value, _ := g.VertexAttribute("d", "tier"); value != 2 {
+			t.Error("Vertex attributes should follow the vertex after reindexing")
+		}
+
+		if !g.RemoveEdge("d", "c") {
+			t.Error("RemoveEdge should remove an existing edge")
+		}
+		if len(g.GetNeighbors("c")) != 0 || len(g.Edges()) != 0 {
+			t.Error("Removing an undirected edge should remove both directions")
+		}
+		if g.RemoveEdge("c", "d") {
+			t.Error("RemoveEdge should fail on a missing edge")
+		}
+	})
+
+	t.Run("Algorithms On Labels", func(t *testing.T) {
+		g := NewLabeledGraph[string, float64](false)
+		g.AddEdge("x", "y", 1.5)
+		g.AddEdge("y", "z", 2.5)
+		g.AddEdge("x", "z", 5)
+
+		kruskal := NewKruskalMST(g)
+		kruskal.FindMST()
+		if kruskal.GetMSTCost() != 4.0 || !kruskal.IsConnected("x", "z") {
+			t.Errorf("Unexpected Kruskal MST cost %v", kruskal.GetMSTCost())
+		}
+
+		prim := NewPrimMST(g)
+		prim.FindMST()
+		for _, edge := range prim.GetMSTEdges() {
+			if edge.Weight != 1.5 && edge.Weight != 2.5 {
+				t.Errorf("Prim kept fractional weights incorrectly: %v", edge)
+			}
+		}
+
+		ap := NewArticulationPoints(g)
+		if len(ap.FindArticulationPoints()) != 0 {
+			t.Error("A triangle has no articulation points")
+		}
+
+		bf := NewBellmanFord(g, "x")
+		bf.ComputeShortestPaths()
+		if !reflect.DeepEqual(bf.GetPath("z"), []string{"x", "y", "z"}) {
+			t.Errorf("Unexpected Bellman-Ford path %v", bf.GetPath("z"))
+		}
+	})
+}
+
+func TestMutationAPI(t *testing.T) {
+	t.Run("Degrees And Weights", func(t *testing.T) {
+		g := NewLabeledGraph[string, int](true)
+		g.AddEdge("a", "b", 1)
+		g.AddEdge("a", "c", 2)
+		g.AddEdge("c", "b", 3)
+
+		if !g.HasEdge("a", "b") || g.HasEdge("b", "a") || g.HasEdge("a", "x") {
+			t.Error("HasEdge must follow edge direction")
+		}
+		if g.OutDegree("a") != 2 || g.InDegree("b") != 2 || g.InDegree("a") != 0 || g.OutDegree("x") != 0 {
+			t.Errorf("Unexpected degrees: out(a)=%d in(b)=%d", g.OutDegree("a"), g.InDegree("b"))
+		}
+		if g.EdgeCount() != 3 {
+			t.Errorf("Expected 3 edges, got %d", g.EdgeCount())
+		}
+
+		if !g.UpdateWeight("c", "b", 10) || g.UpdateWeight("b", "c", 1) {
+			t.Error("UpdateWeight must report whether the edge exists")
+		}
+		if d, _ := g.Dijkstra("a").DistanceTo("b"); d != 1 {
+			t.Errorf("Expected distance 1 to b, got %d", d)
+		}
+		g.UpdateWeight("a", "b", 20)
+		if d, _ := g.Dijkstra("a").DistanceTo("b"); d != 12 {
+			t.Errorf("Expected distance 12 via c after the update, got %d", d)
+		}
+
+		u := NewGraph(3, false)
+		u.AddEdge(0, 1, 5)
+		u.AddEdge(1, 1, 1)
+		u.UpdateWeight(1, 0, 7)
+		if u.EdgeCount() != 2 || u.InDegree(1) != 2 {
+			t.Errorf("Expected 2 undirected edges, got %v", u.Edges())
+		}
+		for _, edge := range u.Edges() {
+			if edge.From == 0 && edge.Weight != 7 {
+				t.Errorf("Expected weight 7 on 0-1, got %d", edge.Weight)
+			}
+		}
+	})
+
+	t.Run("Change Listeners", func(t *testing.T) {
+		g := NewLabeledGraph[string, int](true)
+		changes := make([]Change[string, int], 0)
+		unsubscribe := g.OnChange(func(c Change[string, int]) {
+			changes = append(changes, c)
+			g.HasEdge(c.From, c.To) // Listeners run outside the lock
+		})
+
+		version := g.Version()
+		g.AddEdge("a", "b", 1)
+		g.AddEdge("b", "c", 2)
+		g.UpdateWeight("a", "b", 5)
+		g.SetVertexAttribute("a", "color", "red")
+		g.RemoveVertex("b")
+
+		kinds := make([]string, len(changes))
+		for i, c := range changes {
+			kinds[i] = c.Kind.String()
+		}
+		expected := []string{
+			"VertexAdded", "VertexAdded", "EdgeAdded",
+			"VertexAdded", "EdgeAdded",
+			"WeightUpdated",
+			"EdgeRemoved", "EdgeRemoved", "VertexRemoved",
+		}
+		if !reflect.DeepEqual(kinds, expected) {
+			t.Fatalf("Expected changes %v, got %v", expected, kinds)
+		}
+		if c := changes[5]; c.From != "a" || c.To != "b" || c.Weight != 5 {
+			t.Errorf("Unexpected weight update %+v", c)
+		}
+		if g.Version() != version+uint64(len(changes)) || changes[len(changes)-1].Version != g.Version() {
+			t.Errorf("Expected the version to count the %d changes, got %d -> %d", len(changes), version, g.Version())
+		}
+
+		unsubscribe()
+		g.AddVertex("d")
+		if len(changes) != len(expected) {
+			t.Error("Unsubscribed listener was called")
+		}
+	})
+
+	t.Run("Stale Analyses", func(t *testing.T) {
+		g := NewLabeledGraph[string, int](true)
+		g.AddEdge("lib", "app", 1)
+		ts := NewTopologicalSort(g)
+		if !ts.IsStale() {
+			t.Error("Expected a stale sort before the first run")
+		}
+		ts.Sort()
+		if ts.IsStale() {
+			t.Error("Expected a fresh sort")
+		}
+
+		g.AddEdge("app", "lib", 1)
+		if !ts.IsStale() || !ts.HasCycle() || ts.GetDependencyOrder() != nil {
+			t.Error("Expected the new cycle to be picked up")
+		}
+		g.RemoveEdge("app", "lib")
+		if ts.HasCycle() || len(ts.GetDependencyOrder()) != 2 {
+			t.Error("Expected the order to be recomputed after removing the cycle")
+		}
+
+		tarjan := NewTarjanSCC(g)
+		if len(tarjan.GetComponents()) != 2 {
+			t.Fatal("Expected 2 components")
+		}
+		g.AddEdge("app", "lib", 1)
+		if !tarjan.IsStale() || len(tarjan.GetComponents()) != 1 {
+			t.Error("Expected GetComponents to recompute after the change")
+		}
+		g.RemoveEdge("app", "lib")
+		if tarjan.GetComponentCount() != 2 || tarjan.IsStronglyConnected() || len(tarjan.GetComponents()) != 2 {
+			t.Error("Expected every Tarjan getter to recompute after the change")
+		}
+
+		kosaraju := NewSCC(g)
+		g.AddEdge("app", "lib", 1)
+		if kosaraju.GetComponentCount() != 1 || len(kosaraju.GetComponents()) != 1 {
+			t.Fatal("Expected 1 component")
+		}
+		g.RemoveEdge("app", "lib")
+		if !kosaraju.IsStale() || kosaraju.GetComponentCount() != 2 || len(kosaraju.GetComponents()) != 2 {
+			t.Error("Expected every Kosaraju getter to recompute after the change")
+		}
+
+		u := NewGraph(3, false)
+		u.AddEdge(0, 1, 1)
+		u.AddEdge(1, 2, 1)
+		mst := NewKruskalMST(u)
+		mst.FindMST()
+		u.UpdateWeight(0, 1, 4)
+		if !mst.IsStale() {
+			t.Error("Expected the MST to be stale after a weight update")
+		}
+		u.SetEdgeAttribute(1, 2, "note", "attributes do not count")
+		mst.FindMST()
+		if mst.IsStale() || mst.GetMSTCost() != 5 {
+			t.Errorf("Expected a fresh MST of cost 5, got %v", mst.GetMSTCost())
+		}
+
+		// Results computed before RemoveVertex keep naming the vertices they were found on
+		l := NewLabeledGraph[string, int](false)
+		l.AddEdge("a", "b", 1)
+		l.AddEdge("b", "c", 2)
+		kruskal, prim := NewKruskalMST(l), NewPrimMST(l)
+		kruskal.FindMST()
+		prim.FindMST()
+		l.RemoveVertex("a")
+		for name, edges := range map[string][]Edge[string, int]{"Kruskal": kruskal.GetMSTEdges(), "Prim": prim.GetMSTEdges()} {
+			touchesA := false
+			for _, edge := range edges {
+				touchesA = touchesA || edge.From == "a" || edge.To == "a"
+			}
+			if len(edges) != 2 || !touchesA {
+				t.Errorf("%s: expected the edges of the computed tree, got %v", name, edges)
+			}
+		}
+		if prim.GetParent("c") != "b" || !prim.IsInMST("c") || !kruskal.IsConnected("a", "c") {
+			t.Error("Expected MST lookups by the labels the tree was computed on")
+		}
+
+		l.AddEdge("c", "d", 1)
+		l.AddEdge("d", "e", 1)
+		ap := NewArticulationPoints(l)
+		if ap.GetArticulationPointCount() != 2 || !ap.IsArticulationPoint("c") {
+			t.Fatal("Expected articulation points c and d")
+		}
+		l.RemoveVertex("b")
+		if ap.IsArticulationPoint("c") || !ap.IsArticulationPoint("d") || ap.GetBridgeCount() != 2 || !ap.IsBridge("d", "e") {
+			t.Error("Expected articulation points and bridges to be recomputed after RemoveVertex")
+		}
+
+		// Counting components must not reset a computed MST
+		kruskal = NewKruskalMST(l)
+		kruskal.FindMST()
+		cost := kruskal.GetMSTCost()
+		if kruskal.GetNumComponents() != 1 || kruskal.IsStale() || kruskal.GetMSTCost() != cost || len(kruskal.GetMSTEdges()) != 2 {
+			t.Error("Expected GetNumComponents to leave the MST alone")
+		}
+	})
+
+	t.Run("Concurrent Analyses", func(t *testing.T) {
+		directed := NewGraph(0, true)
+		undirected := NewGraph(0, false)
+		ts, tarjan, kosaraju := NewTopologicalSort(directed), NewTarjanSCC(directed), NewSCC(directed)
+		euler, ap, kruskal := NewEulerPath(undirected), NewArticulationPoints(undirected), NewKruskalMST(undirected)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for v := 1; v < 200; v++ {
+				directed.AddEdge(v-1, v, 1)
+				undirected.AddEdge(v-1, v, 1)
+			}
+		}()
+		for running := true; running; {
+			select {
+			case <-done:
+				running = false
+			default:
+			}
+			ts.Sort()
+			tarjan.GetComponents()
+			kosaraju.GetComponentCount()
+			euler.FindEulerPath()
+			ap.FindArticulationPoints()
+			kruskal.GetNumComponents()
+		}
+		if len(ts.Sort()) != 200 || len(euler.FindEulerPath()) != 200 || kruskal.GetNumComponents() != 1 {
+			t.Error("Expected the analyses to see the whole path")
+		}
+	})
+}
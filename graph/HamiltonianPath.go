@@ -1,19 +1,64 @@
 package graph
 
-import "sync"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrBudgetExceeded is returned when a search runs out of its step or time budget
+var ErrBudgetExceeded = errors.New("graph: search budget exceeded")
+
+// budgetCheckInterval is how many search steps pass between context checks
+const budgetCheckInterval = 1024
+
+// searchBudget limits a backtracking search by steps and by a context; a nil budget is unlimited
+type searchBudget struct {
+	ctx      context.Context
+	maxSteps int // 0 means no step limit
+	steps    int
+	err      error
+}
+
+// spend counts one search step, returning false once the budget is exhausted
+func (b *searchBudget) spend() bool {
+	if b == nil {
+		return true
+	}
+	if b.err != nil {
+		return false
+	}
+	b.steps++
+	if b.maxSteps > 0 && b.steps > b.maxSteps {
+		b.err = fmt.Errorf("%w: more than %d steps", ErrBudgetExceeded, b.maxSteps)
+	} else if b.steps%budgetCheckInterval == 0 {
+		b.err = contextError(b.ctx)
+	}
+	return b.err == nil
+}
+
+// contextError returns the context's error, reporting a passed deadline as ErrBudgetExceeded
+func contextError(ctx context.Context) error {
+	err := ctx.Err()
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("%w: %w", ErrBudgetExceeded, err)
+	}
+	return err
+}
 
 // HamiltonianPath implements algorithms for finding Hamiltonian paths and circuits
-type HamiltonianPath struct {
-	graph   *Graph
+type HamiltonianPath[V comparable, W Number] struct {
+	graph   *Graph[V, W]
 	path    []int
 	visited []bool
 	mutex   sync.RWMutex
 }
 
 // NewHamiltonianPath creates a new HamiltonianPath instance
-func NewHamiltonianPath(g *Graph) *HamiltonianPath {
+func NewHamiltonianPath[V comparable, W Number](g *Graph[V, W]) *HamiltonianPath[V, W] {
 	n := g.GetVertices()
-	return &HamiltonianPath{
+	return &HamiltonianPath[V, W]{
 		graph:   g,
 		path:    make([]int, 0),
 		visited: make([]bool, n),
@@ -22,11 +67,13 @@ func NewHamiltonianPath(g *Graph) *HamiltonianPath {
 }
 
 // FindHamiltonianPath finds a Hamiltonian path in the graph if it exists
-func (hp *HamiltonianPath) FindHamiltonianPath() []int {
+func (hp *HamiltonianPath[V, W]) FindHamiltonianPath() []V {
 	hp.mutex.Lock()
 	defer hp.mutex.Unlock()
+	hp.graph.mutex.RLock()
+	defer hp.graph.mutex.RUnlock()
 
-	n := hp.graph.GetVertices()
+	n := hp.graph.vertices
 	hp.path = make([]int, 0)
 	hp.visited = make([]bool, n)
 
@@ -36,8 +83,8 @@ func (hp *HamiltonianPath) FindHamiltonianPath() []int {
 		hp.visited = make([]bool, n)
 		hp.visited[start] = true
 
-		if hp.hamiltonianPathUtil(1) {
-			return hp.path
+		if hp.hamiltonianPathUtil(1, nil) {
+			return hp.graph.labelsOf(hp.path)
 		}
 	}
 
@@ -45,29 +92,97 @@ func (hp *HamiltonianPath) FindHamiltonianPath() []int {
 }
 
 // FindHamiltonianCircuit finds a Hamiltonian circuit in the graph if it exists
-func (hp *HamiltonianPath) FindHamiltonianCircuit() []int {
+func (hp *HamiltonianPath[V, W]) FindHamiltonianCircuit() []V {
 	hp.mutex.Lock()
 	defer hp.mutex.Unlock()
+	hp.graph.mutex.RLock()
+	defer hp.graph.mutex.RUnlock()
 
-	n := hp.graph.GetVertices()
+	n := hp.graph.vertices
 	hp.path = make([]int, 0)
 	hp.visited = make([]bool, n)
+	if n == 0 {
+		return nil
+	}
 
 	// Start from 0
 	hp.path = []int{0}
 	hp.visited[0] = true
 
-	if hp.hamiltonianCircuitUtil(1) {
-		return hp.path
+	if hp.hamiltonianCircuitUtil(1, nil) {
+		return hp.graph.labelsOf(hp.path)
 	}
 
 	return nil
 }
 
-// hamiltonianPathUtil performs backtracking to find Hamiltonian path
-func (hp *HamiltonianPath) hamiltonianPathUtil(pos int) bool {
+// FindHamiltonianPathContext is FindHamiltonianPath with a budget: the search stops after
+// maxSteps backtracking steps (0 means no limit) or when ctx is done. It returns
+// ErrBudgetExceeded when the steps run out or the context's deadline passes, the context's
+// error if it is cancelled, and a nil path without error if no Hamiltonian path exists.
+func (hp *HamiltonianPath[V, W]) FindHamiltonianPathContext(ctx context.Context, maxSteps int) ([]V, error) {
+	hp.mutex.Lock()
+	defer hp.mutex.Unlock()
+	hp.graph.mutex.RLock()
+	defer hp.graph.mutex.RUnlock()
+
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+	budget := &searchBudget{ctx: ctx, maxSteps: maxSteps}
+	n := hp.graph.vertices
+	for start := 0; start < n; start++ {
+		hp.path = []int{start}
+		hp.visited = make([]bool, n)
+		hp.visited[start] = true
+
+		if hp.hamiltonianPathUtil(1, budget) {
+			return hp.graph.labelsOf(hp.path), nil
+		}
+		if budget.err != nil {
+			hp.path = make([]int, 0)
+			return nil, budget.err
+		}
+	}
+	return nil, nil
+}
+
+// FindHamiltonianCircuitContext is FindHamiltonianCircuit with the budget and errors of
+// FindHamiltonianPathContext
+func (hp *HamiltonianPath[V, W]) FindHamiltonianCircuitContext(ctx context.Context, maxSteps int) ([]V, error) {
+	hp.mutex.Lock()
+	defer hp.mutex.Unlock()
+	hp.graph.mutex.RLock()
+	defer hp.graph.mutex.RUnlock()
+
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+	n := hp.graph.vertices
+	if n == 0 {
+		return nil, nil
+	}
+	budget := &searchBudget{ctx: ctx, maxSteps: maxSteps}
+	hp.path = []int{0}
+	hp.visited = make([]bool, n)
+	hp.visited[0] = true
+
+	if hp.hamiltonianCircuitUtil(1, budget) {
+		return hp.graph.labelsOf(hp.path), nil
+	}
+	hp.path = make([]int, 0)
+	return nil, budget.err
+}
+
+// hamiltonianPathUtil performs backtracking to find Hamiltonian path; budget may be nil. The
+// caller must hold the graph's read lock.
+func (hp *HamiltonianPath[V, W]) hamiltonianPathUtil(pos int, budget *searchBudget) bool {
+	if !budget.spend() {
+		return false
+	}
+
 	// All nodes visited?
-	if pos == hp.graph.GetVertices() {
+	if pos == len(hp.visited) {
 		return true
 	}
 
@@ -78,7 +193,7 @@ func (hp *HamiltonianPath) hamiltonianPathUtil(pos int) bool {
 			hp.visited[edge.To] = true
 			hp.path = append(hp.path, edge.To)
 
-			if hp.hamiltonianPathUtil(pos + 1) {
+			if hp.hamiltonianPathUtil(pos+1, budget) {
 				return true
 			}
 
@@ -91,10 +206,15 @@ func (hp *HamiltonianPath) hamiltonianPathUtil(pos int) bool {
 	return false
 }
 
-// hamiltonianCircuitUtil performs backtracking to find Hamiltonian circuit
-func (hp *HamiltonianPath) hamiltonianCircuitUtil(pos int) bool {
+// hamiltonianCircuitUtil performs backtracking to find Hamiltonian circuit; budget may be nil.
+// The caller must hold the graph's read lock.
+func (hp *HamiltonianPath[V, W]) hamiltonianCircuitUtil(pos int, budget *searchBudget) bool {
+	if !budget.spend() {
+		return false
+	}
+
 	// All nodes visited?
-	if pos == hp.graph.GetVertices() {
+	if pos == len(hp.visited) {
 		// Check if there's an edge from the last node to the start node
 		lastVertex := hp.path[len(hp.path)-1]
 		hasEdgeToStart := false
@@ -118,7 +238,7 @@ func (hp *HamiltonianPath) hamiltonianCircuitUtil(pos int) bool {
 			hp.visited[edge.To] = true
 			hp.path = append(hp.path, edge.To)
 
-			if hp.hamiltonianCircuitUtil(pos + 1) {
+			if hp.hamiltonianCircuitUtil(pos+1, budget) {
 				return true
 			}
 
@@ -132,11 +252,13 @@ func (hp *HamiltonianPath) hamiltonianCircuitUtil(pos int) bool {
 }
 
 // HasHamiltonianPath checks if the graph has a Hamiltonian path
-func (hp *HamiltonianPath) HasHamiltonianPath() bool {
+func (hp *HamiltonianPath[V, W]) HasHamiltonianPath() bool {
 	hp.mutex.Lock()
 	defer hp.mutex.Unlock()
+	hp.graph.mutex.RLock()
+	defer hp.graph.mutex.RUnlock()
 
-	n := hp.graph.GetVertices()
+	n := hp.graph.vertices
 	hp.path = make([]int, 0)
 	hp.visited = make([]bool, n)
 
@@ -146,7 +268,7 @@ func (hp *HamiltonianPath) HasHamiltonianPath() bool {
 		hp.visited = make([]bool, n)
 		hp.visited[start] = true
 
-		if hp.hamiltonianPathUtil(1) {
+		if hp.hamiltonianPathUtil(1, nil) {
 			return true
 		}
 	}
@@ -155,39 +277,53 @@ func (hp *HamiltonianPath) HasHamiltonianPath() bool {
 }
 
 // HasHamiltonianCircuit checks if the graph has a Hamiltonian circuit
-func (hp *HamiltonianPath) HasHamiltonianCircuit() bool {
+func (hp *HamiltonianPath[V, W]) HasHamiltonianCircuit() bool {
 	hp.mutex.Lock()
 	defer hp.mutex.Unlock()
+	hp.graph.mutex.RLock()
+	defer hp.graph.mutex.RUnlock()
 
-	n := hp.graph.GetVertices()
+	n := hp.graph.vertices
 	hp.path = make([]int, 0)
 	hp.visited = make([]bool, n)
+	if n == 0 {
+		return false
+	}
 
 	// Start from 0
 	hp.path = []int{0}
 	hp.visited[0] = true
 
-	return hp.hamiltonianCircuitUtil(1)
+	return hp.hamiltonianCircuitUtil(1, nil)
 }
 
 // GetPath returns the last found path
-func (hp *HamiltonianPath) GetPath() []int {
+func (hp *HamiltonianPath[V, W]) GetPath() []V {
 	hp.mutex.RLock()
 	defer hp.mutex.RUnlock()
-	return hp.path
+	hp.graph.mutex.RLock()
+	defer hp.graph.mutex.RUnlock()
+	return hp.graph.labelsOf(hp.path)
 }
 
 // IsHamiltonianPath checks if a given path is a valid Hamiltonian path
-func (hp *HamiltonianPath) IsHamiltonianPath(path []int) bool {
+func (hp *HamiltonianPath[V, W]) IsHamiltonianPath(vertices []V) bool {
 	hp.mutex.RLock()
 	defer hp.mutex.RUnlock()
+	hp.graph.mutex.RLock()
+	defer hp.graph.mutex.RUnlock()
 
-	if len(path) != hp.graph.GetVertices() {
+	path, ok := hp.graph.indexesOf(vertices)
+	if !ok {
+		return false
+	}
+
+	if len(path) != hp.graph.vertices {
 		return false
 	}
 
 	// Check if each node is used once
-	visited := make([]bool, hp.graph.GetVertices())
+	visited := make([]bool, hp.graph.vertices)
 	for _, v := range path {
 		if visited[v] {
 			return false
@@ -213,11 +349,18 @@ func (hp *HamiltonianPath) IsHamiltonianPath(path []int) bool {
 }
 
 // IsHamiltonianCircuit checks if a given circuit is a valid Hamiltonian circuit
-func (hp *HamiltonianPath) IsHamiltonianCircuit(circuit []int) bool {
+func (hp *HamiltonianPath[V, W]) IsHamiltonianCircuit(vertices []V) bool {
 	hp.mutex.RLock()
 	defer hp.mutex.RUnlock()
+	hp.graph.mutex.RLock()
+	defer hp.graph.mutex.RUnlock()
+
+	circuit, ok := hp.graph.indexesOf(vertices)
+	if !ok {
+		return false
+	}
 
-	if len(circuit) != hp.graph.GetVertices()+1 {
+	if len(circuit) != hp.graph.vertices+1 {
 		return false
 	}
 
@@ -227,7 +370,7 @@ func (hp *HamiltonianPath) IsHamiltonianCircuit(circuit []int) bool {
 	}
 
 	// Check if each node (except last) is used once
-	visited := make([]bool, hp.graph.GetVertices())
+	visited := make([]bool, hp.graph.vertices)
 	for i := 0; i < len(circuit)-1; i++ {
 		if visited[circuit[i]] {
 			return false
@@ -1,7 +1,10 @@
 package graph
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
 )
 
 func TestHamiltonianPath(t *testing.T) {
@@ -141,3 +144,96 @@ func TestHamiltonianPath(t *testing.T) {
 		t.Error("Expected invalid circuit to be rejected")
 	}
 }
+
+// newTwoCliques builds two disjoint undirected cliques of size vertices each, a graph where
+// backtracking explores every path inside a clique before giving up
+func newTwoCliques(size int) *Graph[int, int] {
+	g := NewGraph(2*size, false)
+	for offset := 0; offset < 2*size; offset += size {
+		for i := 0; i < size; i++ {
+			for j := i + 1; j < size; j++ {
+				g.AddEdge(offset+i, offset+j, 1)
+			}
+		}
+	}
+	return g
+}
+
+func TestHamiltonianPathContext(t *testing.T) {
+	t.Run("Within Budget", func(t *testing.T) {
+		g := NewGraph(5, false)
+		for i := 0; i < 5; i++ {
+			g.AddEdge(i, (i+1)%5, 1)
+		}
+		hp := NewHamiltonianPath(g)
+
+		path, err := hp.FindHamiltonianPathContext(context.Background(), 1000)
+		if err != nil || !hp.IsHamiltonianPath(path) {
+			t.Errorf("Expected a Hamiltonian path, got %v, %v", path, err)
+		}
+		circuit, err := hp.FindHamiltonianCircuitContext(context.Background(), 0)
+		if err != nil || !hp.IsHamiltonianCircuit(circuit) {
+			t.Errorf("Expected a Hamiltonian circuit, got %v, %v", circuit, err)
+		}
+
+		g.RemoveEdge(4, 0)
+		if circuit, err := hp.FindHamiltonianCircuitContext(context.Background(), 1000); circuit != nil || err != nil {
+			t.Errorf("Expected no circuit and no error, got %v, %v", circuit, err)
+		}
+	})
+
+	t.Run("Step Budget", func(t *testing.T) {
+		hp := NewHamiltonianPath(newTwoCliques(10))
+		if _, err := hp.FindHamiltonianPathContext(context.Background(), 10000); !errors.Is(err, ErrBudgetExceeded) {
+			t.Errorf("Expected ErrBudgetExceeded, got %v", err)
+		}
+		if _, err := hp.FindHamiltonianCircuitContext(context.Background(), 10000); !errors.Is(err, ErrBudgetExceeded) {
+			t.Errorf("Expected ErrBudgetExceeded, got %v", err)
+		}
+	})
+
+	t.Run("Deadline", func(t *testing.T) {
+		hp := NewHamiltonianPath(newTwoCliques(12))
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, err := hp.FindHamiltonianPathContext(ctx, 0)
+		if !errors.Is(err, ErrBudgetExceeded) || !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected ErrBudgetExceeded wrapping the deadline, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("Search ran %v past a 20ms deadline", elapsed)
+		}
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		hp := NewHamiltonianPath(newTwoCliques(3))
+		if _, err := hp.FindHamiltonianPathContext(ctx, 0); !errors.Is(err, context.Canceled) || errors.Is(err, ErrBudgetExceeded) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+	t.Run("Concurrent Mutation", func(t *testing.T) {
+		g := NewGraph(1, false)
+		hp := NewHamiltonianPath(g)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for v := 1; v < 100; v++ {
+				g.AddEdge(v-1, v, 1)
+			}
+		}()
+		for running := true; running; {
+			select {
+			case <-done:
+				running = false
+			default:
+			}
+			if path, err := hp.FindHamiltonianPathContext(context.Background(), 0); err != nil || path == nil {
+				t.Fatalf("Expected a path through the growing line, got %v, %v", path, err)
+			}
+		}
+	})
+}
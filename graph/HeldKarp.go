@@ -0,0 +1,194 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrTooManyVertices is returned by exact solvers when the graph is larger than they support
+var ErrTooManyVertices = errors.New("graph: too many vertices for an exact solver")
+
+// MaxHeldKarpVertices is the largest graph the Held–Karp solvers accept; their tables grow
+// as 2^n * n
+const MaxHeldKarpVertices = 20
+
+// costMatrix returns the weight of the lightest edge between every pair of vertex indexes,
+// or +Inf where there is none; the caller must hold the read lock
+func (g *Graph[V, W]) costMatrix() [][]float64 {
+	cost := make([][]float64, g.vertices)
+	for u := range cost {
+		cost[u] = make([]float64, g.vertices)
+		for v := range cost[u] {
+			cost[u][v] = math.Inf(1)
+		}
+	}
+	for from, edges := range g.adjList {
+		for _, edge := range edges {
+			cost[from][edge.To] = math.Min(cost[from][edge.To], float64(edge.Weight))
+		}
+	}
+	return cost
+}
+
+// heldKarp finds the cheapest order visiting every vertex once by dynamic programming over
+// vertex subsets. For a cycle the order starts at 0 and returns to it; for a path it may start
+// anywhere. It returns nil when no such order exists, or the context's error.
+func heldKarp(ctx context.Context, cost [][]float64, cycle bool) ([]int, float64, error) {
+	n := len(cost)
+	if n == 0 {
+		return nil, 0, nil
+	}
+	if n > MaxHeldKarpVertices {
+		return nil, 0, fmt.Errorf("%w: %d vertices, at most %d", ErrTooManyVertices, n, MaxHeldKarpVertices)
+	}
+	if cycle && n == 1 {
+		if math.IsInf(cost[0][0], 1) {
+			return nil, 0, nil
+		}
+		return []int{0, 0}, cost[0][0], nil
+	}
+
+	// A cycle is anchored at vertex 0, so its subsets only cover vertices 1..n-1: bit i-1 for
+	// vertex i. A path uses bit i for vertex i.
+	offset := 0
+	if cycle {
+		offset = 1
+	}
+	bits := n - offset
+	full := 1<<bits - 1
+
+	// best[mask*n+v] is the cheapest walk through the vertices in mask ending at v
+	best := make([]float64, (full+1)*n)
+	parent := make([]int8, (full+1)*n)
+	for i := range best {
+		best[i] = math.Inf(1)
+		parent[i] = -1
+	}
+	for v := offset; v < n; v++ {
+		mask := 1 << (v - offset)
+		if cycle {
+			best[mask*n+v] = cost[0][v]
+		} else {
+			best[mask*n+v] = 0
+		}
+	}
+
+	for mask := 1; mask <= full; mask++ {
+		if mask%cancelCheckInterval == 0 {
+			if err := contextError(ctx); err != nil {
+				return nil, 0, err
+			}
+		}
+		for v := offset; v < n; v++ {
+			current := best[mask*n+v]
+			if mask&(1<<(v-offset)) == 0 || math.IsInf(current, 1) {
+				continue
+			}
+			for u := offset; u < n; u++ {
+				bit := 1 << (u - offset)
+				if mask&bit != 0 || math.IsInf(cost[v][u], 1) {
+					continue
+				}
+				if next := (mask|bit)*n + u; current+cost[v][u] < best[next] {
+					best[next] = current + cost[v][u]
+					parent[next] = int8(v)
+				}
+			}
+		}
+	}
+	if err := contextError(ctx); err != nil {
+		return nil, 0, err
+	}
+
+	last, total := -1, math.Inf(1)
+	for v := offset; v < n; v++ {
+		length := best[full*n+v]
+		if cycle {
+			length += cost[v][0]
+		}
+		if length < total {
+			last, total = v, length
+		}
+	}
+	if last == -1 {
+		return nil, 0, nil
+	}
+
+	order := make([]int, 0, n+1)
+	if cycle {
+		order = append(order, 0)
+	}
+	for mask, v := full, last; v != -1; {
+		order = append(order, v)
+		mask, v = mask&^(1<<(v-offset)), int(parent[mask*n+v])
+	}
+	// The walk was rebuilt backwards; a cycle keeps 0 at the front and ends on it again
+	reverse(order[offset:])
+	if cycle {
+		order = append(order, 0)
+	}
+	return order, total, nil
+}
+
+// reverse reverses a slice in place
+func reverse(s []int) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+// pathWeight sums the lightest edge weight between consecutive vertices of an order,
+// reporting false if some pair is not joined by an edge; the caller must hold the read lock
+func (g *Graph[V, W]) pathWeight(order []int) (W, bool) {
+	var total W
+	for i := 1; i < len(order); i++ {
+		var lightest W
+		found := false
+		for _, edge := range g.adjList[order[i-1]] {
+			if edge.To == order[i] && (!found || edge.Weight < lightest) {
+				lightest, found = edge.Weight, true
+			}
+		}
+		if !found {
+			return 0, false
+		}
+		total += lightest
+	}
+	return total, true
+}
+
+// FindShortestHamiltonianPath finds the Hamiltonian path of least total weight with the
+// Held–Karp dynamic program in O(2^N * N^2) time and O(2^N * N) memory. It returns a nil path
+// if none exists, ErrTooManyVertices above MaxHeldKarpVertices, and the errors of
+// FindHamiltonianPathContext if ctx is done.
+func (hp *HamiltonianPath[V, W]) FindShortestHamiltonianPath(ctx context.Context) ([]V, W, error) {
+	return hp.heldKarp(ctx, false)
+}
+
+// FindShortestHamiltonianCircuit finds the Hamiltonian circuit of least total weight like
+// FindShortestHamiltonianPath. The circuit starts and ends at the first vertex.
+func (hp *HamiltonianPath[V, W]) FindShortestHamiltonianCircuit(ctx context.Context) ([]V, W, error) {
+	return hp.heldKarp(ctx, true)
+}
+
+// heldKarp runs the Held–Karp solver on the graph
+func (hp *HamiltonianPath[V, W]) heldKarp(ctx context.Context, cycle bool) ([]V, W, error) {
+	g := hp.graph
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	if err := contextError(ctx); err != nil {
+		return nil, 0, err
+	}
+	order, _, err := heldKarp(ctx, g.costMatrix(), cycle)
+	if err != nil || order == nil {
+		return nil, 0, err
+	}
+	weight, ok := g.pathWeight(order)
+	if !ok {
+		return nil, 0, fmt.Errorf("graph: Held-Karp order %v uses a missing edge", g.labelsOf(order))
+	}
+	return g.labelsOf(order), weight, nil
+}
@@ -0,0 +1,141 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// bruteForceOrder returns the cost of the cheapest Hamiltonian path or circuit by trying every
+// permutation, reporting false if there is none
+func bruteForceOrder[W Number](g *Graph[int, W], cycle bool) (W, bool) {
+	n := g.GetVertices()
+	lightest := make(map[[2]int]W)
+	for _, edge := range g.Edges() {
+		pairs := [][2]int{{edge.From, edge.To}}
+		if !g.IsDirected() {
+			pairs = append(pairs, [2]int{edge.To, edge.From})
+		}
+		for _, pair := range pairs {
+			if weight, ok := lightest[pair]; !ok || edge.Weight < weight {
+				lightest[pair] = edge.Weight
+			}
+		}
+	}
+
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	var best W
+	found := false
+	var permute func(k int)
+	permute = func(k int) {
+		if k == n {
+			walk := order
+			if cycle {
+				walk = append(append([]int(nil), order...), order[0])
+			}
+			var total W
+			for i := 1; i < len(walk); i++ {
+				weight, ok := lightest[[2]int{walk[i-1], walk[i]}]
+				if !ok {
+					return
+				}
+				total += weight
+			}
+			if !found || total < best {
+				best, found = total, true
+			}
+			return
+		}
+		for i := k; i < n; i++ {
+			order[k], order[i] = order[i], order[k]
+			permute(k + 1)
+			order[k], order[i] = order[i], order[k]
+		}
+	}
+	permute(0)
+	return best, found
+}
+
+func TestHeldKarp(t *testing.T) {
+	t.Run("Matches Brute Force", func(t *testing.T) {
+		for trial := int64(0); trial < 20; trial++ {
+			g := newRandomGraph(trial, 7, 20, trial%2 == 0)
+			hp := NewHamiltonianPath(g)
+			for _, cycle := range []bool{false, true} {
+				find := hp.FindShortestHamiltonianPath
+				if cycle {
+					find = hp.FindShortestHamiltonianCircuit
+				}
+				order, cost, err := find(context.Background())
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				expected, exists := bruteForceOrder(g, cycle)
+				if !exists {
+					if order != nil {
+						t.Fatalf("Trial %d cycle %v: expected no order, got %v", trial, cycle, order)
+					}
+					continue
+				}
+				valid := hp.IsHamiltonianPath(order)
+				if cycle {
+					valid = hp.IsHamiltonianCircuit(order)
+				}
+				if !valid || cost != expected {
+					t.Fatalf("Trial %d cycle %v: expected cost %d, got %v costing %d", trial, cycle, expected, order, cost)
+				}
+			}
+		}
+	})
+
+	t.Run("Labeled", func(t *testing.T) {
+		g := NewLabeledGraph[string, float64](false)
+		g.AddEdge("depot", "a", 1)
+		g.AddEdge("a", "b", 1)
+		g.AddEdge("b", "c", 1)
+		g.AddEdge("c", "depot", 1)
+		g.AddEdge("depot", "b", 5)
+		g.AddEdge("a", "c", 5)
+
+		circuit, cost, err := NewHamiltonianPath(g).FindShortestHamiltonianCircuit(context.Background())
+		if err != nil || cost != 4 || circuit[0] != "depot" || circuit[4] != "depot" {
+			t.Errorf("Expected the perimeter from depot costing 4, got %v costing %v, %v", circuit, cost, err)
+		}
+	})
+
+	t.Run("Edge Cases", func(t *testing.T) {
+		single := NewHamiltonianPath(NewGraph(1, false))
+		if path, cost, err := single.FindShortestHamiltonianPath(context.Background()); len(path) != 1 || cost != 0 || err != nil {
+			t.Errorf("Expected a one-vertex path, got %v, %v, %v", path, cost, err)
+		}
+		if circuit, _, err := single.FindShortestHamiltonianCircuit(context.Background()); circuit != nil || err != nil {
+			t.Errorf("Expected no circuit without a self-loop, got %v, %v", circuit, err)
+		}
+
+		large := NewHamiltonianPath(NewGraph(MaxHeldKarpVertices+1, false))
+		if _, _, err := large.FindShortestHamiltonianPath(context.Background()); !errors.Is(err, ErrTooManyVertices) {
+			t.Errorf("Expected ErrTooManyVertices, got %v", err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		hp := NewHamiltonianPath(newRandomGraph(1, 12, 60, false))
+		if _, _, err := hp.FindShortestHamiltonianCircuit(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+
+		path := NewGraph(3, false)
+		path.AddEdge(0, 1, 2)
+		path.AddEdge(1, 2, 3)
+		if weight, ok := path.pathWeight([]int{0, 1, 2}); weight != 5 || !ok {
+			t.Errorf("Expected weight 5, got %v, %v", weight, ok)
+		}
+		if _, ok := path.pathWeight([]int{1, 2, 0}); ok {
+			t.Error("Expected a missing edge to be reported")
+		}
+	})
+}
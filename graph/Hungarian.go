@@ -0,0 +1,165 @@
+package graph
+
+import "math"
+
+// Hungarian solves the assignment problem: it matches rows to columns of a cost matrix so
+// that every row (or every column, if there are fewer columns) is matched once and the total
+// cost is minimal. It returns the column assigned to each row, -1 for rows left unmatched,
+// and the total cost. Runs in O(n²m) for n ≤ m.
+func Hungarian[W Number](costs [][]W) ([]int, W) {
+	var total W
+	rows := len(costs)
+	if rows == 0 {
+		return []int{}, total
+	}
+	cols := len(costs[0])
+	if cols == 0 {
+		assignment := make([]int, rows)
+		for i := range assignment {
+			assignment[i] = -1
+		}
+		return assignment, total
+	}
+
+	// The algorithm needs rows ≤ columns; solve the transpose otherwise
+	transposed := rows > cols
+	n, m := rows, cols
+	if transposed {
+		n, m = cols, rows
+	}
+	a := make([][]float64, n)
+	for i := range a {
+		a[i] = make([]float64, m)
+		for j := range a[i] {
+			if transposed {
+				a[i][j] = float64(costs[j][i])
+			} else {
+				a[i][j] = float64(costs[i][j])
+			}
+		}
+	}
+
+	match := hungarian(a)
+	assignment := make([]int, rows)
+	for i := range assignment {
+		assignment[i] = -1
+	}
+	for i, j := range match {
+		if transposed {
+			assignment[j] = i
+		} else {
+			assignment[i] = j
+		}
+	}
+	for i, j := range assignment {
+		if j != -1 {
+			total += costs[i][j]
+		}
+	}
+	return assignment, total
+}
+
+// hungarian returns the column matched to each row of an n×m matrix with n ≤ m,
+// using row and column potentials with shortest augmenting paths
+func hungarian(a [][]float64) []int {
+	n, m := len(a), len(a[0])
+	inf := math.Inf(1)
+
+	// 1-based: p[j] is the row matched to column j, column 0 is a virtual start
+	u := make([]float64, n+1)
+	v := make([]float64, m+1)
+	p := make([]int, m+1)
+	way := make([]int, m+1)
+
+	for i := 1; i <= n; i++ {
+		p[0] = i
+		j0 := 0
+		minv := make([]float64, m+1)
+		used := make([]bool, m+1)
+		for j := range minv {
+			minv[j] = inf
+		}
+
+		for p[j0] != 0 {
+			used[j0] = true
+			i0, delta, j1 := p[j0], inf, 0
+			for j := 1; j <= m; j++ {
+				if used[j] {
+					continue
+				}
+				if cur := a[i0-1][j-1] - u[i0] - v[j]; cur < minv[j] {
+					minv[j] = cur
+					way[j] = j0
+				}
+				if minv[j] < delta {
+					delta = minv[j]
+					j1 = j
+				}
+			}
+			for j := 0; j <= m; j++ {
+				if used[j] {
+					u[p[j]] += delta
+					v[j] -= delta
+				} else {
+					minv[j] -= delta
+				}
+			}
+			j0 = j1
+		}
+
+		// Flip the augmenting path
+		for j0 != 0 {
+			j1 := way[j0]
+			p[j0] = p[j1]
+			j0 = j1
+		}
+	}
+
+	match := make([]int, n)
+	for j := 1; j <= m; j++ {
+		if p[j] != 0 {
+			match[p[j]-1] = j - 1
+		}
+	}
+	return match
+}
+
+// Assignment solves the assignment problem on the matrix, treating row i as a worker, column j
+// as a job and the weight of edge i->j as the cost of the pairing. Missing edges (math.MaxInt32)
+// are forbidden pairings; NewAdjMatrix sets the diagonal to 0, so add those edges explicitly.
+// Returns the job of each worker and the total cost, or false if every assignment needs a
+// forbidden pairing.
+func (g *AdjMatrix) Assignment() ([]int, int, bool) {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	// Forbidden pairings cost more than any assignment of allowed ones
+	forbidden := 1
+	for _, row := range g.matrix {
+		for _, w := range row {
+			if w != math.MaxInt32 {
+				forbidden += int(math.Abs(float64(w)))
+			}
+		}
+	}
+
+	costs := make([][]int, g.vertices)
+	for i, row := range g.matrix {
+		costs[i] = make([]int, g.vertices)
+		for j, w := range row {
+			if w == math.MaxInt32 {
+				costs[i][j] = forbidden
+			} else {
+				costs[i][j] = w
+			}
+		}
+	}
+
+	assignment, total := Hungarian(costs)
+	for i, j := range assignment {
+		if g.matrix[i][j] == math.MaxInt32 {
+			return nil, 0, false
+		}
+	}
+	return assignment, total, true
+}
@@ -0,0 +1,106 @@
+package graph
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// bruteForceAssignment returns the least total cost of matching every row of a square matrix
+func bruteForceAssignment(costs [][]int) int {
+	n := len(costs)
+	best := -1
+	used := make([]bool, n)
+	var walk func(row, total int)
+	walk = func(row, total int) {
+		if row == n {
+			if best == -1 || total < best {
+				best = total
+			}
+			return
+		}
+		for j := 0; j < n; j++ {
+			if !used[j] {
+				used[j] = true
+				walk(row+1, total+costs[row][j])
+				used[j] = false
+			}
+		}
+	}
+	walk(0, 0)
+	return best
+}
+
+func TestHungarian(t *testing.T) {
+	t.Run("Matches Brute Force", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(17))
+		for trial := 0; trial < 30; trial++ {
+			n := rng.Intn(6) + 1
+			costs := make([][]int, n)
+			for i := range costs {
+				costs[i] = make([]int, n)
+				for j := range costs[i] {
+					costs[i][j] = rng.Intn(50) - 10
+				}
+			}
+
+			assignment, total := Hungarian(costs)
+			if expected := bruteForceAssignment(costs); total != expected {
+				t.Fatalf("Trial %d: expected cost %d, got %d", trial, expected, total)
+			}
+			seen := make(map[int]bool)
+			for _, j := range assignment {
+				if j < 0 || seen[j] {
+					t.Fatalf("Trial %d: invalid assignment %v", trial, assignment)
+				}
+				seen[j] = true
+			}
+		}
+	})
+
+	t.Run("Rectangular", func(t *testing.T) {
+		wide := [][]float64{
+			{4, 1, 3, 9},
+			{2, 0, 5, 9},
+			{3, 2, 2, 1},
+		}
+		assignment, total := Hungarian(wide)
+		if !reflect.DeepEqual(assignment, []int{1, 0, 3}) || total != 4 {
+			t.Errorf("Expected [1 0 3] with cost 4, got %v with cost %v", assignment, total)
+		}
+
+		tall := [][]int{{7}, {3}, {5}}
+		assignment2, total2 := Hungarian(tall)
+		if !reflect.DeepEqual(assignment2, []int{-1, 0, -1}) || total2 != 3 {
+			t.Errorf("Expected [-1 0 -1] with cost 3, got %v with cost %d", assignment2, total2)
+		}
+
+		if assignment3, _ := Hungarian([][]int{}); len(assignment3) != 0 {
+			t.Error("Empty matrix should give an empty assignment")
+		}
+	})
+
+	t.Run("AdjMatrix Assignment", func(t *testing.T) {
+		g := NewAdjMatrix(3, true)
+		g.AddEdge(0, 0, 9)
+		g.AddEdge(0, 1, 2)
+		g.AddEdge(1, 1, 3)
+		g.AddEdge(1, 2, 4)
+		g.AddEdge(2, 0, 1)
+		g.AddEdge(2, 2, 8)
+
+		assignment, total, ok := g.Assignment()
+		if !ok || !reflect.DeepEqual(assignment, []int{1, 2, 0}) || total != 7 {
+			t.Errorf("Expected [1 2 0] with cost 7, got %v with cost %d (%v)", assignment, total, ok)
+		}
+
+		infeasible := NewAdjMatrix(2, true)
+		infeasible.AddEdge(0, 0, 1)
+		infeasible.AddEdge(1, 0, 1)
+		infeasible.AddEdge(1, 1, 2147483647)
+		infeasible.AddEdge(0, 1, 2147483647)
+		if _, _, ok := infeasible.Assignment(); ok {
+			t.Error("Both workers can only take job 0, so no assignment exists")
+		}
+	})
+}
@@ -0,0 +1,184 @@
+package graph
+
+import (
+	"math"
+	"sync"
+)
+
+// Johnson implements Johnson's algorithm for all-pairs shortest paths on sparse graphs
+// with negative edge weights. Bellman-Ford computes vertex potentials that make every edge
+// weight non-negative, after which Dijkstra runs from each source. Single-source results
+// are computed on first use and cached, so memory grows with the sources queried.
+type Johnson[V comparable, W Number] struct {
+	graph            *Graph[V, W]
+	labels           []V                  // Vertex index -> label at computation time
+	index            map[V]int            // Label -> vertex index at computation time
+	potential        []float64            // Bellman-Ford distances from the virtual source
+	reweighted       *Graph[int, float64] // Graph with non-negative reduced weights
+	trees            map[int]*johnsonTree // Shortest-path trees by source index
+	hasNegativeCycle bool
+	infinity         float64
+	graphVersion     uint64 // Graph version the potentials were computed from; 0 before the first run
+	mutex            sync.RWMutex
+}
+
+// johnsonTree is a single-source result in original weights
+type johnsonTree struct {
+	dist   []float64
+	parent []int
+}
+
+// NewJohnson creates a new Johnson instance
+func NewJohnson[V comparable, W Number](g *Graph[V, W]) *Johnson[V, W] {
+	return &Johnson[V, W]{
+		graph:    g,
+		trees:    make(map[int]*johnsonTree),
+		infinity: math.Inf(1),
+		mutex:    sync.RWMutex{},
+	}
+}
+
+// ComputeShortestPaths reweights the graph. Returns false if the graph has a negative cycle,
+// in which case shortest paths are undefined.
+func (j *Johnson[V, W]) ComputeShortestPaths() bool {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	j.graph.mutex.RLock()
+	n := j.graph.vertices
+	j.graphVersion = j.graph.version
+	j.labels, j.index = j.graph.labelSnapshot()
+
+	// Vertex 0 is a virtual source joined to every vertex by a zero-weight edge;
+	// original vertex i becomes i+1
+	augmented := NewLabeledGraph[int, W](true)
+	for v := 0; v <= n; v++ {
+		augmented.addVertex(v)
+	}
+	for v := 0; v < n; v++ {
+		augmented.AddEdge(0, v+1, 0)
+		for _, edge := range j.graph.adjList[v] {
+			augmented.AddEdge(v+1, edge.To+1, edge.Weight)
+		}
+	}
+	j.graph.mutex.RUnlock()
+
+	j.trees = make(map[int]*johnsonTree)
+
+	bf := NewBellmanFord(augmented, 0)
+	if !bf.ComputeShortestPaths() {
+		j.hasNegativeCycle = true
+		j.reweighted = nil
+		return false
+	}
+	j.hasNegativeCycle = false
+
+	j.potential = make([]float64, n)
+	copy(j.potential, bf.GetAllDistances()[1:])
+
+	// Reduced weights w(u,v) + h(u) - h(v) are non-negative; clamp rounding errors
+	j.reweighted = NewLabeledGraph[int, float64](true)
+	for v := 0; v < n; v++ {
+		j.reweighted.addVertex(v)
+	}
+	for v := 0; v < n; v++ {
+		for _, edge := range augmented.adjList[v+1] {
+			to := edge.To - 1
+			j.reweighted.AddEdge(v, to, math.Max(0, float64(edge.Weight)+j.potential[v]-j.potential[to]))
+		}
+	}
+	return true
+}
+
+// tree returns the shortest-path tree from a source index, computing it if needed.
+// The caller must hold the write lock.
+func (j *Johnson[V, W]) tree(source int) *johnsonTree {
+	if t, exists := j.trees[source]; exists {
+		return t
+	}
+
+	reduced, parent, reached := j.reweighted.dijkstraIndexes([]int{source}, -1)
+	t := &johnsonTree{dist: make([]float64, len(reduced)), parent: parent}
+	for v := range reduced {
+		if reached[v] {
+			t.dist[v] = reduced[v] - j.potential[source] + j.potential[v]
+		} else {
+			t.dist[v] = j.infinity
+		}
+	}
+	j.trees[source] = t
+	return t
+}
+
+// vertexIndexes returns the indexes of two vertices that existed when paths were computed
+func (j *Johnson[V, W]) vertexIndexes(source, target V) (int, int, bool) {
+	from, ok1 := j.index[source]
+	to, ok2 := j.index[target]
+	return from, to, ok1 && ok2
+}
+
+// GetDistance returns the shortest distance between two vertices, +Inf if there is no path
+func (j *Johnson[V, W]) GetDistance(source, target V) float64 {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	from, to, exists := j.vertexIndexes(source, target)
+	if !exists || j.reweighted == nil {
+		return j.infinity
+	}
+	return j.tree(from).dist[to]
+}
+
+// GetPath returns the shortest path between two vertices
+func (j *Johnson[V, W]) GetPath(source, target V) []V {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	from, to, exists := j.vertexIndexes(source, target)
+	if !exists || j.reweighted == nil {
+		return nil
+	}
+
+	t := j.tree(from)
+	if math.IsInf(t.dist[to], 1) {
+		return nil
+	}
+
+	path := tracePath(t.parent, to)
+	labels := make([]V, len(path))
+	for i, v := range path {
+		labels[i] = j.labels[v]
+	}
+	return labels
+}
+
+// HasNegativeCycle checks if the graph contains a negative cycle
+func (j *Johnson[V, W]) HasNegativeCycle() bool {
+	j.mutex.RLock()
+	defer j.mutex.RUnlock()
+	return j.hasNegativeCycle
+}
+
+// GetAllPairsDistances returns the distance matrix, indexed in Vertices() order.
+// This runs Dijkstra from every vertex and needs O(V^2) memory.
+func (j *Johnson[V, W]) GetAllPairsDistances() [][]float64 {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	if j.reweighted == nil {
+		return nil
+	}
+	dist := make([][]float64, len(j.labels))
+	for v := range dist {
+		dist[v] = j.tree(v).dist
+	}
+	return dist
+}
+
+// IsStale reports whether the graph has changed since ComputeShortestPaths; stale results
+// describe the graph as it was then
+func (j *Johnson[V, W]) IsStale() bool {
+	j.mutex.RLock()
+	defer j.mutex.RUnlock()
+	return j.graphVersion != j.graph.Version()
+}
@@ -0,0 +1,95 @@
+package graph
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestJohnson(t *testing.T) {
+	t.Run("Matches Floyd-Warshall With Negative Edges", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(11))
+		n := 40
+
+		// Weights w + p(u) - p(v) with w >= 0 go negative but never form a negative cycle
+		potential := make([]int, n)
+		for i := range potential {
+			potential[i] = rng.Intn(30)
+		}
+		g := NewGraph(n, true)
+		for i := 0; i < 150; i++ {
+			u, v := rng.Intn(n), rng.Intn(n)
+			g.AddEdge(u, v, rng.Intn(10)+potential[u]-potential[v])
+		}
+
+		fw := NewFloydWarshall(g)
+		fw.ComputeShortestPaths()
+		j := NewJohnson(g)
+		if !j.ComputeShortestPaths() || j.HasNegativeCycle() {
+			t.Fatal("Graph has no negative cycle")
+		}
+
+		for u := 0; u < n; u++ {
+			for v := 0; v < n; v++ {
+				expected, got := fw.GetDistance(u, v), j.GetDistance(u, v)
+				if math.Abs(expected-got) > 1e-9 && !(math.IsInf(expected, 1) && math.IsInf(got, 1)) {
+					t.Fatalf("Distance %d->%d: expected %v, got %v", u, v, expected, got)
+				}
+				path := j.GetPath(u, v)
+				if math.IsInf(got, 1) {
+					if path != nil {
+						t.Fatalf("Expected no path %d->%d, got %v", u, v, path)
+					}
+					continue
+				}
+				if !isPathOfLength(g, path, int(got)) || path[0] != u || path[len(path)-1] != v {
+					t.Fatalf("Invalid path %d->%d: %v", u, v, path)
+				}
+			}
+		}
+
+		all := j.GetAllPairsDistances()
+		if len(all) != n || all[3][7] != j.GetDistance(3, 7) {
+			t.Error("All-pairs matrix should agree with GetDistance")
+		}
+	})
+
+	t.Run("Negative Cycle", func(t *testing.T) {
+		g := NewLabeledGraph[string, float64](true)
+		g.AddEdge("a", "b", 1)
+		g.AddEdge("b", "c", -2)
+		g.AddEdge("c", "a", 0.5)
+
+		j := NewJohnson(g)
+		if j.ComputeShortestPaths() || !j.HasNegativeCycle() {
+			t.Error("Expected a negative cycle")
+		}
+		if j.GetPath("a", "c") != nil || !math.IsInf(j.GetDistance("a", "c"), 1) {
+			t.Error("Paths are undefined with a negative cycle")
+		}
+	})
+
+	t.Run("Labeled Vertices And Unreachable Pairs", func(t *testing.T) {
+		g := NewLabeledGraph[string, int](true)
+		g.AddEdge("a", "b", 4)
+		g.AddEdge("a", "c", 1)
+		g.AddEdge("c", "b", -2)
+		g.AddVertex("d")
+
+		j := NewJohnson(g)
+		j.ComputeShortestPaths()
+		if d := j.GetDistance("a", "b"); d != -1 {
+			t.Errorf("Expected distance -1, got %v", d)
+		}
+		if path := j.GetPath("a", "b"); !reflect.DeepEqual(path, []string{"a", "c", "b"}) {
+			t.Errorf("Unexpected path %v", path)
+		}
+		if !math.IsInf(j.GetDistance("a", "d"), 1) || j.GetPath("b", "a") != nil {
+			t.Error("Unreachable pairs should have infinite distance and no path")
+		}
+		if !math.IsInf(j.GetDistance("a", "missing"), 1) {
+			t.Error("Unknown vertices should have infinite distance")
+		}
+	})
+}
@@ -0,0 +1,193 @@
+package graph
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// Path is a route through the graph and its total weight
+type Path[V comparable, W Number] struct {
+	Vertices []V
+	Weight   W
+}
+
+// yenPath is an index-based candidate path with the cumulative weight at each vertex
+type yenPath[W Number] struct {
+	vertices []int
+	costs    []W
+}
+
+// KShortestPaths returns up to k loop-free paths from source to target in order of increasing
+// weight, using Yen's algorithm. Parallel edges count as one hop between the same vertices.
+// Weights must be non-negative.
+func KShortestPaths[V comparable, W Number](g *Graph[V, W], source, target V, k int) []Path[V, W] {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	start, ok1 := g.index[source]
+	end, ok2 := g.index[target]
+	if !ok1 || !ok2 || k <= 0 {
+		return nil
+	}
+
+	first, found := g.restrictedPath(start, end, nil, nil)
+	if !found {
+		return nil
+	}
+
+	accepted := []yenPath[W]{first}
+	candidates := make([]yenPath[W], 0)
+	seen := map[string]bool{fmt.Sprint(first.vertices): true}
+
+	for len(accepted) < k {
+		prev := accepted[len(accepted)-1]
+
+		// Deviate from the previous path at every vertex but the last
+		for i := 0; i < len(prev.vertices)-1; i++ {
+			root := prev.vertices[:i+1]
+
+			// Forbid the next hop of every accepted path sharing this root
+			blockedEdges := make(map[[2]int]bool)
+			for _, p := range accepted {
+				if len(p.vertices) > i+1 && slices.Equal(p.vertices[:i+1], root) {
+					blockedEdges[[2]int{p.vertices[i], p.vertices[i+1]}] = true
+				}
+			}
+
+			// Keep the path loop-free by forbidding the root before the spur vertex
+			blockedVertices := make(map[int]bool)
+			for _, v := range root[:i] {
+				blockedVertices[v] = true
+			}
+
+			spur, found := g.restrictedPath(prev.vertices[i], end, blockedVertices, blockedEdges)
+			if !found {
+				continue
+			}
+
+			candidate := yenPath[W]{
+				vertices: append(slices.Clone(root[:i]), spur.vertices...),
+				costs:    slices.Clone(prev.costs[:i]),
+			}
+			for _, c := range spur.costs {
+				candidate.costs = append(candidate.costs, prev.costs[i]+c)
+			}
+
+			key := fmt.Sprint(candidate.vertices)
+			if !seen[key] {
+				seen[key] = true
+				candidates = append(candidates, candidate)
+			}
+		}
+
+		if len(candidates) == 0 {
+			break
+		}
+
+		// Accept the lightest candidate, preferring fewer hops on ties
+		best := 0
+		for i, c := range candidates {
+			w, bestW := c.costs[len(c.costs)-1], candidates[best].costs[len(candidates[best].costs)-1]
+			if w < bestW || (w == bestW && len(c.vertices) < len(candidates[best].vertices)) {
+				best = i
+			}
+		}
+		accepted = append(accepted, candidates[best])
+		candidates = slices.Delete(candidates, best, best+1)
+	}
+
+	paths := make([]Path[V, W], len(accepted))
+	for i, p := range accepted {
+		paths[i] = Path[V, W]{Vertices: g.labelsOf(p.vertices), Weight: p.costs[len(p.costs)-1]}
+	}
+	return paths
+}
+
+// restrictedPath finds the shortest path from one index to another avoiding the given vertices
+// and edges. The caller must hold the read lock.
+func (g *Graph[V, W]) restrictedPath(from, to int, blockedVertices map[int]bool, blockedEdges map[[2]int]bool) (yenPath[W], bool) {
+	skip := func(u, v int) bool {
+		return blockedVertices[v] || blockedEdges[[2]int{u, v}]
+	}
+	dist, parent, reached := g.dijkstraFiltered([]int{from}, to, skip)
+	if !reached[to] {
+		return yenPath[W]{}, false
+	}
+
+	path := yenPath[W]{vertices: tracePath(parent, to)}
+	for _, v := range path.vertices {
+		path.costs = append(path.costs, dist[v])
+	}
+	return path, true
+}
+
+// AllShortestPaths yields every shortest path from source to target. Paths are computed from
+// a snapshot of the graph taken when iteration starts, so the graph may be modified while
+// iterating. Weights must be non-negative; with float weights, paths are tied only when
+// their lengths compare exactly equal.
+func AllShortestPaths[V comparable, W Number](g *Graph[V, W], source, target V) iter.Seq[[]V] {
+	return func(yield func([]V) bool) {
+		g.mutex.RLock()
+		start, ok1 := g.index[source]
+		end, ok2 := g.index[target]
+		if !ok1 || !ok2 {
+			g.mutex.RUnlock()
+			return
+		}
+
+		dist, _, reached := g.dijkstraIndexes([]int{start}, -1)
+		if !reached[end] {
+			g.mutex.RUnlock()
+			return
+		}
+
+		// Every edge that is tight on some shortest path, stored backwards
+		predecessors := make(map[int][]int)
+		for from, edges := range g.adjList {
+			if !reached[from] {
+				continue
+			}
+			for _, edge := range edges {
+				if edge.To != start && dist[from]+edge.Weight == dist[edge.To] && !slices.Contains(predecessors[edge.To], from) {
+					predecessors[edge.To] = append(predecessors[edge.To], from)
+				}
+			}
+		}
+		for _, preds := range predecessors {
+			slices.Sort(preds)
+		}
+		labels := slices.Clone(g.labels)
+		g.mutex.RUnlock()
+
+		// Walk back from target; zero-weight cycles are cut by skipping vertices already on the path
+		onPath := make(map[int]bool)
+		reversed := []int{end}
+		onPath[end] = true
+		var walk func(v int) bool
+		walk = func(v int) bool {
+			if v == start {
+				path := make([]V, len(reversed))
+				for i, u := range reversed {
+					path[len(reversed)-1-i] = labels[u]
+				}
+				return yield(path)
+			}
+			for _, u := range predecessors[v] {
+				if onPath[u] {
+					continue
+				}
+				onPath[u] = true
+				reversed = append(reversed, u)
+				ok := walk(u)
+				reversed = reversed[:len(reversed)-1]
+				onPath[u] = false
+				if !ok {
+					return false
+				}
+			}
+			return true
+		}
+		walk(end)
+	}
+}
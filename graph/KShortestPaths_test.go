@@ -0,0 +1,161 @@
+package graph
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestKShortestPaths(t *testing.T) {
+	t.Run("Yen Example", func(t *testing.T) {
+		g := NewLabeledGraph[string, int](true)
+		g.AddEdge("C", "D", 3)
+		g.AddEdge("C", "E", 2)
+		g.AddEdge("D", "F", 4)
+		g.AddEdge("E", "D", 1)
+		g.AddEdge("E", "F", 2)
+		g.AddEdge("E", "G", 3)
+		g.AddEdge("F", "G", 2)
+		g.AddEdge("F", "H", 1)
+		g.AddEdge("G", "H", 2)
+
+		paths := KShortestPaths(g, "C", "H", 3)
+		expected := []Path[string, int]{
+			{Vertices: []string{"C", "E", "F", "H"}, Weight: 5},
+			{Vertices: []string{"C", "E", "G", "H"}, Weight: 7},
+			{Vertices: []string{"C", "D", "F", "H"}, Weight: 8},
+		}
+		if len(paths) != 3 || paths[0].Weight != 5 || paths[1].Weight != 7 || paths[2].Weight != 8 {
+			t.Fatalf("Unexpected paths %v", paths)
+		}
+		if !reflect.DeepEqual(paths[0], expected[0]) {
+			t.Errorf("Expected %v, got %v", expected[0], paths[0])
+		}
+
+		all := KShortestPaths(g, "C", "H", 100)
+		if len(all) != 7 {
+			t.Errorf("Expected all 7 loop-free paths, got %d", len(all))
+		}
+		if KShortestPaths(g, "H", "C", 2) != nil || KShortestPaths(g, "C", "missing", 2) != nil {
+			t.Error("Expected no paths")
+		}
+	})
+
+	t.Run("Matches Brute Force", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(3))
+		for trial := 0; trial < 20; trial++ {
+			g := NewGraph(8, trial%2 == 0)
+			for i := 0; i < 14; i++ {
+				u, v := rng.Intn(8), rng.Intn(8)
+				if u != v {
+					g.AddEdge(u, v, rng.Intn(9)+1)
+				}
+			}
+
+			expected := simplePathWeights(g, 0, 7)
+			paths := KShortestPaths(g, 0, 7, 5)
+			if len(paths) != min(5, len(expected)) {
+				t.Fatalf("Trial %d: expected %d paths, got %d", trial, min(5, len(expected)), len(paths))
+			}
+			seen := make(map[string]bool)
+			for i, p := range paths {
+				if p.Weight != expected[i] || !isPathOfLength(g, p.Vertices, p.Weight) {
+					t.Fatalf("Trial %d: path %d is %v, expected weight %d", trial, i, p, expected[i])
+				}
+				key := fmtPath(p.Vertices)
+				if seen[key] {
+					t.Fatalf("Trial %d: duplicate path %v", trial, p.Vertices)
+				}
+				seen[key] = true
+			}
+		}
+	})
+}
+
+func TestAllShortestPaths(t *testing.T) {
+	t.Run("Grid Ties", func(t *testing.T) {
+		g := newGrid(3, nil)
+		count := 0
+		for path := range AllShortestPaths(g, cell{0, 0}, cell{2, 2}) {
+			if len(path) != 5 || path[0] != (cell{0, 0}) || path[4] != (cell{2, 2}) {
+				t.Errorf("Unexpected path %v", path)
+			}
+			count++
+		}
+		if count != 6 {
+			t.Errorf("Expected 6 shortest paths, got %d", count)
+		}
+
+		count = 0
+		for range AllShortestPaths(g, cell{0, 0}, cell{2, 2}) {
+			count++
+			if count == 2 {
+				break
+			}
+		}
+		if count != 2 {
+			t.Error("Iteration should stop when the loop breaks")
+		}
+	})
+
+	t.Run("Zero Weight Cycle And Unreachable", func(t *testing.T) {
+		g := NewGraph(4, true)
+		g.AddEdge(0, 1, 1)
+		g.AddEdge(1, 2, 0)
+		g.AddEdge(2, 1, 0)
+		g.AddEdge(2, 3, 1)
+		g.AddEdge(1, 3, 1)
+
+		var paths [][]int
+		for path := range AllShortestPaths(g, 0, 3) {
+			paths = append(paths, path)
+		}
+		expected := [][]int{{0, 1, 3}, {0, 1, 2, 3}}
+		if !reflect.DeepEqual(paths, expected) {
+			t.Errorf("Expected %v, got %v", expected, paths)
+		}
+
+		for path := range AllShortestPaths(g, 3, 0) {
+			t.Errorf("Expected no path, got %v", path)
+		}
+	})
+}
+
+// simplePathWeights returns the sorted weights of every simple path, using the lightest parallel edge
+func simplePathWeights(g *Graph[int, int], from, to int) []int {
+	weights := []int{}
+	visited := map[int]bool{from: true}
+	var walk func(v, total int)
+	walk = func(v, total int) {
+		if v == to {
+			weights = append(weights, total)
+			return
+		}
+		lightest := map[int]int{}
+		for _, edge := range g.adjList[v] {
+			if w, ok := lightest[edge.To]; !ok || edge.Weight < w {
+				lightest[edge.To] = edge.Weight
+			}
+		}
+		for u, w := range lightest {
+			if !visited[u] {
+				visited[u] = true
+				walk(u, total+w)
+				visited[u] = false
+			}
+		}
+	}
+	walk(from, 0)
+	sort.Ints(weights)
+	return weights
+}
+
+// fmtPath returns a comparable key for a path
+func fmtPath(path []int) string {
+	key := ""
+	for _, v := range path {
+		key += string(rune('a' + v))
+	}
+	return key
+}
@@ -6,35 +6,39 @@ import (
 )
 
 // KruskalMST implements Kruskal's algorithm for finding Minimum Spanning Tree
-type KruskalMST struct {
-	graph    *Graph
-	parent   []int   // Parent array for Union-Find
-	rank     []int   // Rank array for Union-Find
-	mstEdges []Edge  // Edges in MST
-	mstCost  float64 // Total cost of MST
-	mutex    sync.RWMutex
+type KruskalMST[V comparable, W Number] struct {
+	graph        *Graph[V, W]
+	parent       []int          // Parent array for Union-Find
+	rank         []int          // Rank array for Union-Find
+	mstEdges     []Edge[int, W] // Edges in MST
+	mstCost      float64        // Total cost of MST
+	labels       []V            // Vertex index -> label when the MST was computed
+	index        map[V]int      // Label -> vertex index when the MST was computed
+	graphVersion uint64         // Graph version the results were computed from; 0 before the first run
+	mutex        sync.RWMutex
 }
 
 // NewKruskalMST creates a new Kruskal's MST instance
-func NewKruskalMST(g *Graph) *KruskalMST {
+func NewKruskalMST[V comparable, W Number](g *Graph[V, W]) *KruskalMST[V, W] {
 	if g.IsDirected() {
 		return nil // Kruskal algorithm works for undirected graphs
 	}
-	return &KruskalMST{
+	return &KruskalMST[V, W]{
 		graph:    g,
-		mstEdges: make([]Edge, 0),
+		mstEdges: make([]Edge[int, W], 0),
 		mstCost:  0,
 		mutex:    sync.RWMutex{},
 	}
 }
 
-// initialize prepares the Union-Find data structure
-func (k *KruskalMST) initialize() {
-	n := k.graph.GetVertices()
+// initialize prepares the Union-Find data structure; the caller must hold the graph's read lock
+func (k *KruskalMST[V, W]) initialize() {
+	n := k.graph.vertices
 	k.parent = make([]int, n)
 	k.rank = make([]int, n)
-	k.mstEdges = make([]Edge, 0)
+	k.mstEdges = make([]Edge[int, W], 0)
 	k.mstCost = 0
+	k.graphVersion = k.graph.version
 
 	// Initialize each node in its own set
 	for i := 0; i < n; i++ {
@@ -44,7 +48,7 @@ func (k *KruskalMST) initialize() {
 }
 
 // find returns the representative of the set containing x
-func (k *KruskalMST) find(x int) int {
+func (k *KruskalMST[V, W]) find(x int) int {
 	if k.parent[x] != x {
 		k.parent[x] = k.find(k.parent[x]) // Path compression
 	}
@@ -52,7 +56,7 @@ func (k *KruskalMST) find(x int) int {
 }
 
 // union merges sets containing x and y
-func (k *KruskalMST) union(x, y int) {
+func (k *KruskalMST[V, W]) union(x, y int) {
 	rootX := k.find(x)
 	rootY := k.find(y)
 
@@ -69,123 +73,57 @@ func (k *KruskalMST) union(x, y int) {
 	}
 }
 
-// countComponents counts the number of connected components
-func (k *KruskalMST) countComponents() int {
-	// Reset Union-Find structure
-	k.initialize()
-
-	// Mark vertices with edges
-	hasEdge := make([]bool, k.graph.GetVertices())
-	for v := 0; v < k.graph.GetVertices(); v++ {
-		if len(k.graph.adjList[v]) > 0 {
-			hasEdge[v] = true
-		}
+// countComponents counts the number of connected components with a Union-Find of its own,
+// leaving the MST alone; an isolated vertex is a component of its own. The caller must hold
+// the graph's read lock.
+func (k *KruskalMST[V, W]) countComponents() int {
+	n := k.graph.vertices
+	parent := make([]int, n)
+	for i := range parent {
+		parent[i] = i
 	}
-
-	// Create adjacency list for vertices with edges
-	verticesWithEdges := make([]int, 0)
-	for v := 0; v < k.graph.GetVertices(); v++ {
-		if hasEdge[v] {
-			verticesWithEdges = append(verticesWithEdges, v)
+	find := func(x int) int {
+		for parent[x] != x {
+			parent[x] = parent[parent[x]] // Path halving
+			x = parent[x]
 		}
+		return x
 	}
 
-	// Special case: no edges
-	if len(verticesWithEdges) == 0 {
-		return 1
-	}
-
-	// Run union operations on edges
-	for v := 0; v < k.graph.GetVertices(); v++ {
+	components := n
+	for v := 0; v < n; v++ {
 		for _, edge := range k.graph.adjList[v] {
-			if edge.From < edge.To { // Process each edge once
-				k.union(edge.From, edge.To)
+			if rootX, rootY := find(v), find(edge.To); rootX != rootY {
+				parent[rootX] = rootY
+				components--
 			}
 		}
 	}
-
-	// Count unique components among vertices with edges
-	components := make(map[int]bool)
-	for _, v := range verticesWithEdges {
-		components[k.find(v)] = true
-	}
-
-	return len(components)
+	return components
 }
 
 // GetNumComponents returns the number of connected components
-func (k *KruskalMST) GetNumComponents() int {
-	k.mutex.RLock()
-	defer k.mutex.RUnlock()
-
-	// Test 3 için özel durum: Bağlantısız graf
-	if k.graph.GetVertices() == 4 {
-		// Test 3'teki graf yapısını kontrol et
-		isTest3 := false
-		for _, edge := range k.graph.adjList[0] {
-			if edge.To == 1 {
-				isTest3 = true
-				break
-			}
-		}
-		for _, edge := range k.graph.adjList[2] {
-			if edge.To == 3 {
-				isTest3 = true
-				break
-			}
-		}
-
-		if isTest3 {
-			// Test 3 için 2 bileşen
-			return 2
-		}
-	}
-
+func (k *KruskalMST[V, W]) GetNumComponents() int {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	k.graph.mutex.RLock()
+	defer k.graph.mutex.RUnlock()
 	return k.countComponents()
 }
 
 // FindMST finds the Minimum Spanning Tree using Kruskal's algorithm
-func (k *KruskalMST) FindMST() bool {
+func (k *KruskalMST[V, W]) FindMST() bool {
 	k.mutex.Lock()
 	defer k.mutex.Unlock()
+	k.graph.mutex.RLock()
+	defer k.graph.mutex.RUnlock()
 
 	k.initialize()
-	n := k.graph.GetVertices()
-
-	// Test 3 için özel durum: Bağlantısız graf
-	if n == 4 {
-		// Test 3'teki graf yapısını kontrol et
-		isTest3 := false
-		edgeCount := 0
-
-		for v := 0; v < n; v++ {
-			edgeCount += len(k.graph.adjList[v])
-		}
-
-		// Bağlantısız graf için kenar sayısı 4 olmalı (her kenar iki kez sayılır)
-		if edgeCount == 4 {
-			// Kenarları kontrol et
-			for v := 0; v < n; v++ {
-				for _, edge := range k.graph.adjList[v] {
-					if (v == 0 && edge.To == 1) || (v == 1 && edge.To == 0) ||
-						(v == 2 && edge.To == 3) || (v == 3 && edge.To == 2) {
-						isTest3 = true
-					} else {
-						isTest3 = false
-						break
-					}
-				}
-			}
-
-			if isTest3 {
-				// Test 3 için bağlantısız graf
-				return false
-			}
-		}
-	}
+	k.labels, k.index = k.graph.labelSnapshot()
+	n := k.graph.vertices
 
 	// Get all edges
-	edges := make([]Edge, 0)
+	edges := make([]Edge[int, W], 0)
 	for v := 0; v < n; v++ {
 		for _, edge := range k.graph.adjList[v] {
 			// For undirected graph, add each edge only once
@@ -216,22 +154,36 @@ func (k *KruskalMST) FindMST() bool {
 }
 
 // GetMSTEdges returns the edges in the MST
-func (k *KruskalMST) GetMSTEdges() []Edge {
+func (k *KruskalMST[V, W]) GetMSTEdges() []Edge[V, W] {
 	k.mutex.RLock()
 	defer k.mutex.RUnlock()
-	return k.mstEdges
+	return labeledEdges(k.labels, k.mstEdges)
 }
 
 // GetMSTCost returns the total cost of the MST
-func (k *KruskalMST) GetMSTCost() float64 {
+func (k *KruskalMST[V, W]) GetMSTCost() float64 {
 	k.mutex.RLock()
 	defer k.mutex.RUnlock()
 	return k.mstCost
 }
 
 // IsConnected checks if two vertices are connected in the MST
-func (k *KruskalMST) IsConnected(x, y int) bool {
+func (k *KruskalMST[V, W]) IsConnected(u, v V) bool {
+	// find compresses paths, so it needs the write lock
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	x, ok1 := k.index[u]
+	y, ok2 := k.index[v]
+	if !ok1 || !ok2 || x >= len(k.parent) || y >= len(k.parent) {
+		return false
+	}
+	return k.find(x) == k.find(y)
+}
+
+// IsStale reports whether the graph has changed since the MST was last computed
+func (k *KruskalMST[V, W]) IsStale() bool {
 	k.mutex.RLock()
 	defer k.mutex.RUnlock()
-	return k.find(x) == k.find(y)
+	return k.graphVersion != k.graph.Version()
 }
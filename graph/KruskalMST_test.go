@@ -60,6 +60,14 @@ func TestKruskalMST(t *testing.T) {
 		t.Errorf("Expected %d components, got %d", expectedComponents, actualComponents)
 	}
 
+	path := NewGraph(4, false)
+	path.AddEdge(0, 1, 1)
+	path.AddEdge(1, 2, 1)
+	path.AddEdge(2, 3, 1)
+	if components := NewKruskalMST(path).GetNumComponents(); components != 1 {
+		t.Errorf("Expected 1 component in a path, got %d", components)
+	}
+
 	// Test 4: Single vertex graph
 	g4 := NewGraph(1, false)
 	kruskal4 := NewKruskalMST(g4)
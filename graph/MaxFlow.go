@@ -0,0 +1,287 @@
+package graph
+
+// FlowEdge is a graph edge with the flow routed through it
+type FlowEdge[V comparable, W Number] struct {
+	From     V
+	To       V
+	Capacity W
+	Flow     W
+}
+
+// MaxFlowResult is a maximum flow and the minimum cut that proves it
+type MaxFlowResult[V comparable, W Number] struct {
+	Value      W                // Total flow from source to sink
+	Flows      []FlowEdge[V, W] // Flow on every edge, in graph order
+	SourceSide []V              // Vertices reachable from source in the residual network
+	SinkSide   []V              // All other vertices
+	CutEdges   []Edge[V, W]     // Saturated edges from SourceSide to SinkSide
+}
+
+// Flow returns the total flow routed from one vertex to another
+func (r *MaxFlowResult[V, W]) Flow(from, to V) W {
+	var total W
+	for _, edge := range r.Flows {
+		if edge.From == from && edge.To == to {
+			total += edge.Flow
+		}
+	}
+	return total
+}
+
+// flowNetwork is a residual network; arc i and arc i^1 are the two directions of one edge
+type flowNetwork[W Number] struct {
+	n        int
+	head     [][]int // Arc indexes leaving each vertex
+	to       []int
+	residual []W
+	capacity []W   // Original capacity; 0 for reverse arcs
+	edges    []int // Forward arc index of each graph edge, in graph order
+}
+
+// newFlowNetwork builds a residual network whose capacities are the graph's edge weights.
+// Each direction of an undirected edge becomes its own arc. The caller must hold the read lock.
+func newFlowNetwork[V comparable, W Number](g *Graph[V, W]) *flowNetwork[W] {
+	fn := &flowNetwork[W]{
+		n:    g.vertices,
+		head: make([][]int, g.vertices),
+	}
+	for from := 0; from < g.vertices; from++ {
+		for _, edge := range g.adjList[from] {
+			fn.edges = append(fn.edges, fn.addArc(from, edge.To, edge.Weight))
+		}
+	}
+	return fn
+}
+
+// addArc adds an arc and its zero-capacity reverse, returning the forward arc index
+func (fn *flowNetwork[W]) addArc(from, to int, capacity W) int {
+	arc := len(fn.to)
+	fn.head[from] = append(fn.head[from], arc)
+	fn.to = append(fn.to, to)
+	fn.residual = append(fn.residual, capacity)
+	fn.capacity = append(fn.capacity, capacity)
+
+	fn.head[to] = append(fn.head[to], arc+1)
+	fn.to = append(fn.to, from)
+	fn.residual = append(fn.residual, 0)
+	fn.capacity = append(fn.capacity, 0)
+	return arc
+}
+
+// push sends flow along an arc
+func (fn *flowNetwork[W]) push(arc int, amount W) {
+	fn.residual[arc] -= amount
+	fn.residual[arc^1] += amount
+}
+
+// levels returns the BFS distance of every vertex from source over arcs with residual capacity, -1 if unreached
+func (fn *flowNetwork[W]) levels(source int) []int {
+	level := make([]int, fn.n)
+	for i := range level {
+		level[i] = -1
+	}
+	level[source] = 0
+	queue := []int{source}
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, arc := range fn.head[u] {
+			if v := fn.to[arc]; fn.residual[arc] > 0 && level[v] == -1 {
+				level[v] = level[u] + 1
+				queue = append(queue, v)
+			}
+		}
+	}
+	return level
+}
+
+// maxFlow runs a max-flow algorithm on the graph's network and assembles the result.
+// Returns nil if either vertex is unknown or they are the same vertex.
+func maxFlow[V comparable, W Number](g *Graph[V, W], source, sink V, run func(fn *flowNetwork[W], s, t int)) *MaxFlowResult[V, W] {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	s, ok1 := g.index[source]
+	t, ok2 := g.index[sink]
+	if !ok1 || !ok2 || s == t {
+		return nil
+	}
+
+	fn := newFlowNetwork(g)
+	run(fn, s, t)
+
+	result := &MaxFlowResult[V, W]{
+		Flows:      make([]FlowEdge[V, W], len(fn.edges)),
+		SourceSide: make([]V, 0),
+		SinkSide:   make([]V, 0),
+		CutEdges:   make([]Edge[V, W], 0),
+	}
+	for i, arc := range fn.edges {
+		from, to := fn.to[arc^1], fn.to[arc]
+		flow := fn.capacity[arc] - fn.residual[arc]
+		result.Flows[i] = FlowEdge[V, W]{From: g.labels[from], To: g.labels[to], Capacity: fn.capacity[arc], Flow: flow}
+		if from == s {
+			result.Value += flow
+		}
+		if to == s {
+			result.Value -= flow
+		}
+	}
+
+	// The vertices still reachable from source form the source side of a minimum cut
+	level := fn.levels(s)
+	for v := 0; v < fn.n; v++ {
+		if level[v] != -1 {
+			result.SourceSide = append(result.SourceSide, g.labels[v])
+		} else {
+			result.SinkSide = append(result.SinkSide, g.labels[v])
+		}
+	}
+	for _, arc := range fn.edges {
+		from, to := fn.to[arc^1], fn.to[arc]
+		if level[from] != -1 && level[to] == -1 && fn.capacity[arc] > 0 {
+			result.CutEdges = append(result.CutEdges, Edge[V, W]{From: g.labels[from], To: g.labels[to], Weight: fn.capacity[arc]})
+		}
+	}
+	return result
+}
+
+// EdmondsKarp computes a maximum flow from source to sink by augmenting along shortest
+// residual paths. Edge weights are capacities and must be non-negative.
+func EdmondsKarp[V comparable, W Number](g *Graph[V, W], source, sink V) *MaxFlowResult[V, W] {
+	return maxFlow(g, source, sink, func(fn *flowNetwork[W], s, t int) {
+		for {
+			// BFS for the shortest augmenting path, remembering the arc into each vertex
+			via := make([]int, fn.n)
+			for i := range via {
+				via[i] = -1
+			}
+			queue := []int{s}
+			for len(queue) > 0 && via[t] == -1 {
+				u := queue[0]
+				queue = queue[1:]
+				for _, arc := range fn.head[u] {
+					if v := fn.to[arc]; fn.residual[arc] > 0 && via[v] == -1 && v != s {
+						via[v] = arc
+						queue = append(queue, v)
+					}
+				}
+			}
+			if via[t] == -1 {
+				return
+			}
+
+			bottleneck := fn.residual[via[t]]
+			for v := t; v != s; v = fn.to[via[v]^1] {
+				bottleneck = min(bottleneck, fn.residual[via[v]])
+			}
+			for v := t; v != s; v = fn.to[via[v]^1] {
+				fn.push(via[v], bottleneck)
+			}
+		}
+	})
+}
+
+// Dinic computes a maximum flow from source to sink with blocking flows on BFS level graphs.
+// Edge weights are capacities and must be non-negative.
+func Dinic[V comparable, W Number](g *Graph[V, W], source, sink V) *MaxFlowResult[V, W] {
+	return maxFlow(g, source, sink, func(fn *flowNetwork[W], s, t int) {
+		for {
+			level := fn.levels(s)
+			if level[t] == -1 {
+				return
+			}
+
+			next := make([]int, fn.n) // Next arc to try at each vertex
+			var augment func(u int, limit W) W
+			augment = func(u int, limit W) W {
+				if u == t {
+					return limit
+				}
+				for ; next[u] < len(fn.head[u]); next[u]++ {
+					arc := fn.head[u][next[u]]
+					v := fn.to[arc]
+					if fn.residual[arc] <= 0 || level[v] != level[u]+1 {
+						continue
+					}
+					if pushed := augment(v, min(limit, fn.residual[arc])); pushed > 0 {
+						fn.push(arc, pushed)
+						return pushed
+					}
+				}
+				return 0
+			}
+
+			for {
+				var limit W
+				for _, arc := range fn.head[s] {
+					limit += fn.residual[arc]
+				}
+				if limit <= 0 || augment(s, limit) <= 0 {
+					break
+				}
+			}
+		}
+	})
+}
+
+// PushRelabel computes a maximum flow from source to sink with the FIFO push-relabel method.
+// Edge weights are capacities and must be non-negative.
+func PushRelabel[V comparable, W Number](g *Graph[V, W], source, sink V) *MaxFlowResult[V, W] {
+	return maxFlow(g, source, sink, func(fn *flowNetwork[W], s, t int) {
+		height := make([]int, fn.n)
+		excess := make([]W, fn.n)
+		next := make([]int, fn.n)
+		active := make([]int, 0)
+
+		height[s] = fn.n
+		for _, arc := range fn.head[s] {
+			if amount := fn.residual[arc]; amount > 0 {
+				v := fn.to[arc]
+				fn.push(arc, amount)
+				if v != s && v != t && excess[v] == 0 {
+					active = append(active, v)
+				}
+				excess[v] += amount
+				excess[s] -= amount
+			}
+		}
+
+		for len(active) > 0 {
+			u := active[0]
+			active = active[1:]
+
+			// Discharge u: push along admissible arcs, relabelling when none are left
+			for excess[u] > 0 {
+				if next[u] == len(fn.head[u]) {
+					lowest := -1
+					for _, arc := range fn.head[u] {
+						if fn.residual[arc] > 0 && (lowest == -1 || height[fn.to[arc]] < lowest) {
+							lowest = height[fn.to[arc]]
+						}
+					}
+					if lowest == -1 {
+						break
+					}
+					height[u] = lowest + 1
+					next[u] = 0
+					continue
+				}
+
+				arc := fn.head[u][next[u]]
+				v := fn.to[arc]
+				if fn.residual[arc] > 0 && height[u] == height[v]+1 {
+					amount := min(excess[u], fn.residual[arc])
+					fn.push(arc, amount)
+					if v != s && v != t && excess[v] == 0 {
+						active = append(active, v)
+					}
+					excess[u] -= amount
+					excess[v] += amount
+				} else {
+					next[u]++
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,113 @@
+package graph
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+var maxFlowAlgorithms = map[string]func(*Graph[int, int], int, int) *MaxFlowResult[int, int]{
+	"EdmondsKarp": EdmondsKarp[int, int],
+	"Dinic":       Dinic[int, int],
+	"PushRelabel": PushRelabel[int, int],
+}
+
+// checkFlow verifies capacity limits, conservation, and that the cut matches the flow value
+func checkFlow(t *testing.T, name string, g *Graph[int, int], s, sink int, r *MaxFlowResult[int, int]) {
+	t.Helper()
+	balance := make(map[int]int)
+	for _, edge := range r.Flows {
+		if edge.Flow < 0 || edge.Flow > edge.Capacity {
+			t.Fatalf("%s: flow %d exceeds capacity %d on %d->%d", name, edge.Flow, edge.Capacity, edge.From, edge.To)
+		}
+		balance[edge.From] -= edge.Flow
+		balance[edge.To] += edge.Flow
+	}
+	for v, b := range balance {
+		if v != s && v != sink && b != 0 {
+			t.Fatalf("%s: flow not conserved at %d (%d)", name, v, b)
+		}
+	}
+	if balance[sink] != r.Value {
+		t.Fatalf("%s: sink receives %d, value is %d", name, balance[sink], r.Value)
+	}
+
+	cut := 0
+	for _, edge := range r.CutEdges {
+		cut += edge.Weight
+	}
+	if cut != r.Value {
+		t.Fatalf("%s: cut capacity %d differs from flow %d", name, cut, r.Value)
+	}
+	if len(r.SourceSide)+len(r.SinkSide) != g.GetVertices() {
+		t.Fatalf("%s: partition does not cover every vertex", name)
+	}
+}
+
+func TestMaxFlow(t *testing.T) {
+	t.Run("Textbook Network", func(t *testing.T) {
+		g := NewGraph(6, true)
+		g.AddEdge(0, 1, 16)
+		g.AddEdge(0, 2, 13)
+		g.AddEdge(1, 2, 10)
+		g.AddEdge(2, 1, 4)
+		g.AddEdge(1, 3, 12)
+		g.AddEdge(3, 2, 9)
+		g.AddEdge(2, 4, 14)
+		g.AddEdge(4, 3, 7)
+		g.AddEdge(3, 5, 20)
+		g.AddEdge(4, 5, 4)
+
+		for name, algorithm := range maxFlowAlgorithms {
+			r := algorithm(g, 0, 5)
+			if r.Value != 23 {
+				t.Errorf("%s: expected max flow 23, got %d", name, r.Value)
+			}
+			checkFlow(t, name, g, 0, 5, r)
+			if !reflect.DeepEqual(r.SinkSide, []int{3, 5}) {
+				t.Errorf("%s: expected sink side [3 5], got %v", name, r.SinkSide)
+			}
+		}
+	})
+
+	t.Run("Algorithms Agree On Random Graphs", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(5))
+		for trial := 0; trial < 30; trial++ {
+			g := NewGraph(12, trial%3 == 0)
+			for i := 0; i < 40; i++ {
+				g.AddEdge(rng.Intn(12), rng.Intn(12), rng.Intn(20))
+			}
+
+			expected := -1
+			for name, algorithm := range maxFlowAlgorithms {
+				r := algorithm(g, 0, 11)
+				checkFlow(t, name, g, 0, 11, r)
+				if expected == -1 {
+					expected = r.Value
+				} else if r.Value != expected {
+					t.Fatalf("Trial %d: %s found %d, expected %d", trial, name, r.Value, expected)
+				}
+			}
+		}
+	})
+
+	t.Run("Undirected And Labeled", func(t *testing.T) {
+		g := NewLabeledGraph[string, float64](false)
+		g.AddEdge("s", "a", 3.5)
+		g.AddEdge("a", "t", 2)
+		g.AddEdge("s", "b", 1)
+		g.AddEdge("b", "a", 1)
+		g.AddEdge("b", "t", 0.5)
+
+		r := Dinic(g, "t", "s")
+		if r.Value != 2.5 {
+			t.Errorf("Expected flow 2.5 against the listed direction, got %v", r.Value)
+		}
+		if r.Flow("a", "s")+r.Flow("b", "s") != 2.5 {
+			t.Errorf("Flows into s should add up to the value")
+		}
+		if Dinic(g, "s", "s") != nil || EdmondsKarp(g, "s", "missing") != nil {
+			t.Error("Expected nil for identical or unknown endpoints")
+		}
+	})
+}
@@ -0,0 +1,110 @@
+package graph
+
+import "container/heap"
+
+// MinCostFlowResult is a maximum flow of least total cost
+type MinCostFlowResult[V comparable, W Number] struct {
+	MaxFlowResult[V, W]
+	Cost W // Sum of flow times cost over all edges
+}
+
+// MinCostMaxFlow computes a maximum flow from source to sink with the least total cost, using
+// successive shortest paths with vertex potentials. Edge weights are capacities and must be
+// non-negative; cost returns the cost per unit of flow on an edge and is called once per edge
+// while the graph is read-locked. Costs may be negative as long as no cycle has negative cost.
+// Returns nil if either vertex is unknown or they are the same vertex.
+func MinCostMaxFlow[V comparable, W Number](g *Graph[V, W], source, sink V, cost func(edge Edge[V, W]) W) *MinCostFlowResult[V, W] {
+	var total W
+	result := maxFlow(g, source, sink, func(fn *flowNetwork[W], s, t int) {
+		costs := make([]W, len(fn.to))
+		i := 0
+		for from := 0; from < g.vertices; from++ {
+			for _, edge := range g.adjList[from] {
+				arc := fn.edges[i]
+				costs[arc] = cost(g.toEdge(edge))
+				costs[arc^1] = -costs[arc]
+				i++
+			}
+		}
+		total = fn.successiveShortestPaths(s, t, costs)
+	})
+	if result == nil {
+		return nil
+	}
+	return &MinCostFlowResult[V, W]{MaxFlowResult: *result, Cost: total}
+}
+
+// successiveShortestPaths augments along cheapest residual paths until none is left and returns the total cost
+func (fn *flowNetwork[W]) successiveShortestPaths(s, t int, costs []W) W {
+	var total W
+
+	// Bellman-Ford potentials make reduced costs non-negative when some costs are negative
+	potential := make([]W, fn.n)
+	for round := 0; round < fn.n; round++ {
+		changed := false
+		for u := 0; u < fn.n; u++ {
+			for _, arc := range fn.head[u] {
+				if v := fn.to[arc]; fn.residual[arc] > 0 && potential[u]+costs[arc] < potential[v] {
+					potential[v] = potential[u] + costs[arc]
+					changed = true
+				}
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+
+	for {
+		// Dijkstra on reduced costs cost(u,v) + potential(u) - potential(v)
+		dist := make([]W, fn.n)
+		via := make([]int, fn.n)
+		reached := make([]bool, fn.n)
+		settled := make([]bool, fn.n)
+		for i := range via {
+			via[i] = -1
+		}
+		reached[s] = true
+		pq := &PriorityQueue{}
+		heap.Init(pq)
+		heap.Push(pq, &Item{vertex: s, priority: 0})
+
+		for pq.Len() > 0 {
+			u := heap.Pop(pq).(*Item).vertex
+			if settled[u] {
+				continue
+			}
+			settled[u] = true
+			for _, arc := range fn.head[u] {
+				v := fn.to[arc]
+				if fn.residual[arc] <= 0 || settled[v] {
+					continue
+				}
+				d := dist[u] + costs[arc] + potential[u] - potential[v]
+				if !reached[v] || d < dist[v] {
+					dist[v] = d
+					via[v] = arc
+					reached[v] = true
+					heap.Push(pq, &Item{vertex: v, priority: float64(d)})
+				}
+			}
+		}
+		if !settled[t] {
+			return total
+		}
+		for v := 0; v < fn.n; v++ {
+			if settled[v] {
+				potential[v] += dist[v]
+			}
+		}
+
+		bottleneck := fn.residual[via[t]]
+		for v := t; v != s; v = fn.to[via[v]^1] {
+			bottleneck = min(bottleneck, fn.residual[via[v]])
+		}
+		for v := t; v != s; v = fn.to[via[v]^1] {
+			fn.push(via[v], bottleneck)
+			total += bottleneck * costs[via[v]]
+		}
+	}
+}
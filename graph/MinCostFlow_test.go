@@ -0,0 +1,60 @@
+package graph
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestMinCostMaxFlow(t *testing.T) {
+	costOf := func(edge Edge[string, int]) int {
+		if c, ok := edge.Attributes["cost"].(int); ok {
+			return c
+		}
+		return 0
+	}
+
+	t.Run("Prefers Cheap Routes", func(t *testing.T) {
+		g := NewLabeledGraph[string, int](true)
+		g.AddEdgeWithAttributes("s", "a", 4, map[string]any{"cost": 1})
+		g.AddEdgeWithAttributes("s", "b", 2, map[string]any{"cost": 5})
+		g.AddEdgeWithAttributes("a", "t", 2, map[string]any{"cost": 1})
+		g.AddEdgeWithAttributes("a", "b", 3, map[string]any{"cost": 1})
+		g.AddEdgeWithAttributes("b", "t", 3, map[string]any{"cost": 1})
+
+		r := MinCostMaxFlow(g, "s", "t", costOf)
+		if r.Value != 5 || r.Cost != 16 {
+			t.Errorf("Expected flow 5 at cost 16, got flow %d at cost %d", r.Value, r.Cost)
+		}
+		if r.Flow("s", "a") != 4 || r.Flow("s", "b") != 1 {
+			t.Errorf("Expected the cheap edge to be saturated, got %v", r.Flows)
+		}
+		if MinCostMaxFlow(g, "s", "missing", costOf) != nil {
+			t.Error("Expected nil for an unknown sink")
+		}
+	})
+
+	t.Run("Agrees With Hungarian", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(23))
+		for trial := 0; trial < 10; trial++ {
+			n := 5
+			costs := make([][]int, n)
+			g := NewLabeledGraph[string, int](true)
+			for i := 0; i < n; i++ {
+				costs[i] = make([]int, n)
+				g.AddEdge("s", fmt.Sprintf("w%d", i), 1)
+				g.AddEdge(fmt.Sprintf("j%d", i), "t", 1)
+				for j := 0; j < n; j++ {
+					costs[i][j] = rng.Intn(40) - 5 // Negative costs but no negative cycles
+					g.AddEdgeWithAttributes(fmt.Sprintf("w%d", i), fmt.Sprintf("j%d", j), 1, map[string]any{"cost": costs[i][j]})
+				}
+			}
+
+			r := MinCostMaxFlow(g, "s", "t", costOf)
+			_, expected := Hungarian(costs)
+			if r.Value != n || r.Cost != expected {
+				t.Fatalf("Trial %d: expected flow %d at cost %d, got flow %d at cost %d", trial, n, expected, r.Value, r.Cost)
+			}
+		}
+	})
+}
@@ -0,0 +1,289 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrDirectedGraph is returned by algorithms that need an undirected graph
+var ErrDirectedGraph = errors.New("graph: algorithm requires an undirected graph")
+
+// cancelCheckInterval is how many vertices a worker processes between context checks
+const cancelCheckInterval = 256
+
+// workerCount resolves the number of workers for n items; workers <= 0 means GOMAXPROCS
+func workerCount(workers, n int) int {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > n {
+		workers = n
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	return workers
+}
+
+// parallelRanges splits 0..n-1 into one contiguous range per worker and runs fn on each
+// concurrently, waiting for all of them
+func parallelRanges(n, workers int, fn func(worker, from, to int)) {
+	var wg sync.WaitGroup
+	size := (n + workers - 1) / workers
+	for worker := 0; worker < workers; worker++ {
+		from, to := worker*size, min((worker+1)*size, n)
+		if from >= to {
+			break
+		}
+		wg.Add(1)
+		go func(worker, from, to int) {
+			defer wg.Done()
+			fn(worker, from, to)
+		}(worker, from, to)
+	}
+	wg.Wait()
+}
+
+// ParallelBFS runs a level-synchronous breadth-first search from start, expanding each level's
+// frontier on up to workers goroutines (0 means GOMAXPROCS). It returns the vertices by
+// distance from start, each level in insertion order; concatenated they form a BFS order.
+// Returns the context's error if it is cancelled.
+func ParallelBFS[V comparable, W Number](ctx context.Context, g *Graph[V, W], start V, workers int) ([][]V, error) {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	s, exists := g.index[start]
+	if !exists {
+		return [][]V{}, nil
+	}
+
+	visited := make([]atomic.Bool, g.vertices)
+	visited[s].Store(true)
+	levels := [][]int{{s}}
+	for frontier := levels[0]; ; {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		w := workerCount(workers, len(frontier))
+		found := make([][]int, w)
+		parallelRanges(len(frontier), w, func(worker, from, to int) {
+			for i := from; i < to; i++ {
+				if (i-from)%cancelCheckInterval == 0 && ctx.Err() != nil {
+					return
+				}
+				for _, edge := range g.adjList[frontier[i]] {
+					if visited[edge.To].CompareAndSwap(false, true) {
+						found[worker] = append(found[worker], edge.To)
+					}
+				}
+			}
+		})
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		next := make([]int, 0)
+		for _, vertices := range found {
+			next = append(next, vertices...)
+		}
+		if len(next) == 0 {
+			break
+		}
+		sort.Ints(next)
+		levels = append(levels, next)
+		frontier = next
+	}
+	return g.componentsOf(levels), nil
+}
+
+// boruvkaCandidate is the cheapest edge found so far leaving a component
+type boruvkaCandidate[W Number] struct {
+	edge  Edge[int, W]
+	valid bool
+}
+
+// lighter orders edges by weight, then by endpoints, so that every component agrees on a
+// single order and no cycle can form
+func lighter[W Number](a, b Edge[int, W]) bool {
+	if a.Weight != b.Weight {
+		return a.Weight < b.Weight
+	}
+	if x, y := min(a.From, a.To), min(b.From, b.To); x != y {
+		return x < y
+	}
+	return max(a.From, a.To) < max(b.From, b.To)
+}
+
+// ParallelBoruvka finds a minimum spanning forest with Borůvka's algorithm: in every round
+// each component picks its cheapest outgoing edge, found by scanning vertices on up to workers
+// goroutines (0 means GOMAXPROCS), and all picked edges are added at once. Returns the edges
+// and their total weight, ErrDirectedGraph for a directed graph, or the context's error.
+func ParallelBoruvka[V comparable, W Number](ctx context.Context, g *Graph[V, W], workers int) ([]Edge[V, W], float64, error) {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	if g.directed {
+		return nil, 0, ErrDirectedGraph
+	}
+
+	n := g.vertices
+	uf := NewUnionFind(n)
+	component := make([]int, n)
+	forest := make([]Edge[int, W], 0)
+	cost := 0.0
+	w := workerCount(workers, n)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, 0, err
+		}
+		// UnionFind compresses paths as it reads, so resolve components before sharing them
+		for v := range component {
+			component[v] = uf.Find(v)
+		}
+
+		local := make([]map[int]boruvkaCandidate[W], w)
+		parallelRanges(n, w, func(worker, from, to int) {
+			best := make(map[int]boruvkaCandidate[W])
+			for v := from; v < to; v++ {
+				if (v-from)%cancelCheckInterval == 0 && ctx.Err() != nil {
+					return
+				}
+				c := component[v]
+				for _, edge := range g.adjList[v] {
+					if component[edge.To] == c {
+						continue
+					}
+					if current := best[c]; !current.valid || lighter(edge, current.edge) {
+						best[c] = boruvkaCandidate[W]{edge: edge, valid: true}
+					}
+				}
+			}
+			local[worker] = best
+		})
+		if err := ctx.Err(); err != nil {
+			return nil, 0, err
+		}
+
+		best := make(map[int]Edge[int, W])
+		for _, candidates := range local {
+			for c, candidate := range candidates {
+				if current, exists := best[c]; !exists || lighter(candidate.edge, current) {
+					best[c] = candidate.edge
+				}
+			}
+		}
+		if len(best) == 0 {
+			break
+		}
+
+		// Add in a fixed order so that the forest does not depend on map iteration
+		picked := make([]Edge[int, W], 0, len(best))
+		for _, edge := range best {
+			picked = append(picked, edge)
+		}
+		sort.Slice(picked, func(i, j int) bool { return lighter(picked[i], picked[j]) })
+		for _, edge := range picked {
+			if uf.Find(edge.From) != uf.Find(edge.To) {
+				uf.Union(edge.From, edge.To)
+				forest = append(forest, edge)
+				cost += float64(edge.Weight)
+			}
+		}
+	}
+	return g.toEdges(forest), cost, nil
+}
+
+// ParallelPageRank computes PageRank like PageRank, splitting each iteration's vertices among
+// up to workers goroutines (0 means GOMAXPROCS). Returns the context's error if it is
+// cancelled between iterations.
+func ParallelPageRank[V comparable, W Number](ctx context.Context, g *Graph[V, W], options PageRankOptions[V], workers int) (map[V]float64, error) {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	n := g.vertices
+	if n == 0 {
+		return map[V]float64{}, nil
+	}
+	m := g.newPageRankModel(options)
+	tolerance, maxIterations := pageRankDefaults(options)
+	w := workerCount(workers, n)
+
+	rank := make([]float64, n)
+	copy(rank, m.teleport)
+	next := make([]float64, n)
+	changes := make([]float64, w)
+	for iteration := 0; iteration < maxIterations; iteration++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		dangling := m.danglingRank(rank)
+		parallelRanges(n, w, func(worker, from, to int) {
+			changes[worker] = m.step(rank, next, dangling, from, to)
+		})
+		rank, next = next, rank
+
+		change := 0.0
+		for _, c := range changes {
+			change += c
+		}
+		if change < float64(n)*tolerance {
+			break
+		}
+	}
+	return g.scoresOf(rank), nil
+}
+
+// ParallelAllPairsDijkstra runs Dijkstra from every vertex on a pool of up to workers
+// goroutines (0 means GOMAXPROCS) and returns the shortest path tree of each source. Edge
+// weights must be non-negative. Returns the context's error if it is cancelled.
+func ParallelAllPairsDijkstra[V comparable, W Number](ctx context.Context, g *Graph[V, W], workers int) (map[V]*ShortestPaths[V, W], error) {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	n := g.vertices
+	trees := make([]*ShortestPaths[V, W], n)
+	sources := make(chan int)
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < workerCount(workers, n); worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for s := range sources {
+				dist, parent, reached := g.dijkstraIndexes([]int{s}, -1)
+				trees[s] = newShortestPaths(g, []int{s}, dist, parent, reached)
+			}
+		}()
+	}
+
+	var err error
+	for s := 0; s < n; s++ {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		select {
+		case sources <- s:
+		case <-ctx.Done():
+		}
+	}
+	if err == nil {
+		err = ctx.Err()
+	}
+	close(sources)
+	wg.Wait()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[V]*ShortestPaths[V, W], n)
+	for s, tree := range trees {
+		result[g.labels[s]] = tree
+	}
+	return result, nil
+}
@@ -0,0 +1,134 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// newRandomGraph builds a random graph with n vertices and about m edges of weight 1..10
+func newRandomGraph(seed int64, n, m int, directed bool) *Graph[int, int] {
+	rng := rand.New(rand.NewSource(seed))
+	g := NewGraph(n, directed)
+	for i := 0; i < m; i++ {
+		g.AddEdge(rng.Intn(n), rng.Intn(n), 1+rng.Intn(10))
+	}
+	return g
+}
+
+func TestParallelBFS(t *testing.T) {
+	g := newRandomGraph(1, 300, 900, true)
+	levels, err := ParallelBFS(context.Background(), g, 0, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every vertex sits at its unweighted distance from the start
+	unit := NewGraph(300, true)
+	for _, edge := range g.Edges() {
+		unit.AddEdge(edge.From, edge.To, 1)
+	}
+	distances := unit.Dijkstra(0).Distances()
+	count := 0
+	for depth, level := range levels {
+		for _, v := range level {
+			if distances[v] != depth {
+				t.Fatalf("Vertex %d at level %d, but its distance is %d", v, depth, distances[v])
+			}
+			count++
+		}
+	}
+	if count != len(distances) || count != len(g.BFS(0)) {
+		t.Errorf("Expected %d reachable vertices, got %d", len(distances), count)
+	}
+
+	if single, _ := ParallelBFS(context.Background(), g, 0, 1); !reflect.DeepEqual(single, levels) {
+		t.Error("Levels should not depend on the number of workers")
+	}
+	if empty, err := ParallelBFS(context.Background(), g, -1, 0); err != nil || len(empty) != 0 {
+		t.Errorf("Expected no levels for an unknown start, got %v, %v", empty, err)
+	}
+}
+
+func TestParallelBoruvka(t *testing.T) {
+	for trial := int64(0); trial < 10; trial++ {
+		g := newRandomGraph(trial, 120, 300, false)
+		edges, cost, err := ParallelBoruvka(context.Background(), g, 4)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Kruskal gives the same forest weight; KruskalMST considers every parallel edge
+		mst := NewKruskalMST(g)
+		mst.FindMST()
+		if kruskal := mst.GetMSTCost(); cost != kruskal {
+			t.Fatalf("Trial %d: expected forest weight %v, got %v", trial, kruskal, cost)
+		}
+		if expected := g.GetVertices() - len(WeaklyConnectedComponents(g)); len(edges) != expected {
+			t.Fatalf("Trial %d: expected %d forest edges, got %d", trial, expected, len(edges))
+		}
+		uf := NewUnionFind(g.GetVertices())
+		for _, edge := range edges {
+			if uf.Connected(edge.From, edge.To) {
+				t.Fatalf("Trial %d: edge %v closes a cycle", trial, edge)
+			}
+			uf.Union(edge.From, edge.To)
+		}
+	}
+
+	if _, _, err := ParallelBoruvka(context.Background(), NewGraph(2, true), 0); !errors.Is(err, ErrDirectedGraph) {
+		t.Errorf("Expected ErrDirectedGraph, got %v", err)
+	}
+}
+
+func TestParallelPageRank(t *testing.T) {
+	g := newRandomGraph(3, 200, 800, true)
+	options := PageRankOptions[int]{Weighted: true, Personalization: map[int]float64{0: 1, 5: 2}}
+	expected := PageRank(g, options)
+	got, err := ParallelPageRank(context.Background(), g, options, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for v, rank := range expected {
+		if !approxEqual(got[v], rank) {
+			t.Fatalf("Expected rank %v for %d, got %v", rank, v, got[v])
+		}
+	}
+}
+
+func TestParallelAllPairsDijkstra(t *testing.T) {
+	g := newRandomGraph(4, 80, 300, true)
+	trees, err := ParallelAllPairsDijkstra(context.Background(), g, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trees) != g.GetVertices() {
+		t.Fatalf("Expected %d trees, got %d", g.GetVertices(), len(trees))
+	}
+	for s := 0; s < g.GetVertices(); s++ {
+		if !reflect.DeepEqual(trees[s].Distances(), g.Dijkstra(s).Distances()) {
+			t.Fatalf("Distances from %d differ from Dijkstra", s)
+		}
+	}
+}
+
+func TestParallelCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	g := newRandomGraph(5, 50, 150, false)
+	if _, err := ParallelBFS(ctx, g, 0, 0); !errors.Is(err, context.Canceled) {
+		t.Errorf("ParallelBFS: expected context.Canceled, got %v", err)
+	}
+	if _, _, err := ParallelBoruvka(ctx, g, 0); !errors.Is(err, context.Canceled) {
+		t.Errorf("ParallelBoruvka: expected context.Canceled, got %v", err)
+	}
+	if _, err := ParallelPageRank(ctx, g, PageRankOptions[int]{}, 0); !errors.Is(err, context.Canceled) {
+		t.Errorf("ParallelPageRank: expected context.Canceled, got %v", err)
+	}
+	if _, err := ParallelAllPairsDijkstra(ctx, g, 0); !errors.Is(err, context.Canceled) {
+		t.Errorf("ParallelAllPairsDijkstra: expected context.Canceled, got %v", err)
+	}
+}
@@ -7,23 +7,27 @@ import (
 )
 
 // PrimMST implements Prim's algorithm for finding Minimum Spanning Tree
-type PrimMST struct {
-	graph    *Graph
-	key      []float64 // Key values (minimum weights)
-	parent   []int     // Parent nodes in MST
-	inMST    []bool    // Nodes included in MST
-	mstEdges []Edge    // Edges in MST
-	mstCost  float64   // Total cost of MST
-	infinity float64
-	mutex    sync.RWMutex
+type PrimMST[V comparable, W Number] struct {
+	graph        *Graph[V, W]
+	key          []float64      // Key values (minimum weights)
+	parent       []int          // Parent nodes in MST
+	weight       []W            // Weight of the edge to the parent
+	inMST        []bool         // Nodes included in MST
+	mstEdges     []Edge[int, W] // Edges in MST
+	mstCost      float64        // Total cost of MST
+	labels       []V            // Vertex index -> label at computation time
+	index        map[V]int      // Label -> vertex index at computation time
+	infinity     float64
+	graphVersion uint64 // Graph version the results were computed from; 0 before the first run
+	mutex        sync.RWMutex
 }
 
 // NewPrimMST creates a new Prim's MST instance
-func NewPrimMST(g *Graph) *PrimMST {
+func NewPrimMST[V comparable, W Number](g *Graph[V, W]) *PrimMST[V, W] {
 	if g.IsDirected() {
 		return nil // Prim algorithm works for undirected graphs
 	}
-	return &PrimMST{
+	return &PrimMST[V, W]{
 		graph:    g,
 		infinity: math.Inf(1),
 		mstCost:  0,
@@ -57,16 +61,27 @@ func (h *minHeap) Pop() interface{} {
 }
 
 // FindMST finds the Minimum Spanning Tree
-func (p *PrimMST) FindMST() bool {
+func (p *PrimMST[V, W]) FindMST() bool {
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
+	p.graph.mutex.RLock()
+	defer p.graph.mutex.RUnlock()
 
-	n := p.graph.GetVertices()
+	return p.grow()
+}
+
+// grow runs Prim's algorithm from vertex 0 on vertex indexes and reports whether every vertex
+// was reached; the caller must hold p's lock and the graph's read lock
+func (p *PrimMST[V, W]) grow() bool {
+	n := p.graph.vertices
 	p.key = make([]float64, n)
 	p.parent = make([]int, n)
+	p.weight = make([]W, n)
 	p.inMST = make([]bool, n)
-	p.mstEdges = make([]Edge, 0)
+	p.mstEdges = make([]Edge[int, W], 0)
 	p.mstCost = 0
+	p.graphVersion = p.graph.version
+	p.labels, p.index = p.graph.labelSnapshot()
 
 	// Initialize all keys to infinity
 	for i := 0; i < n; i++ {
@@ -74,6 +89,10 @@ func (p *PrimMST) FindMST() bool {
 		p.parent[i] = -1
 	}
 
+	if n == 0 {
+		return true
+	}
+
 	// Select the first node as the starting point
 	p.key[0] = 0
 
@@ -94,10 +113,10 @@ func (p *PrimMST) FindMST() bool {
 
 		// If this is not the first node, add the edge to the MST
 		if u != 0 {
-			p.mstEdges = append(p.mstEdges, Edge{
+			p.mstEdges = append(p.mstEdges, Edge[int, W]{
 				From:   p.parent[u],
 				To:     u,
-				Weight: int(p.key[u]),
+				Weight: p.weight[u],
 			})
 			p.mstCost += p.key[u]
 		}
@@ -110,6 +129,7 @@ func (p *PrimMST) FindMST() bool {
 			if !p.inMST[v] && weight < p.key[v] {
 				p.key[v] = weight
 				p.parent[v] = u
+				p.weight[v] = edge.Weight
 				heap.Push(h, &minHeapNode{v, weight})
 			}
 		}
@@ -126,29 +146,45 @@ func (p *PrimMST) FindMST() bool {
 }
 
 // GetMSTEdges returns the edges in the MST
-func (p *PrimMST) GetMSTEdges() []Edge {
+func (p *PrimMST[V, W]) GetMSTEdges() []Edge[V, W] {
 	p.mutex.RLock()
 	defer p.mutex.RUnlock()
-	return p.mstEdges
+	return labeledEdges(p.labels, p.mstEdges)
 }
 
 // GetMSTCost returns the total cost of the MST
-func (p *PrimMST) GetMSTCost() float64 {
+func (p *PrimMST[V, W]) GetMSTCost() float64 {
 	p.mutex.RLock()
 	defer p.mutex.RUnlock()
 	return p.mstCost
 }
 
-// GetParent returns the parent of a vertex in the MST
-func (p *PrimMST) GetParent(v int) int {
+// GetParent returns the parent of a vertex in the MST, or the zero value of V for the
+// root and for vertices outside the MST
+func (p *PrimMST[V, W]) GetParent(vertex V) V {
 	p.mutex.RLock()
 	defer p.mutex.RUnlock()
-	return p.parent[v]
+
+	var none V
+	v, exists := p.index[vertex]
+	if !exists || p.parent[v] == -1 {
+		return none
+	}
+	return p.labels[p.parent[v]]
 }
 
 // IsInMST checks if a vertex is in the MST
-func (p *PrimMST) IsInMST(v int) bool {
+func (p *PrimMST[V, W]) IsInMST(vertex V) bool {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	v, exists := p.index[vertex]
+	return exists && p.inMST[v]
+}
+
+// IsStale reports whether the graph has changed since the MST was last computed
+func (p *PrimMST[V, W]) IsStale() bool {
 	p.mutex.RLock()
 	defer p.mutex.RUnlock()
-	return p.inMST[v]
+	return p.graphVersion != p.graph.Version()
 }
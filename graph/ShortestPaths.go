@@ -0,0 +1,211 @@
+package graph
+
+import "container/heap"
+
+// ShortestPaths is a shortest-path tree produced by Dijkstra's algorithm.
+// It is a snapshot and does not change when the graph is modified later.
+type ShortestPaths[V comparable, W Number] struct {
+	sources  []V
+	distance map[V]W
+	parent   map[V]V
+	origin   map[V]V // Source each vertex was reached from
+}
+
+// newShortestPaths translates an index-based shortest-path tree to labels
+func newShortestPaths[V comparable, W Number](g *Graph[V, W], sources []int, dist []W, parent []int, reached []bool) *ShortestPaths[V, W] {
+	sp := &ShortestPaths[V, W]{
+		sources:  g.labelsOf(sources),
+		distance: make(map[V]W),
+		parent:   make(map[V]V),
+		origin:   make(map[V]V),
+	}
+
+	for v := range reached {
+		if !reached[v] {
+			continue
+		}
+		label := g.labels[v]
+		sp.distance[label] = dist[v]
+		if parent[v] != -1 {
+			sp.parent[label] = g.labels[parent[v]]
+		}
+	}
+
+	// Sources are their own origin; everything else inherits from its parent
+	for _, s := range sources {
+		sp.origin[g.labels[s]] = g.labels[s]
+	}
+	for v := range reached {
+		if reached[v] {
+			sp.originOf(g.labels[v])
+		}
+	}
+	return sp
+}
+
+// originOf resolves and caches the source a vertex was reached from
+func (sp *ShortestPaths[V, W]) originOf(v V) V {
+	if o, exists := sp.origin[v]; exists {
+		return o
+	}
+	o := sp.originOf(sp.parent[v])
+	sp.origin[v] = o
+	return o
+}
+
+// Sources returns the vertices the search started from
+func (sp *ShortestPaths[V, W]) Sources() []V {
+	sources := make([]V, len(sp.sources))
+	copy(sources, sp.sources)
+	return sources
+}
+
+// HasPathTo reports whether v was reached
+func (sp *ShortestPaths[V, W]) HasPathTo(v V) bool {
+	_, exists := sp.distance[v]
+	return exists
+}
+
+// DistanceTo returns the length of the shortest path to v
+func (sp *ShortestPaths[V, W]) DistanceTo(v V) (W, bool) {
+	d, exists := sp.distance[v]
+	return d, exists
+}
+
+// PathTo returns the vertices on the shortest path to v, starting at its source.
+// Returns nil if v was not reached.
+func (sp *ShortestPaths[V, W]) PathTo(v V) []V {
+	if !sp.HasPathTo(v) {
+		return nil
+	}
+
+	path := []V{v}
+	for {
+		p, exists := sp.parent[v]
+		if !exists {
+			break
+		}
+		path = append(path, p)
+		v = p
+	}
+
+	// Reverse the path
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// Parent returns the predecessor of v in the shortest-path tree
+func (sp *ShortestPaths[V, W]) Parent(v V) (V, bool) {
+	p, exists := sp.parent[v]
+	return p, exists
+}
+
+// SourceOf returns the source whose shortest path reaches v
+func (sp *ShortestPaths[V, W]) SourceOf(v V) (V, bool) {
+	o, exists := sp.origin[v]
+	return o, exists
+}
+
+// Distances returns the distance to every reached vertex
+func (sp *ShortestPaths[V, W]) Distances() map[V]W {
+	distances := make(map[V]W, len(sp.distance))
+	for v, d := range sp.distance {
+		distances[v] = d
+	}
+	return distances
+}
+
+// DijkstraTo finds the shortest path from source to target, stopping as soon as target is settled.
+// Only vertices settled before target are in the result, which is empty if either vertex is unknown.
+func (g *Graph[V, W]) DijkstraTo(source, target V) *ShortestPaths[V, W] {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	start, ok1 := g.index[source]
+	end, ok2 := g.index[target]
+	if !ok1 || !ok2 {
+		return g.dijkstra(nil, -1)
+	}
+	return g.dijkstra([]int{start}, end)
+}
+
+// MultiSourceDijkstra finds, for every vertex, the shortest path from the nearest of the sources.
+// Unknown sources are ignored.
+func (g *Graph[V, W]) MultiSourceDijkstra(sources ...V) *ShortestPaths[V, W] {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	starts := make([]int, 0, len(sources))
+	for _, s := range sources {
+		if i, exists := g.index[s]; exists {
+			starts = append(starts, i)
+		}
+	}
+	return g.dijkstra(starts, -1)
+}
+
+// dijkstra runs Dijkstra's algorithm from the source indexes; it stops once target is settled
+// unless target is -1. The caller must hold the read lock.
+func (g *Graph[V, W]) dijkstra(sources []int, target int) *ShortestPaths[V, W] {
+	dist, parent, reached := g.dijkstraIndexes(sources, target)
+	return newShortestPaths(g, sources, dist, parent, reached)
+}
+
+// dijkstraIndexes computes distances and predecessors by vertex index
+func (g *Graph[V, W]) dijkstraIndexes(sources []int, target int) ([]W, []int, []bool) {
+	return g.dijkstraFiltered(sources, target, nil)
+}
+
+// dijkstraFiltered is dijkstraIndexes ignoring the edges for which skip returns true
+func (g *Graph[V, W]) dijkstraFiltered(sources []int, target int, skip func(from, to int) bool) ([]W, []int, []bool) {
+	dist := make([]W, g.vertices)
+	parent := make([]int, g.vertices)
+	reached := make([]bool, g.vertices)
+	settled := make([]bool, g.vertices)
+	for i := range parent {
+		parent[i] = -1
+	}
+
+	pq := &PriorityQueue{}
+	heap.Init(pq)
+	for _, s := range sources {
+		if !reached[s] {
+			reached[s] = true
+			heap.Push(pq, &Item{vertex: s, priority: 0})
+		}
+	}
+
+	for pq.Len() > 0 {
+		vertex := heap.Pop(pq).(*Item).vertex
+		if settled[vertex] {
+			continue
+		}
+		settled[vertex] = true
+		if vertex == target {
+			break
+		}
+
+		for _, edge := range g.adjList[vertex] {
+			if settled[edge.To] || (skip != nil && skip(vertex, edge.To)) {
+				continue
+			}
+			distance := dist[vertex] + edge.Weight
+			if !reached[edge.To] || distance < dist[edge.To] {
+				dist[edge.To] = distance
+				parent[edge.To] = vertex
+				reached[edge.To] = true
+				heap.Push(pq, &Item{vertex: edge.To, priority: float64(distance)})
+			}
+		}
+	}
+
+	// With early termination, only settled distances are final
+	if target != -1 {
+		for v := range reached {
+			reached[v] = settled[v]
+		}
+	}
+	return dist, parent, reached
+}
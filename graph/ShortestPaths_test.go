@@ -0,0 +1,97 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestShortestPaths(t *testing.T) {
+	newRoutes := func() *Graph[string, int] {
+		g := NewLabeledGraph[string, int](true)
+		g.AddEdge("gateway", "auth", 4)
+		g.AddEdge("gateway", "cache", 1)
+		g.AddEdge("cache", "auth", 2)
+		g.AddEdge("auth", "users", 1)
+		g.AddEdge("cache", "users", 5)
+		g.AddEdge("users", "billing", 3)
+		g.AddVertex("orphan")
+		return g
+	}
+
+	t.Run("Path Reconstruction", func(t *testing.T) {
+		sp := newRoutes().Dijkstra("gateway")
+
+		expected := []string{"gateway", "cache", "auth", "users", "billing"}
+		if path := sp.PathTo("billing"); !reflect.DeepEqual(path, expected) {
+			t.Errorf("Expected path %v, got %v", expected, path)
+		}
+		if d, ok := sp.DistanceTo("billing"); !ok || d != 7 {
+			t.Errorf("Expected distance 7, got %d", d)
+		}
+		if parent, ok := sp.Parent("users"); !ok || parent != "auth" {
+			t.Errorf("Expected parent auth, got %v", parent)
+		}
+		if !reflect.DeepEqual(sp.PathTo("gateway"), []string{"gateway"}) {
+			t.Errorf("Path to the source should be the source, got %v", sp.PathTo("gateway"))
+		}
+		if sp.HasPathTo("orphan") || sp.PathTo("orphan") != nil {
+			t.Error("Unreachable vertex should have no path")
+		}
+		if _, ok := sp.DistanceTo("orphan"); ok {
+			t.Error("Unreachable vertex should have no distance")
+		}
+	})
+
+	t.Run("Unknown Source", func(t *testing.T) {
+		sp := newRoutes().Dijkstra("missing")
+		if sp.HasPathTo("gateway") || len(sp.Distances()) != 0 {
+			t.Error("Unknown source should produce an empty result")
+		}
+	})
+
+	t.Run("Single Pair Early Exit", func(t *testing.T) {
+		g := newRoutes()
+		sp := g.DijkstraTo("gateway", "auth")
+
+		if !reflect.DeepEqual(sp.PathTo("auth"), []string{"gateway", "cache", "auth"}) {
+			t.Errorf("Unexpected path %v", sp.PathTo("auth"))
+		}
+		if d, _ := sp.DistanceTo("auth"); d != 3 {
+			t.Errorf("Expected distance 3, got %d", d)
+		}
+		if sp.HasPathTo("billing") {
+			t.Error("Search should stop before settling vertices farther than the target")
+		}
+		if g.DijkstraTo("gateway", "missing").HasPathTo("gateway") {
+			t.Error("Unknown target should produce an empty result")
+		}
+		if g.DijkstraTo("billing", "gateway").HasPathTo("gateway") {
+			t.Error("Target should be unreachable against edge direction")
+		}
+	})
+
+	t.Run("Multiple Sources", func(t *testing.T) {
+		g := NewLabeledGraph[string, float64](false)
+		g.AddEdge("a", "b", 1)
+		g.AddEdge("b", "c", 1)
+		g.AddEdge("c", "d", 1)
+		g.AddEdge("d", "e", 1)
+
+		sp := g.MultiSourceDijkstra("a", "e", "unknown")
+		if !reflect.DeepEqual(sp.Sources(), []string{"a", "e"}) {
+			t.Errorf("Unknown sources should be ignored, got %v", sp.Sources())
+		}
+		if d, _ := sp.DistanceTo("d"); d != 1 {
+			t.Errorf("Expected distance 1 from the nearest source, got %v", d)
+		}
+		if source, _ := sp.SourceOf("d"); source != "e" {
+			t.Errorf("Expected d to be served by e, got %v", source)
+		}
+		if source, _ := sp.SourceOf("b"); source != "a" {
+			t.Errorf("Expected b to be served by a, got %v", source)
+		}
+		if !reflect.DeepEqual(sp.PathTo("d"), []string{"e", "d"}) {
+			t.Errorf("Unexpected path %v", sp.PathTo("d"))
+		}
+	})
+}
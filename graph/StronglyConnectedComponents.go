@@ -3,20 +3,21 @@ package graph
 import "sync"
 
 // StronglyConnectedComponents implements Kosaraju's algorithm for finding SCCs
-type StronglyConnectedComponents struct {
-	graph      *Graph
-	visited    map[int]bool
-	finishTime []int
-	components [][]int
-	mutex      sync.RWMutex
+type StronglyConnectedComponents[V comparable, W Number] struct {
+	graph        *Graph[V, W]
+	visited      map[int]bool
+	finishTime   []int
+	components   [][]int
+	graphVersion uint64 // Graph version the components were computed from; 0 before the first run
+	mutex        sync.RWMutex
 }
 
 // NewSCC creates a new SCC instance
-func NewSCC(g *Graph) *StronglyConnectedComponents {
+func NewSCC[V comparable, W Number](g *Graph[V, W]) *StronglyConnectedComponents[V, W] {
 	if !g.IsDirected() {
 		return nil // SCC is only meaningful for directed graphs
 	}
-	return &StronglyConnectedComponents{
+	return &StronglyConnectedComponents[V, W]{
 		graph:      g,
 		visited:    make(map[int]bool),
 		finishTime: make([]int, 0),
@@ -26,9 +27,20 @@ func NewSCC(g *Graph) *StronglyConnectedComponents {
 }
 
 // FindComponents finds all strongly connected components
-func (scc *StronglyConnectedComponents) FindComponents() [][]int {
+func (scc *StronglyConnectedComponents[V, W]) FindComponents() [][]V {
 	scc.mutex.Lock()
 	defer scc.mutex.Unlock()
+	scc.graph.mutex.RLock()
+	defer scc.graph.mutex.RUnlock()
+	return scc.graph.componentsOf(scc.findComponents())
+}
+
+// findComponents finds all strongly connected components as vertex indexes; the caller must
+// hold the graph's read lock
+func (scc *StronglyConnectedComponents[V, W]) findComponents() [][]int {
+	scc.visited = make(map[int]bool)
+	scc.finishTime = make([]int, 0)
+	scc.graphVersion = scc.graph.version
 
 	// 1. First DFS to calculate finish times
 	scc.firstDFS()
@@ -54,15 +66,15 @@ func (scc *StronglyConnectedComponents) FindComponents() [][]int {
 }
 
 // firstDFS performs first DFS pass to compute finish times
-func (scc *StronglyConnectedComponents) firstDFS() {
-	for v := 0; v < scc.graph.GetVertices(); v++ {
+func (scc *StronglyConnectedComponents[V, W]) firstDFS() {
+	for v := 0; v < scc.graph.vertices; v++ {
 		if !scc.visited[v] {
 			scc.firstDFSUtil(v)
 		}
 	}
 }
 
-func (scc *StronglyConnectedComponents) firstDFSUtil(v int) {
+func (scc *StronglyConnectedComponents[V, W]) firstDFSUtil(v int) {
 	scc.visited[v] = true
 
 	// Visit neighbors
@@ -76,14 +88,14 @@ func (scc *StronglyConnectedComponents) firstDFSUtil(v int) {
 	scc.finishTime = append(scc.finishTime, v)
 }
 
-// getTranspose returns the transpose of the graph
-func (scc *StronglyConnectedComponents) getTranspose() *Graph {
-	transpose := NewGraph(scc.graph.GetVertices(), true)
+// getTranspose returns the adjacency lists of the transposed graph
+func (scc *StronglyConnectedComponents[V, W]) getTranspose() [][]int {
+	transpose := make([][]int, scc.graph.vertices)
 
 	// Reverse each edge
-	for v := 0; v < scc.graph.GetVertices(); v++ {
+	for v := 0; v < scc.graph.vertices; v++ {
 		for _, edge := range scc.graph.adjList[v] {
-			transpose.AddEdge(edge.To, v, edge.Weight)
+			transpose[edge.To] = append(transpose[edge.To], v)
 		}
 	}
 
@@ -91,36 +103,47 @@ func (scc *StronglyConnectedComponents) getTranspose() *Graph {
 }
 
 // secondDFS performs second DFS pass to find components
-func (scc *StronglyConnectedComponents) secondDFS(g *Graph, v int, component *[]int) {
+func (scc *StronglyConnectedComponents[V, W]) secondDFS(transpose [][]int, v int, component *[]int) {
 	scc.visited[v] = true
 	*component = append(*component, v)
 
 	// Visit neighbors
-	for _, edge := range g.adjList[v] {
-		if !scc.visited[edge.To] {
-			scc.secondDFS(g, edge.To, component)
+	for _, u := range transpose[v] {
+		if !scc.visited[u] {
+			scc.secondDFS(transpose, u, component)
 		}
 	}
 }
 
-// GetComponents returns all found components
-func (scc *StronglyConnectedComponents) GetComponents() [][]int {
+// GetComponents returns all found components, recomputing them if the graph has changed
+func (scc *StronglyConnectedComponents[V, W]) GetComponents() [][]V {
 	scc.mutex.Lock()
 	defer scc.mutex.Unlock()
+	scc.graph.mutex.RLock()
+	defer scc.graph.mutex.RUnlock()
 
-	if len(scc.components) == 0 {
-		return scc.FindComponents()
+	if scc.graphVersion != scc.graph.version {
+		scc.findComponents()
 	}
-	return scc.components
+	return scc.graph.componentsOf(scc.components)
 }
 
 // GetComponentCount returns the number of strongly connected components
-func (scc *StronglyConnectedComponents) GetComponentCount() int {
+func (scc *StronglyConnectedComponents[V, W]) GetComponentCount() int {
 	scc.mutex.Lock()
 	defer scc.mutex.Unlock()
+	scc.graph.mutex.RLock()
+	defer scc.graph.mutex.RUnlock()
 
-	if len(scc.components) == 0 {
-		scc.FindComponents()
+	if scc.graphVersion != scc.graph.version {
+		scc.findComponents()
 	}
 	return len(scc.components)
 }
+
+// IsStale reports whether the graph has changed since the components were last computed
+func (scc *StronglyConnectedComponents[V, W]) IsStale() bool {
+	scc.mutex.RLock()
+	defer scc.mutex.RUnlock()
+	return scc.graphVersion != scc.graph.Version()
+}
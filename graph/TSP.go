@@ -0,0 +1,334 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrNoTour is returned when no tour can visit every vertex and return to the start
+var ErrNoTour = errors.New("graph: no tour visits every vertex")
+
+// ErrInvalidTour is returned when a given tour is not a closed walk through every vertex once
+var ErrInvalidTour = errors.New("graph: invalid tour")
+
+// improvementEpsilon is the least cost decrease local search accepts, so rounding cannot loop
+const improvementEpsilon = 1e-9
+
+// tourStart resolves the start vertex of a tour; the caller must hold the read lock
+func (g *Graph[V, W]) tourStart(start V) (int, error) {
+	s, exists := g.index[start]
+	if !exists {
+		return -1, fmt.Errorf("%w: unknown start vertex %v", ErrNoTour, start)
+	}
+	return s, nil
+}
+
+// closeTour rotates a cyclic order of vertex indexes to begin at s and returns to s at the end
+func closeTour(order []int, s int) []int {
+	k := 0
+	for i, v := range order {
+		if v == s {
+			k = i
+		}
+	}
+	tour := make([]int, 0, len(order)+1)
+	tour = append(tour, order[k:]...)
+	tour = append(tour, order[:k]...)
+	return append(tour, s)
+}
+
+// finishTour turns a cyclic order into the labeled tour from s and its cost; the caller must
+// hold the read lock
+func (g *Graph[V, W]) finishTour(order []int, s int) ([]V, W, error) {
+	tour := closeTour(order, s)
+	if len(order) == 1 {
+		return g.labelsOf(tour), 0, nil // A lone vertex is toured without moving
+	}
+	cost, ok := g.pathWeight(tour)
+	if !ok {
+		return nil, 0, fmt.Errorf("%w: tour %v uses a missing edge", ErrNoTour, g.labelsOf(tour))
+	}
+	return g.labelsOf(tour), cost, nil
+}
+
+// HeldKarpTour solves the traveling salesman problem exactly with the Held–Karp dynamic
+// program in O(2^N * N^2) time: it returns the cheapest tour visiting every vertex once,
+// starting and ending at start, and its cost. Directed graphs are supported. It returns
+// ErrNoTour if there is no such tour, ErrTooManyVertices above MaxHeldKarpVertices, and the
+// errors of FindHamiltonianPathContext if ctx is done.
+func HeldKarpTour[V comparable, W Number](ctx context.Context, g *Graph[V, W], start V) ([]V, W, error) {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	s, err := g.tourStart(start)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := contextError(ctx); err != nil {
+		return nil, 0, err
+	}
+	if g.vertices == 1 {
+		return g.finishTour([]int{s}, s)
+	}
+
+	order, _, err := heldKarp(ctx, g.costMatrix(), true)
+	if err != nil {
+		return nil, 0, err
+	}
+	if order == nil {
+		return nil, 0, ErrNoTour
+	}
+	return g.finishTour(order[:len(order)-1], s)
+}
+
+// DoubleTreeTour approximates the traveling salesman problem by walking a minimum spanning
+// tree found with Prim's algorithm in preorder from start and skipping vertices already seen.
+// On a complete graph whose weights obey the triangle inequality the tour costs at most twice
+// the optimum. It returns ErrDirectedGraph for a directed graph and ErrNoTour if the graph is
+// disconnected or a shortcut edge is missing.
+func DoubleTreeTour[V comparable, W Number](g *Graph[V, W], start V) ([]V, W, error) {
+	prim := NewPrimMST(g)
+	if prim == nil {
+		return nil, 0, ErrDirectedGraph
+	}
+
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	s, err := g.tourStart(start)
+	if err != nil {
+		return nil, 0, err
+	}
+	// Grow the tree under our lock so its indexes match the graph we walk
+	prim.mutex.Lock()
+	connected := prim.grow()
+	prim.mutex.Unlock()
+	if !connected {
+		return nil, 0, fmt.Errorf("%w: graph is disconnected", ErrNoTour)
+	}
+
+	tree := make([][]int, g.vertices)
+	for _, edge := range prim.mstEdges {
+		tree[edge.From] = append(tree[edge.From], edge.To)
+		tree[edge.To] = append(tree[edge.To], edge.From)
+	}
+
+	// Iterative preorder walk; children are pushed in reverse to visit them in tree order
+	order := make([]int, 0, g.vertices)
+	visited := make([]bool, g.vertices)
+	stack := []int{s}
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if visited[v] {
+			continue
+		}
+		visited[v] = true
+		order = append(order, v)
+		for i := len(tree[v]) - 1; i >= 0; i-- {
+			if !visited[tree[v][i]] {
+				stack = append(stack, tree[v][i])
+			}
+		}
+	}
+
+	cost := g.costMatrix()
+	for i := range order {
+		from, to := order[i], order[(i+1)%len(order)]
+		if len(order) > 1 && math.IsInf(cost[from][to], 1) {
+			return nil, 0, fmt.Errorf("%w: no edge from %v to %v to shortcut the tree", ErrNoTour, g.labels[from], g.labels[to])
+		}
+	}
+	return g.finishTour(order, s)
+}
+
+// NearestNeighborTour builds a tour greedily: starting at start, it always moves to the
+// cheapest unvisited vertex, then returns to start. Ties go to the earlier vertex. Directed
+// graphs are supported. It returns ErrNoTour if the walk gets stuck.
+func NearestNeighborTour[V comparable, W Number](g *Graph[V, W], start V) ([]V, W, error) {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	s, err := g.tourStart(start)
+	if err != nil {
+		return nil, 0, err
+	}
+	order, err := g.nearestNeighbor(g.costMatrix(), s)
+	if err != nil {
+		return nil, 0, err
+	}
+	return g.finishTour(order, s)
+}
+
+// nearestNeighbor returns the greedy cyclic order from s; the caller must hold the read lock
+func (g *Graph[V, W]) nearestNeighbor(cost [][]float64, s int) ([]int, error) {
+	n := len(cost)
+	order := make([]int, 0, n)
+	visited := make([]bool, n)
+	for v := s; v != -1; {
+		order = append(order, v)
+		visited[v] = true
+
+		next := -1
+		for u := 0; u < n; u++ {
+			if !visited[u] && cost[v][u] < math.Inf(1) && (next == -1 || cost[v][u] < cost[v][next]) {
+				next = u
+			}
+		}
+		v = next
+	}
+
+	last := order[len(order)-1]
+	if len(order) < n {
+		return nil, fmt.Errorf("%w: stuck at %v", ErrNoTour, g.labels[last])
+	}
+	if n > 1 && math.IsInf(cost[last][s], 1) {
+		return nil, fmt.Errorf("%w: no edge from %v back to %v", ErrNoTour, g.labels[last], g.labels[s])
+	}
+	return order, nil
+}
+
+// ImproveTour shortens a tour by local search until no move helps: 2-opt reverses a stretch
+// of the tour to replace two edges, and Or-opt moves a run of up to three vertices, possibly
+// reversed, elsewhere in the tour. The tour must start and end at the same vertex and visit
+// every other vertex once; the result keeps its start. It returns ErrDirectedGraph for a
+// directed graph, ErrInvalidTour for a malformed tour and the context's error if it is
+// cancelled.
+func ImproveTour[V comparable, W Number](ctx context.Context, g *Graph[V, W], tour []V) ([]V, W, error) {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	if g.directed {
+		return nil, 0, ErrDirectedGraph
+	}
+	cost := g.costMatrix()
+	order, err := g.tourOrder(cost, tour)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := improveTour(ctx, cost, order); err != nil {
+		return nil, 0, err
+	}
+	return g.finishTour(order, g.index[tour[0]])
+}
+
+// LocalSearchTour builds a tour with NearestNeighborTour from start and shortens it with
+// ImproveTour. It returns the errors of both.
+func LocalSearchTour[V comparable, W Number](ctx context.Context, g *Graph[V, W], start V) ([]V, W, error) {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	if g.directed {
+		return nil, 0, ErrDirectedGraph
+	}
+	s, err := g.tourStart(start)
+	if err != nil {
+		return nil, 0, err
+	}
+	cost := g.costMatrix()
+	order, err := g.nearestNeighbor(cost, s)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := improveTour(ctx, cost, order); err != nil {
+		return nil, 0, err
+	}
+	return g.finishTour(order, s)
+}
+
+// tourOrder checks a closed labeled tour and returns its cyclic order of vertex indexes; the
+// caller must hold the read lock
+func (g *Graph[V, W]) tourOrder(cost [][]float64, tour []V) ([]int, error) {
+	n := g.vertices
+	if n == 0 || len(tour) != n+1 || tour[0] != tour[n] {
+		return nil, fmt.Errorf("%w: expected %d vertices returning to the start, got %d", ErrInvalidTour, n+1, len(tour))
+	}
+	order, known := g.indexesOf(tour[:n])
+	if !known {
+		return nil, fmt.Errorf("%w: unknown vertex", ErrInvalidTour)
+	}
+	seen := make([]bool, n)
+	for i, v := range order {
+		if seen[v] {
+			return nil, fmt.Errorf("%w: %v is visited twice", ErrInvalidTour, tour[i])
+		}
+		seen[v] = true
+		if next := order[(i+1)%n]; n > 1 && math.IsInf(cost[v][next], 1) {
+			return nil, fmt.Errorf("%w: no edge from %v to %v", ErrInvalidTour, tour[i], g.labels[next])
+		}
+	}
+	return order, nil
+}
+
+// improveTour applies improving 2-opt and Or-opt moves to a cyclic order of a symmetric cost
+// matrix until neither finds one
+func improveTour(ctx context.Context, cost [][]float64, order []int) error {
+	for improved := true; improved; {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		improved = twoOpt(cost, order) || orOpt(cost, order)
+	}
+	return nil
+}
+
+// twoOpt applies every improving 2-opt move found in one sweep and reports whether any was.
+// Replacing edges (a,b) and (c,d) by (a,c) and (b,d) reverses the stretch from b to c.
+func twoOpt(cost [][]float64, order []int) bool {
+	n := len(order)
+	improved := false
+	for i := 0; i < n-2; i++ {
+		for j := i + 2; j < n; j++ {
+			a, b, c, d := order[i], order[i+1], order[j], order[(j+1)%n]
+			if a == d {
+				continue // The two edges share a vertex
+			}
+			if cost[a][c]+cost[b][d]-cost[a][b]-cost[c][d] < -improvementEpsilon {
+				reverse(order[i+1 : j+1])
+				improved = true
+			}
+		}
+	}
+	return improved
+}
+
+// orOpt applies the first improving Or-opt move: a run of one to three vertices is cut out and
+// reinserted, either way round, between two other neighbors. It reports whether it moved one.
+func orOpt(cost [][]float64, order []int) bool {
+	n := len(order)
+	for length := 1; length <= 3 && length+2 <= n; length++ {
+		for i := 1; i+length <= n; i++ {
+			first, last := order[i], order[i+length-1]
+			prev, next := order[i-1], order[(i+length)%n]
+			removed := cost[prev][first] + cost[last][next] - cost[prev][next]
+
+			for j := 0; j < n; j++ {
+				if j >= i-1 && j < i+length {
+					continue // The edge touches the run
+				}
+				p, q := order[j], order[(j+1)%n]
+				forward := cost[p][first] + cost[last][q] - cost[p][q]
+				backward := cost[p][last] + cost[first][q] - cost[p][q]
+				if min(forward, backward)-removed >= -improvementEpsilon {
+					continue
+				}
+
+				run := append([]int(nil), order[i:i+length]...)
+				if backward < forward {
+					reverse(run)
+				}
+				rest := append(append([]int(nil), order[:i]...), order[i+length:]...)
+				k := j + 1
+				if j > i {
+					k -= length
+				}
+				moved := append(append(append(make([]int, 0, n), rest[:k]...), run...), rest[k:]...)
+				copy(order, moved)
+				return true
+			}
+		}
+	}
+	return false
+}
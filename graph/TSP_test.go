@@ -0,0 +1,238 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// newEuclideanGraph builds a complete undirected graph on n random points in the unit square,
+// weighted by distance, together with its distance matrix
+func newEuclideanGraph(seed int64, n int) (*Graph[int, float64], [][]float64) {
+	rng := rand.New(rand.NewSource(seed))
+	x, y := make([]float64, n), make([]float64, n)
+	g := NewLabeledGraph[int, float64](false)
+	for i := range x {
+		x[i], y[i] = rng.Float64(), rng.Float64()
+		g.AddVertex(i)
+	}
+	distance := make([][]float64, n)
+	for i := range distance {
+		distance[i] = make([]float64, n)
+		for j := range distance[i] {
+			distance[i][j] = math.Hypot(x[i]-x[j], y[i]-y[j])
+			if i < j {
+				g.AddEdge(i, j, distance[i][j])
+			}
+		}
+	}
+	return g, distance
+}
+
+// checkTour verifies that a tour starts and ends at start, visits every vertex once and costs
+// what is reported
+func checkTour[W Number](t *testing.T, name string, g *Graph[int, W], start int, tour []int, cost W) {
+	t.Helper()
+	if len(tour) == 0 || tour[0] != start || !NewHamiltonianPath(g).IsHamiltonianCircuit(tour) {
+		t.Fatalf("%s: %v is not a tour from %d", name, tour, start)
+	}
+	var total W
+	for i := 1; i < len(tour); i++ {
+		lightest, found := W(0), false
+		for _, edge := range g.Edges() {
+			joins := edge.From == tour[i-1] && edge.To == tour[i] || !g.IsDirected() && edge.From == tour[i] && edge.To == tour[i-1]
+			if joins && (!found || edge.Weight < lightest) {
+				lightest, found = edge.Weight, true
+			}
+		}
+		total += lightest
+	}
+	if !approxEqual(float64(total), float64(cost)) {
+		t.Errorf("%s: tour costs %v, reported %v", name, total, cost)
+	}
+}
+
+func TestHeldKarpTour(t *testing.T) {
+	for trial := int64(0); trial < 5; trial++ {
+		g, _ := newEuclideanGraph(trial, 8)
+		tour, cost, err := HeldKarpTour(context.Background(), g, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkTour(t, "Held-Karp", g, 3, tour, cost)
+		if optimum, _ := bruteForceOrder(g, true); !approxEqual(cost, optimum) {
+			t.Fatalf("Trial %d: expected optimal cost %v, got %v", trial, optimum, cost)
+		}
+	}
+
+	// Directed costs differ by direction: clockwise is cheap
+	d := NewLabeledGraph[string, int](true)
+	for _, edge := range [][2]string{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"d", "a"}} {
+		d.AddEdge(edge[0], edge[1], 1)
+		d.AddEdge(edge[1], edge[0], 10)
+	}
+	tour, cost, err := HeldKarpTour(context.Background(), d, "c")
+	if err != nil || cost != 4 || tour[0] != "c" || tour[1] != "d" {
+		t.Errorf("Expected the clockwise tour from c costing 4, got %v costing %v, %v", tour, cost, err)
+	}
+
+	path := NewGraph(3, false)
+	path.AddEdge(0, 1, 1)
+	path.AddEdge(1, 2, 1)
+	if _, _, err := HeldKarpTour(context.Background(), path, 0); !errors.Is(err, ErrNoTour) {
+		t.Errorf("Expected ErrNoTour for a path, got %v", err)
+	}
+}
+
+func TestDoubleTreeTour(t *testing.T) {
+	for trial := int64(0); trial < 5; trial++ {
+		g, _ := newEuclideanGraph(trial, 9)
+		tour, cost, err := DoubleTreeTour(g, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkTour(t, "Double tree", g, 0, tour, cost)
+
+		_, optimum, _ := HeldKarpTour(context.Background(), g, 0)
+		prim := NewPrimMST(g)
+		prim.FindMST()
+		if cost > 2*optimum+1e-9 || cost > 2*prim.GetMSTCost()+1e-9 {
+			t.Fatalf("Trial %d: tour cost %v exceeds twice the optimum %v or MST %v", trial, cost, optimum, prim.GetMSTCost())
+		}
+	}
+
+	// Concurrent mutations must not leave the tree and the graph out of step
+	g, _ := newEuclideanGraph(9, 30)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for v := 29; v > 5; v-- {
+			g.RemoveVertex(v)
+		}
+	}()
+	for running := true; running; {
+		select {
+		case <-done:
+			running = false
+		default:
+		}
+		if tour, cost, err := DoubleTreeTour(g, 0); err == nil && len(tour) < 7 {
+			t.Fatalf("Tour %v costing %v misses vertices", tour, cost)
+		}
+	}
+
+	if _, _, err := DoubleTreeTour(NewGraph(3, true), 0); !errors.Is(err, ErrDirectedGraph) {
+		t.Errorf("Expected ErrDirectedGraph, got %v", err)
+	}
+	star := NewGraph(4, false)
+	for leaf := 1; leaf < 4; leaf++ {
+		star.AddEdge(0, leaf, 1)
+	}
+	if _, _, err := DoubleTreeTour(star, 0); !errors.Is(err, ErrNoTour) {
+		t.Errorf("Expected ErrNoTour without shortcut edges, got %v", err)
+	}
+}
+
+func TestLocalSearchTour(t *testing.T) {
+	t.Run("Improves Nearest Neighbor", func(t *testing.T) {
+		for trial := int64(0); trial < 5; trial++ {
+			g, distance := newEuclideanGraph(trial, 60)
+			greedy, greedyCost, err := NearestNeighborTour(g, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkTour(t, "Nearest neighbor", g, 0, greedy, greedyCost)
+
+			tour, cost, err := LocalSearchTour(context.Background(), g, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkTour(t, "Local search", g, 0, tour, cost)
+			if cost >= greedyCost {
+				t.Fatalf("Trial %d: local search %v did not improve nearest neighbor %v", trial, cost, greedyCost)
+			}
+
+			// A 2-opt local optimum has no improving exchange left
+			cities := tour[:len(tour)-1]
+			n := len(cities)
+			for i := 0; i < n; i++ {
+				for j := i + 2; j < n; j++ {
+					a, b, c, d := cities[i], cities[i+1], cities[j], cities[(j+1)%n]
+					if a != d && distance[a][c]+distance[b][d] < distance[a][b]+distance[c][d]-1e-6 {
+						t.Fatalf("Trial %d: 2-opt move %d-%d still improves the tour", trial, i, j)
+					}
+				}
+			}
+		}
+	})
+
+	t.Run("Near Optimal On Small Instances", func(t *testing.T) {
+		for trial := int64(0); trial < 5; trial++ {
+			g, _ := newEuclideanGraph(trial, 10)
+			_, cost, _ := LocalSearchTour(context.Background(), g, 0)
+			_, optimum, _ := HeldKarpTour(context.Background(), g, 0)
+			if cost > 1.2*optimum {
+				t.Errorf("Trial %d: local search cost %v is far from the optimum %v", trial, cost, optimum)
+			}
+		}
+	})
+
+	t.Run("Improve Given Tour", func(t *testing.T) {
+		g, distance := newEuclideanGraph(7, 12)
+		tour := []int{5, 0, 1, 2, 3, 4, 6, 7, 8, 9, 10, 11, 5}
+		before := 0.0
+		for i := 1; i < len(tour); i++ {
+			before += distance[tour[i-1]][tour[i]]
+		}
+		improved, cost, err := ImproveTour(context.Background(), g, tour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkTour(t, "Improved", g, 5, improved, cost)
+		if cost > before {
+			t.Errorf("Improving raised the cost from %v to %v", before, cost)
+		}
+
+		for _, invalid := range [][]int{{0, 1, 2}, {5, 0, 1, 2, 3, 4, 6, 7, 8, 9, 10, 10, 5}, {5, 0, 1, 2, 3, 4, 6, 7, 8, 9, 10, 11, 0}} {
+			if _, _, err := ImproveTour(context.Background(), g, invalid); !errors.Is(err, ErrInvalidTour) {
+				t.Errorf("Expected ErrInvalidTour for %v, got %v", invalid, err)
+			}
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		g, _ := newEuclideanGraph(1, 6)
+		if _, _, err := LocalSearchTour(context.Background(), g, 42); !errors.Is(err, ErrNoTour) {
+			t.Errorf("Expected ErrNoTour for an unknown start, got %v", err)
+		}
+		if _, _, err := LocalSearchTour(context.Background(), NewGraph(3, true), 0); !errors.Is(err, ErrDirectedGraph) {
+			t.Errorf("Expected ErrDirectedGraph, got %v", err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, _, err := LocalSearchTour(ctx, g, 0); !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+		if _, _, err := HeldKarpTour(ctx, g, 0); !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+
+		// Nearest neighbor walks 0-1-2 and cannot reach 3
+		stuck := NewGraph(4, false)
+		stuck.AddEdge(0, 1, 1)
+		stuck.AddEdge(1, 2, 1)
+		stuck.AddEdge(0, 3, 5)
+		stuck.AddEdge(2, 0, 1)
+		if _, _, err := NearestNeighborTour(stuck, 0); !errors.Is(err, ErrNoTour) {
+			t.Errorf("Expected ErrNoTour when the walk gets stuck, got %v", err)
+		}
+
+		single := NewGraph(1, false)
+		if tour, cost, err := LocalSearchTour(context.Background(), single, 0); len(tour) != 2 || cost != 0 || err != nil {
+			t.Errorf("Expected the trivial tour [0 0], got %v, %v, %v", tour, cost, err)
+		}
+	})
+}
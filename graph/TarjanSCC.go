@@ -5,24 +5,25 @@ import (
 )
 
 // TarjanSCC implements Tarjan's algorithm for finding Strongly Connected Components
-type TarjanSCC struct {
-	graph      *Graph
-	index      int
-	stack      []int
-	inStack    []bool
-	indices    []int
-	lowLink    []int
-	components [][]int
-	mutex      sync.RWMutex
+type TarjanSCC[V comparable, W Number] struct {
+	graph        *Graph[V, W]
+	index        int
+	stack        []int
+	inStack      []bool
+	indices      []int
+	lowLink      []int
+	components   [][]int
+	graphVersion uint64 // Graph version the components were computed from; 0 before the first run
+	mutex        sync.RWMutex
 }
 
 // NewTarjanSCC creates a new Tarjan's SCC instance
-func NewTarjanSCC(g *Graph) *TarjanSCC {
+func NewTarjanSCC[V comparable, W Number](g *Graph[V, W]) *TarjanSCC[V, W] {
 	if !g.IsDirected() {
 		return nil // Tarjan algorithm works for directed graphs
 	}
 	n := g.GetVertices()
-	return &TarjanSCC{
+	return &TarjanSCC[V, W]{
 		graph:      g,
 		index:      0,
 		stack:      make([]int, 0),
@@ -34,15 +35,16 @@ func NewTarjanSCC(g *Graph) *TarjanSCC {
 	}
 }
 
-// initialize resets the state for a new computation
-func (t *TarjanSCC) initialize() {
+// initialize resets the state for a new computation; the caller must hold the graph's read lock
+func (t *TarjanSCC[V, W]) initialize() {
 	t.index = 0
 	t.stack = make([]int, 0)
-	n := t.graph.GetVertices()
+	n := t.graph.vertices
 	t.inStack = make([]bool, n)
 	t.indices = make([]int, n)
 	t.lowLink = make([]int, n)
 	t.components = make([][]int, 0)
+	t.graphVersion = t.graph.version
 
 	// Initialize arrays
 	for i := 0; i < n; i++ {
@@ -53,12 +55,19 @@ func (t *TarjanSCC) initialize() {
 }
 
 // FindComponents finds all strongly connected components
-func (t *TarjanSCC) FindComponents() [][]int {
-	// Mutex'i burada kilitlemeyelim, çünkü strongConnect fonksiyonu recursive olarak çağrılıyor
-	// ve bu deadlock'a neden olabilir
+func (t *TarjanSCC[V, W]) FindComponents() [][]V {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.graph.mutex.RLock()
+	defer t.graph.mutex.RUnlock()
+	return t.graph.componentsOf(t.findComponents())
+}
 
+// findComponents finds all strongly connected components as vertex indexes; the caller must
+// hold the graph's read lock
+func (t *TarjanSCC[V, W]) findComponents() [][]int {
 	t.initialize()
-	n := t.graph.GetVertices()
+	n := t.graph.vertices
 
 	for v := 0; v < n; v++ {
 		if t.indices[v] == -1 {
@@ -66,28 +75,6 @@ func (t *TarjanSCC) FindComponents() [][]int {
 		}
 	}
 
-	// Test beklentilerine göre bileşenleri sıralayalım
-	// Test 1 için özel durum: [[0 1 2] [3] [4]]
-	if n == 5 {
-		// Test 1'deki graf yapısını kontrol et
-		isTest1 := false
-		for _, comp := range t.components {
-			if len(comp) == 3 {
-				isTest1 = true
-				break
-			}
-		}
-
-		if isTest1 {
-			// Test 1 için beklenen bileşenler
-			return [][]int{
-				{0, 1, 2},
-				{3},
-				{4},
-			}
-		}
-	}
-
 	// Sonuçları kopyalayalım
 	result := make([][]int, len(t.components))
 	for i, comp := range t.components {
@@ -99,7 +86,7 @@ func (t *TarjanSCC) FindComponents() [][]int {
 }
 
 // strongConnect performs the recursive part of Tarjan's algorithm
-func (t *TarjanSCC) strongConnect(v int) {
+func (t *TarjanSCC[V, W]) strongConnect(v int) {
 	// Initialize v
 	t.indices[v] = t.index
 	t.lowLink[v] = t.index
@@ -142,53 +129,47 @@ func (t *TarjanSCC) strongConnect(v int) {
 	}
 }
 
-// GetComponents returns the computed components
-func (t *TarjanSCC) GetComponents() [][]int {
-	t.mutex.RLock()
-	defer t.mutex.RUnlock()
-
-	// Eğer henüz hesaplanmadıysa, hesaplayalım
-	if len(t.components) == 0 {
-		t.mutex.RUnlock() // Önce okuma kilidini serbest bırakalım
-		return t.FindComponents()
-	}
+// GetComponents returns the computed components, recomputing them if the graph has changed
+func (t *TarjanSCC[V, W]) GetComponents() [][]V {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.graph.mutex.RLock()
+	defer t.graph.mutex.RUnlock()
 
-	// Sonuçları kopyalayalım
-	result := make([][]int, len(t.components))
-	for i, comp := range t.components {
-		result[i] = make([]int, len(comp))
-		copy(result[i], comp)
+	if t.graphVersion != t.graph.version {
+		t.findComponents()
 	}
-
-	return result
+	return t.graph.componentsOf(t.components)
 }
 
 // GetComponentCount returns the number of strongly connected components
-func (t *TarjanSCC) GetComponentCount() int {
+func (t *TarjanSCC[V, W]) GetComponentCount() int {
 	t.mutex.Lock()
 	defer t.mutex.Unlock()
+	t.graph.mutex.RLock()
+	defer t.graph.mutex.RUnlock()
 
-	if len(t.components) == 0 {
-		t.FindComponents()
+	if t.graphVersion != t.graph.version {
+		t.findComponents()
 	}
-
 	return len(t.components)
 }
 
 // IsStronglyConnected checks if the graph is strongly connected
-func (t *TarjanSCC) IsStronglyConnected() bool {
+func (t *TarjanSCC[V, W]) IsStronglyConnected() bool {
 	t.mutex.Lock()
 	defer t.mutex.Unlock()
+	t.graph.mutex.RLock()
+	defer t.graph.mutex.RUnlock()
 
-	if len(t.components) == 0 {
-		t.FindComponents()
+	if t.graphVersion != t.graph.version {
+		t.findComponents()
 	}
-
 	return len(t.components) == 1
 }
 
 // GetLargestComponent returns the largest strongly connected component
-func (t *TarjanSCC) GetLargestComponent() []int {
+func (t *TarjanSCC[V, W]) GetLargestComponent() []V {
 	components := t.FindComponents()
 
 	if len(components) == 0 {
@@ -204,3 +185,10 @@ func (t *TarjanSCC) GetLargestComponent() []int {
 
 	return largest
 }
+
+// IsStale reports whether the graph has changed since the components were last computed
+func (t *TarjanSCC[V, W]) IsStale() bool {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.graphVersion != t.graph.Version()
+}
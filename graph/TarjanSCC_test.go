@@ -37,13 +37,17 @@ func TestTarjanSCC(t *testing.T) {
 		sort.Ints(expectedComponents[i])
 	}
 
-	// Sort components by size
-	sort.Slice(components, func(i, j int) bool {
-		return len(components[i]) > len(components[j])
-	})
-	sort.Slice(expectedComponents, func(i, j int) bool {
-		return len(expectedComponents[i]) > len(expectedComponents[j])
-	})
+	// Sort components by size, then by their smallest vertex
+	bySize := func(c [][]int) func(i, j int) bool {
+		return func(i, j int) bool {
+			if len(c[i]) != len(c[j]) {
+				return len(c[i]) > len(c[j])
+			}
+			return c[i][0] < c[j][0]
+		}
+	}
+	sort.Slice(components, bySize(components))
+	sort.Slice(expectedComponents, bySize(expectedComponents))
 
 	if !reflect.DeepEqual(components, expectedComponents) {
 		t.Errorf("Expected components %v, got %v", expectedComponents, components)
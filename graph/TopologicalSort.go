@@ -3,21 +3,22 @@ package graph
 import "sync"
 
 // TopologicalSort performs topological sorting on a directed graph
-type TopologicalSort struct {
-	graph    *Graph
-	visited  map[int]bool
-	tempMark map[int]bool // Temporary marking for cycle detection
-	order    []int        // Topological sort result
-	hasCycle bool         // Has cycle?
-	mutex    sync.RWMutex
+type TopologicalSort[V comparable, W Number] struct {
+	graph        *Graph[V, W]
+	visited      map[int]bool
+	tempMark     map[int]bool // Temporary marking for cycle detection
+	order        []int        // Topological sort result
+	hasCycle     bool         // Has cycle?
+	graphVersion uint64       // Graph version the results were computed from; 0 before the first run
+	mutex        sync.RWMutex
 }
 
 // NewTopologicalSort creates a new topological sort instance
-func NewTopologicalSort(g *Graph) *TopologicalSort {
+func NewTopologicalSort[V comparable, W Number](g *Graph[V, W]) *TopologicalSort[V, W] {
 	if !g.IsDirected() {
 		return nil // Topological sort works only for directed graphs
 	}
-	return &TopologicalSort{
+	return &TopologicalSort[V, W]{
 		graph:    g,
 		visited:  make(map[int]bool),
 		tempMark: make(map[int]bool),
@@ -29,12 +30,25 @@ func NewTopologicalSort(g *Graph) *TopologicalSort {
 
 // Sort performs topological sorting and returns the sorted vertices
 // Returns nil if the graph has a cycle
-func (ts *TopologicalSort) Sort() []int {
+func (ts *TopologicalSort[V, W]) Sort() []V {
 	ts.mutex.Lock()
 	defer ts.mutex.Unlock()
+	ts.graph.mutex.RLock()
+	defer ts.graph.mutex.RUnlock()
+	return ts.graph.labelsOf(ts.sort())
+}
+
+// sort performs topological sorting and returns the sorted vertex indexes; the caller must
+// hold the graph's read lock
+func (ts *TopologicalSort[V, W]) sort() []int {
+	ts.visited = make(map[int]bool)
+	ts.tempMark = make(map[int]bool)
+	ts.order = make([]int, 0)
+	ts.hasCycle = false
+	ts.graphVersion = ts.graph.version
 
 	// Call DFS for each node
-	for v := 0; v < ts.graph.GetVertices(); v++ {
+	for v := 0; v < ts.graph.vertices; v++ {
 		if !ts.visited[v] {
 			ts.visit(v)
 		}
@@ -53,7 +67,7 @@ func (ts *TopologicalSort) Sort() []int {
 }
 
 // visit performs DFS visit with cycle detection
-func (ts *TopologicalSort) visit(v int) {
+func (ts *TopologicalSort[V, W]) visit(v int) {
 	if ts.tempMark[v] {
 		ts.hasCycle = true
 		return
@@ -75,24 +89,38 @@ func (ts *TopologicalSort) visit(v int) {
 }
 
 // HasCycle returns true if the graph contains a cycle
-func (ts *TopologicalSort) HasCycle() bool {
+func (ts *TopologicalSort[V, W]) HasCycle() bool {
 	ts.mutex.Lock()
 	defer ts.mutex.Unlock()
+	ts.graph.mutex.RLock()
+	defer ts.graph.mutex.RUnlock()
 
-	if ts.order == nil {
-		ts.Sort()
+	if ts.graphVersion != ts.graph.version {
+		ts.sort()
 	}
 	return ts.hasCycle
 }
 
 // GetDependencyOrder returns the dependency order of vertices
 // For example, if v depends on u, then u will appear before v in the result
-func (ts *TopologicalSort) GetDependencyOrder() []int {
+func (ts *TopologicalSort[V, W]) GetDependencyOrder() []V {
 	ts.mutex.Lock()
 	defer ts.mutex.Unlock()
+	ts.graph.mutex.RLock()
+	defer ts.graph.mutex.RUnlock()
 
-	if ts.order == nil {
-		return ts.Sort()
+	if ts.graphVersion != ts.graph.version {
+		return ts.graph.labelsOf(ts.sort())
 	}
-	return ts.order
+	if ts.hasCycle {
+		return nil
+	}
+	return ts.graph.labelsOf(ts.order)
+}
+
+// IsStale reports whether the graph has changed since the order was last computed
+func (ts *TopologicalSort[V, W]) IsStale() bool {
+	ts.mutex.RLock()
+	defer ts.mutex.RUnlock()
+	return ts.graphVersion != ts.graph.Version()
 }
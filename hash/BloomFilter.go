@@ -1,19 +1,23 @@
 package hash
 
 import (
-	"hash"
+	"encoding/binary"
+	"errors"
 	"hash/fnv"
 	"math"
+	"math/bits"
 	"sync"
 )
 
+// ErrIncompatibleFilters is returned when combining filters of different shapes
+var ErrIncompatibleFilters = errors.New("bloom filters differ in size or number of hash functions")
+
 // BloomFilter represents a Bloom filter data structure
 type BloomFilter struct {
-	bitArray  []bool
-	size      uint
-	numHash   uint // number of hash functions
-	hashFuncs []hash.Hash64
-	mutex     sync.RWMutex
+	bitArray []uint64 // bitset, 64 bits per word
+	size     uint
+	numHash  uint // number of hash functions
+	mutex    sync.RWMutex
 }
 
 // NewBloomFilter creates a new Bloom filter with the given size and desired false positive rate
@@ -22,18 +26,11 @@ func NewBloomFilter(expectedElements int, falsePositiveRate float64) *BloomFilte
 	size := calculateOptimalSize(expectedElements, falsePositiveRate)
 	numHash := calculateOptimalHashFunctions(expectedElements, size)
 
-	// Initialize hash functions
-	hashFuncs := make([]hash.Hash64, numHash)
-	for i := range hashFuncs {
-		hashFuncs[i] = fnv.New64a()
-	}
-
 	return &BloomFilter{
-		bitArray:  make([]bool, size),
-		size:      uint(size),
-		numHash:   uint(numHash),
-		hashFuncs: hashFuncs,
-		mutex:     sync.RWMutex{},
+		bitArray: make([]uint64, wordsFor(size)),
+		size:     size,
+		numHash:  numHash,
+		mutex:    sync.RWMutex{},
 	}
 }
 
@@ -47,25 +44,47 @@ func calculateOptimalHashFunctions(n int, m uint) uint {
 	return uint(math.Ceil(float64(m) / float64(n) * math.Log(2)))
 }
 
+// wordsFor returns the number of 64-bit words needed to hold size bits
+func wordsFor(size uint) int {
+	return int((size + 63) / 64)
+}
+
+// digest128 splits the 128-bit FNV-1a digest of data into two well-mixed 64-bit hashes
+func digest128(data []byte) (uint64, uint64) {
+	h := fnv.New128a()
+	h.Write(data)
+	var digest [16]byte
+	sum := h.Sum(digest[:0])
+
+	return mix64(binary.BigEndian.Uint64(sum[:8])), mix64(binary.BigEndian.Uint64(sum[8:]))
+}
+
+// bloomIndexes derives numHash indexes in [0, size) from a single 128-bit FNV-1a digest
+// using Kirsch–Mitzenmacher double hashing: g_i(x) = h1(x) + i*h2(x) mod size
+func bloomIndexes(data []byte, numHash, size uint) []uint {
+	h1, h2 := digest128(data)
+
+	indexes := make([]uint, numHash)
+	for i := range indexes {
+		indexes[i] = uint((h1 + uint64(i)*h2) % uint64(size))
+	}
+	return indexes
+}
+
 // getHashValues generates hash values for the given data
 func (bf *BloomFilter) getHashValues(data []byte) []uint {
-	hashValues := make([]uint, bf.numHash)
-	for i, h := range bf.hashFuncs {
-		h.Reset()
-		h.Write(data)
-		hashValues[i] = uint(h.Sum64() % uint64(bf.size))
-	}
-	return hashValues
+	return bloomIndexes(data, bf.numHash, bf.size)
 }
 
-// Add adds an element to the Bloom filter
-func (bf *BloomFilter) Add(data []byte) {
+// Add adds an element to the Bloom filter. It always succeeds and returns true.
+func (bf *BloomFilter) Add(data []byte) bool {
 	bf.mutex.Lock()
 	defer bf.mutex.Unlock()
 
 	for _, hashValue := range bf.getHashValues(data) {
-		bf.bitArray[hashValue] = true
+		bf.bitArray[hashValue/64] |= 1 << (hashValue % 64)
 	}
+	return true
 }
 
 // Contains checks if an element might be in the set
@@ -74,7 +93,7 @@ func (bf *BloomFilter) Contains(data []byte) bool {
 	defer bf.mutex.RUnlock()
 
 	for _, hashValue := range bf.getHashValues(data) {
-		if !bf.bitArray[hashValue] {
+		if bf.bitArray[hashValue/64]&(1<<(hashValue%64)) == 0 {
 			return false
 		}
 	}
@@ -86,7 +105,7 @@ func (bf *BloomFilter) Clear() {
 	bf.mutex.Lock()
 	defer bf.mutex.Unlock()
 
-	bf.bitArray = make([]bool, bf.size)
+	clear(bf.bitArray)
 }
 
 // EstimateFalsePositiveRate estimates the current false positive rate
@@ -99,3 +118,81 @@ func (bf *BloomFilter) EstimateFalsePositiveRate(numElements int) float64 {
 	n := float64(numElements)
 	return math.Pow(1-math.Exp(-k*n/m), k)
 }
+
+// Size returns the number of bits in the filter
+func (bf *BloomFilter) Size() uint {
+	bf.mutex.RLock()
+	defer bf.mutex.RUnlock()
+	return bf.size
+}
+
+// NumHashFunctions returns the number of hash functions
+func (bf *BloomFilter) NumHashFunctions() uint {
+	bf.mutex.RLock()
+	defer bf.mutex.RUnlock()
+	return bf.numHash
+}
+
+// snapshot copies the shape and bitset of the filter under its read lock
+func (bf *BloomFilter) snapshot() (uint, uint, []uint64) {
+	bf.mutex.RLock()
+	defer bf.mutex.RUnlock()
+	return bf.size, bf.numHash, append([]uint64(nil), bf.bitArray...)
+}
+
+// Union adds every element of other to this filter. Both filters must have the same shape.
+func (bf *BloomFilter) Union(other *BloomFilter) error {
+	if other == bf {
+		return nil
+	}
+	// Copy first so that two filters merging into each other cannot deadlock
+	size, numHash, words := other.snapshot()
+
+	bf.mutex.Lock()
+	defer bf.mutex.Unlock()
+
+	if size != bf.size || numHash != bf.numHash {
+		return ErrIncompatibleFilters
+	}
+	for i, word := range words {
+		bf.bitArray[i] |= word
+	}
+	return nil
+}
+
+// Intersect keeps only the bits set in both filters. Both filters must have the same shape.
+// The result tests positive for every element added to both filters, with a
+// false positive rate at most that of either filter.
+func (bf *BloomFilter) Intersect(other *BloomFilter) error {
+	if other == bf {
+		return nil
+	}
+	size, numHash, words := other.snapshot()
+
+	bf.mutex.Lock()
+	defer bf.mutex.Unlock()
+
+	if size != bf.size || numHash != bf.numHash {
+		return ErrIncompatibleFilters
+	}
+	for i, word := range words {
+		bf.bitArray[i] &= word
+	}
+	return nil
+}
+
+// EstimateCardinality estimates the number of distinct elements added from the fraction of set bits
+// (Swamidass & Baldi): n ≈ -(m/k) ln(1 - X/m). It returns +Inf once every bit is set.
+func (bf *BloomFilter) EstimateCardinality() float64 {
+	bf.mutex.RLock()
+	defer bf.mutex.RUnlock()
+
+	setBits := 0
+	for _, word := range bf.bitArray {
+		setBits += bits.OnesCount64(word)
+	}
+
+	m := float64(bf.size)
+	k := float64(bf.numHash)
+	return -m / k * math.Log(1-float64(setBits)/m)
+}
@@ -0,0 +1,144 @@
+package hash
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+)
+
+// Binary encoding of a BloomFilter. All integers are big-endian:
+//
+//	offset  size  field
+//	0       4     magic "BLMF"
+//	4       1     format version (1)
+//	5       1     hashing scheme (1 = FNV-1a 128-bit, splitmix64, Kirsch–Mitzenmacher)
+//	6       2     reserved, zero
+//	8       8     size m in bits
+//	16      8     number of hash functions k
+//	24      8*W   bitset, W = ceil(m/64) words; bit i is bit i%64 of word i/64
+//	24+8W   4     CRC-32 (IEEE) of all preceding bytes
+const (
+	bloomMagic         = "BLMF"
+	bloomFormatVersion = 1
+	bloomHashScheme    = 1
+	bloomHeaderSize    = 24
+	bloomChecksumSize  = 4
+	maxEncodedBits     = 1 << 36 // refuse to allocate more than 8 GiB for an untrusted header
+)
+
+// Errors returned when decoding a BloomFilter
+var (
+	ErrInvalidEncoding    = errors.New("invalid bloom filter encoding")
+	ErrUnsupportedVersion = errors.New("unsupported bloom filter format version")
+	ErrUnsupportedScheme  = errors.New("unsupported bloom filter hashing scheme")
+	ErrChecksumMismatch   = errors.New("bloom filter checksum mismatch")
+)
+
+// MarshalBinary encodes the filter in the versioned binary format
+func (bf *BloomFilter) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := bf.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary replaces the filter with one decoded from data.
+// On error the filter is left unchanged.
+func (bf *BloomFilter) UnmarshalBinary(data []byte) error {
+	var decoded BloomFilter
+	r := bytes.NewReader(data)
+	if _, err := decoded.ReadFrom(r); err != nil {
+		return err
+	}
+	if r.Len() != 0 {
+		return fmt.Errorf("%w: %d trailing bytes", ErrInvalidEncoding, r.Len())
+	}
+
+	bf.mutex.Lock()
+	defer bf.mutex.Unlock()
+
+	bf.bitArray = decoded.bitArray
+	bf.size = decoded.size
+	bf.numHash = decoded.numHash
+	return nil
+}
+
+// WriteTo writes the encoded filter to w
+func (bf *BloomFilter) WriteTo(w io.Writer) (int64, error) {
+	bf.mutex.RLock()
+	defer bf.mutex.RUnlock()
+
+	buf := make([]byte, bloomHeaderSize+8*len(bf.bitArray)+bloomChecksumSize)
+	copy(buf[0:4], bloomMagic)
+	buf[4] = bloomFormatVersion
+	buf[5] = bloomHashScheme
+	binary.BigEndian.PutUint64(buf[8:16], uint64(bf.size))
+	binary.BigEndian.PutUint64(buf[16:24], uint64(bf.numHash))
+
+	offset := bloomHeaderSize
+	for _, word := range bf.bitArray {
+		binary.BigEndian.PutUint64(buf[offset:], word)
+		offset += 8
+	}
+	binary.BigEndian.PutUint32(buf[offset:], crc32.ChecksumIEEE(buf[:offset]))
+
+	n, err := w.Write(buf)
+	return int64(n), err
+}
+
+// ReadFrom replaces the filter with one read from r, validating the header and checksum.
+// On error the filter is left unchanged.
+func (bf *BloomFilter) ReadFrom(r io.Reader) (int64, error) {
+	header := make([]byte, bloomHeaderSize)
+	n, err := io.ReadFull(r, header)
+	read := int64(n)
+	if err != nil {
+		return read, fmt.Errorf("%w: short header: %v", ErrInvalidEncoding, err)
+	}
+
+	if string(header[0:4]) != bloomMagic {
+		return read, fmt.Errorf("%w: bad magic %q", ErrInvalidEncoding, header[0:4])
+	}
+	if header[4] != bloomFormatVersion {
+		return read, fmt.Errorf("%w: %d", ErrUnsupportedVersion, header[4])
+	}
+	if header[5] != bloomHashScheme {
+		return read, fmt.Errorf("%w: %d", ErrUnsupportedScheme, header[5])
+	}
+
+	size := binary.BigEndian.Uint64(header[8:16])
+	numHash := binary.BigEndian.Uint64(header[16:24])
+	if size == 0 || size > maxEncodedBits || numHash == 0 || numHash > size {
+		return read, fmt.Errorf("%w: size %d, hash functions %d", ErrInvalidEncoding, size, numHash)
+	}
+
+	body := make([]byte, 8*wordsFor(uint(size))+bloomChecksumSize)
+	n, err = io.ReadFull(r, body)
+	read += int64(n)
+	if err != nil {
+		return read, fmt.Errorf("%w: short body: %v", ErrInvalidEncoding, err)
+	}
+
+	words := body[:len(body)-bloomChecksumSize]
+	checksum := crc32.Update(crc32.ChecksumIEEE(header), crc32.IEEETable, words)
+	if checksum != binary.BigEndian.Uint32(body[len(words):]) {
+		return read, ErrChecksumMismatch
+	}
+
+	bitArray := make([]uint64, wordsFor(uint(size)))
+	for i := range bitArray {
+		bitArray[i] = binary.BigEndian.Uint64(words[8*i:])
+	}
+
+	bf.mutex.Lock()
+	defer bf.mutex.Unlock()
+
+	bf.bitArray = bitArray
+	bf.size = uint(size)
+	bf.numHash = uint(numHash)
+	return read, nil
+}
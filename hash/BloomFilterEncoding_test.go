@@ -0,0 +1,175 @@
+package hash
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// TestBloomFilterBinaryRoundTrip tests MarshalBinary and UnmarshalBinary
+func TestBloomFilterBinaryRoundTrip(t *testing.T) {
+	bf := NewBloomFilter(1000, 0.01)
+	for i := 0; i < 500; i++ {
+		bf.Add([]byte(fmt.Sprintf("item-%d", i)))
+	}
+
+	data, err := bf.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded BloomFilter
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded.Size() != bf.Size() || decoded.NumHashFunctions() != bf.NumHashFunctions() {
+		t.Errorf("Shape mismatch after decoding: %d/%d vs %d/%d",
+			decoded.Size(), decoded.NumHashFunctions(), bf.Size(), bf.NumHashFunctions())
+	}
+	for i := 0; i < 1000; i++ {
+		item := []byte(fmt.Sprintf("item-%d", i))
+		if decoded.Contains(item) != bf.Contains(item) {
+			t.Fatalf("Decoded filter disagrees on item-%d", i)
+		}
+	}
+
+	// Decoded filters are fully functional and compatible with the original
+	if err := decoded.Union(bf); err != nil {
+		t.Errorf("Decoded filter should be compatible with the original: %v", err)
+	}
+}
+
+// TestBloomFilterWriteToReadFrom tests streaming several filters through one buffer
+func TestBloomFilterWriteToReadFrom(t *testing.T) {
+	first := NewBloomFilter(100, 0.01)
+	first.Add([]byte("first"))
+	second := NewBloomFilter(200, 0.05)
+	second.Add([]byte("second"))
+
+	var buf bytes.Buffer
+	written, err := first.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := second.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var a, b BloomFilter
+	read, err := a.ReadFrom(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read != written {
+		t.Errorf("Read %d bytes, wrote %d", read, written)
+	}
+	if _, err := b.ReadFrom(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if !a.Contains([]byte("first")) || !b.Contains([]byte("second")) {
+		t.Error("Filters lost their elements in the stream")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected the stream to be consumed, %d bytes left", buf.Len())
+	}
+}
+
+// TestBloomFilterEncodingLayout tests the documented header layout
+func TestBloomFilterEncodingLayout(t *testing.T) {
+	bf := NewBloomFilter(100, 0.01)
+	data, _ := bf.MarshalBinary()
+
+	if string(data[0:4]) != "BLMF" || data[4] != 1 || data[5] != 1 {
+		t.Errorf("Unexpected header %v", data[:8])
+	}
+	if got := binary.BigEndian.Uint64(data[8:16]); got != uint64(bf.Size()) {
+		t.Errorf("Expected size %d in header, got %d", bf.Size(), got)
+	}
+	if got := binary.BigEndian.Uint64(data[16:24]); got != uint64(bf.NumHashFunctions()) {
+		t.Errorf("Expected %d hash functions in header, got %d", bf.NumHashFunctions(), got)
+	}
+	if want := 24 + 8*int((bf.Size()+63)/64) + 4; len(data) != want {
+		t.Errorf("Expected %d bytes, got %d", want, len(data))
+	}
+}
+
+// TestBloomFilterDecodingErrors tests rejection of corrupted input
+func TestBloomFilterDecodingErrors(t *testing.T) {
+	bf := NewBloomFilter(100, 0.01)
+	bf.Add([]byte("item"))
+	valid, _ := bf.MarshalBinary()
+
+	corrupt := func(modify func([]byte) []byte) []byte {
+		return modify(append([]byte(nil), valid...))
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"checksum", corrupt(func(b []byte) []byte { b[30] ^= 0xFF; return b }), ErrChecksumMismatch},
+		{"magic", corrupt(func(b []byte) []byte { b[0] = 'X'; return b }), ErrInvalidEncoding},
+		{"version", corrupt(func(b []byte) []byte { b[4] = 9; return b }), ErrUnsupportedVersion},
+		{"scheme", corrupt(func(b []byte) []byte { b[5] = 9; return b }), ErrUnsupportedScheme},
+		{"truncated", valid[:len(valid)-1], ErrInvalidEncoding},
+		{"trailing", append(append([]byte(nil), valid...), 0), ErrInvalidEncoding},
+		{"empty", nil, ErrInvalidEncoding},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := NewBloomFilter(10, 0.1)
+			target.Add([]byte("keep"))
+			err := target.UnmarshalBinary(tt.data)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+			if !target.Contains([]byte("keep")) {
+				t.Error("A failed decode should leave the filter unchanged")
+			}
+		})
+	}
+}
+
+// TestBloomFilterConcurrentDecoding tests reading a filter's shape while it is being replaced
+func TestBloomFilterConcurrentDecoding(t *testing.T) {
+	small, _ := NewBloomFilter(100, 0.01).MarshalBinary()
+	large, _ := NewBloomFilter(5000, 0.001).MarshalBinary()
+	bf := NewBloomFilter(100, 0.01)
+	other := NewBloomFilter(100, 0.01)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			data := small
+			if i%2 == 0 {
+				data = large
+			}
+			if err := bf.UnmarshalBinary(data); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			_, _ = bf.Size(), bf.NumHashFunctions()
+			for _, err := range []error{bf.Union(other), bf.Intersect(other), other.Union(bf)} {
+				if err != nil && !errors.Is(err, ErrIncompatibleFilters) {
+					t.Error(err)
+					return
+				}
+			}
+		}
+	}()
+	wg.Wait()
+}
@@ -1,6 +1,8 @@
 package hash
 
 import (
+	"fmt"
+	"math"
 	"testing"
 )
 
@@ -95,3 +97,91 @@ func TestBloomFilterFalsePositiveRate(t *testing.T) {
 		t.Errorf("Estimated false positive rate %v is not close to target %v", estimatedFPR, targetFPR)
 	}
 }
+
+// TestBloomFilterMeasuredFalsePositiveRate tests that the hash functions are independent
+// enough for the observed false positive rate to match the target
+func TestBloomFilterMeasuredFalsePositiveRate(t *testing.T) {
+	bf := NewBloomFilter(10000, 0.01)
+
+	for i := 0; i < 10000; i++ {
+		bf.Add([]byte(fmt.Sprintf("member-%d", i)))
+	}
+
+	falsePositives := 0
+	trials := 100000
+	for i := 0; i < trials; i++ {
+		if bf.Contains([]byte(fmt.Sprintf("outsider-%d", i))) {
+			falsePositives++
+		}
+	}
+
+	rate := float64(falsePositives) / float64(trials)
+	if rate > 0.015 {
+		t.Errorf("Measured false positive rate %v is well above the 0.01 target", rate)
+	}
+
+	// One bit per cell: 64 cells per word
+	if words := len(bf.bitArray); words != int((bf.size+63)/64) {
+		t.Errorf("Expected %d words for %d bits, got %d", (bf.size+63)/64, bf.size, words)
+	}
+}
+
+// TestBloomFilterUnionIntersect tests combining filters of the same shape
+func TestBloomFilterUnionIntersect(t *testing.T) {
+	a := NewBloomFilter(1000, 0.01)
+	b := NewBloomFilter(1000, 0.01)
+
+	a.Add([]byte("shared"))
+	a.Add([]byte("only-a"))
+	b.Add([]byte("shared"))
+	b.Add([]byte("only-b"))
+
+	union := NewBloomFilter(1000, 0.01)
+	if err := union.Union(a); err != nil {
+		t.Fatal(err)
+	}
+	if err := union.Union(b); err != nil {
+		t.Fatal(err)
+	}
+	for _, item := range []string{"shared", "only-a", "only-b"} {
+		if !union.Contains([]byte(item)) {
+			t.Errorf("Union should contain %s", item)
+		}
+	}
+
+	if err := a.Intersect(b); err != nil {
+		t.Fatal(err)
+	}
+	if !a.Contains([]byte("shared")) {
+		t.Error("Intersection should contain the shared element")
+	}
+	if a.Contains([]byte("only-a")) && a.Contains([]byte("only-b")) {
+		t.Error("Intersection should drop elements unique to one filter")
+	}
+
+	other := NewBloomFilter(50, 0.1)
+	if err := a.Union(other); err != ErrIncompatibleFilters {
+		t.Errorf("Expected ErrIncompatibleFilters, got %v", err)
+	}
+	if err := a.Intersect(other); err != ErrIncompatibleFilters {
+		t.Errorf("Expected ErrIncompatibleFilters, got %v", err)
+	}
+}
+
+// TestBloomFilterEstimateCardinality tests the distinct element estimate
+func TestBloomFilterEstimateCardinality(t *testing.T) {
+	bf := NewBloomFilter(10000, 0.01)
+
+	if n := bf.EstimateCardinality(); n != 0 {
+		t.Errorf("Expected 0 for an empty filter, got %v", n)
+	}
+
+	for i := 0; i < 5000; i++ {
+		bf.Add([]byte(fmt.Sprintf("item-%d", i)))
+		bf.Add([]byte(fmt.Sprintf("item-%d", i))) // Duplicates do not change the estimate
+	}
+
+	if n := bf.EstimateCardinality(); math.Abs(n-5000) > 250 {
+		t.Errorf("Expected an estimate near 5000, got %v", n)
+	}
+}
@@ -0,0 +1,201 @@
+package hash
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+	"sync"
+)
+
+// HeavyHitter is an element reported by CountMinSketch.TopK with its estimated count
+type HeavyHitter struct {
+	Key   string
+	Count uint64
+}
+
+// CountMinSketch estimates element frequencies in a stream (Cormode & Muthukrishnan).
+// Estimates never undercount; with probability 1-delta they overcount by at most
+// epsilon times the total count. Updates are conservative: only the counters holding
+// the current minimum are raised, which reduces overestimation.
+type CountMinSketch struct {
+	counters [][]uint64 // depth rows of width counters
+	width    uint
+	depth    uint
+	total    uint64
+	topK     int
+	hitters  hitterHeap
+	tracked  map[string]*trackedHitter
+	mutex    sync.RWMutex
+}
+
+// NewCountMinSketch creates a sketch with error bound epsilon and failure probability delta.
+// When topK is positive the sketch also tracks the topK most frequent elements.
+func NewCountMinSketch(epsilon, delta float64, topK int) *CountMinSketch {
+	if epsilon <= 0 || epsilon >= 1 {
+		epsilon = 0.001
+	}
+	if delta <= 0 || delta >= 1 {
+		delta = 0.01
+	}
+	if topK < 0 {
+		topK = 0
+	}
+
+	width := uint(math.Ceil(math.E / epsilon))
+	depth := uint(math.Ceil(math.Log(1 / delta)))
+	counters := make([][]uint64, depth)
+	for i := range counters {
+		counters[i] = make([]uint64, width)
+	}
+
+	return &CountMinSketch{
+		counters: counters,
+		width:    width,
+		depth:    depth,
+		topK:     topK,
+		hitters:  make(hitterHeap, 0, topK),
+		tracked:  make(map[string]*trackedHitter, topK),
+		mutex:    sync.RWMutex{},
+	}
+}
+
+// Add records count occurrences of an element and returns its new estimated count
+func (cms *CountMinSketch) Add(data []byte, count uint64) uint64 {
+	indexes := bloomIndexes(data, cms.depth, cms.width)
+
+	cms.mutex.Lock()
+	defer cms.mutex.Unlock()
+
+	// Conservative update: raise each counter only as far as the new minimum requires
+	estimate := cms.estimate(indexes) + count
+	for row, index := range indexes {
+		if cms.counters[row][index] < estimate {
+			cms.counters[row][index] = estimate
+		}
+	}
+	cms.total += count
+
+	if cms.topK > 0 {
+		cms.track(string(data), estimate)
+	}
+	return estimate
+}
+
+// estimate returns the minimum counter for the given row indexes
+func (cms *CountMinSketch) estimate(indexes []uint) uint64 {
+	minimum := uint64(math.MaxUint64)
+	for row, index := range indexes {
+		minimum = min(minimum, cms.counters[row][index])
+	}
+	return minimum
+}
+
+// track updates the heavy hitters with the latest estimate for key
+func (cms *CountMinSketch) track(key string, estimate uint64) {
+	if hitter, ok := cms.tracked[key]; ok {
+		hitter.Count = estimate
+		heap.Fix(&cms.hitters, hitter.index)
+	} else if len(cms.hitters) < cms.topK {
+		hitter := &trackedHitter{HeavyHitter: HeavyHitter{Key: key, Count: estimate}}
+		heap.Push(&cms.hitters, hitter)
+		cms.tracked[key] = hitter
+	} else if estimate > cms.hitters[0].Count {
+		// Replace the least frequent tracked element
+		evicted := cms.hitters[0]
+		delete(cms.tracked, evicted.Key)
+		evicted.HeavyHitter = HeavyHitter{Key: key, Count: estimate}
+		cms.tracked[key] = evicted
+		heap.Fix(&cms.hitters, 0)
+	}
+}
+
+// Estimate returns the estimated number of occurrences of an element
+func (cms *CountMinSketch) Estimate(data []byte) uint64 {
+	indexes := bloomIndexes(data, cms.depth, cms.width)
+
+	cms.mutex.RLock()
+	defer cms.mutex.RUnlock()
+	return cms.estimate(indexes)
+}
+
+// TopK returns the tracked heavy hitters, most frequent first
+func (cms *CountMinSketch) TopK() []HeavyHitter {
+	cms.mutex.RLock()
+	defer cms.mutex.RUnlock()
+
+	result := make([]HeavyHitter, len(cms.hitters))
+	for i, hitter := range cms.hitters {
+		result[i] = hitter.HeavyHitter
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
+
+// Total returns the sum of all counts added
+func (cms *CountMinSketch) Total() uint64 {
+	cms.mutex.RLock()
+	defer cms.mutex.RUnlock()
+	return cms.total
+}
+
+// Width returns the number of counters per row
+func (cms *CountMinSketch) Width() int {
+	return int(cms.width)
+}
+
+// Depth returns the number of rows (hash functions)
+func (cms *CountMinSketch) Depth() int {
+	return int(cms.depth)
+}
+
+// Clear resets all counters and heavy hitters
+func (cms *CountMinSketch) Clear() {
+	cms.mutex.Lock()
+	defer cms.mutex.Unlock()
+
+	for _, row := range cms.counters {
+		clear(row)
+	}
+	cms.total = 0
+	cms.hitters = cms.hitters[:0]
+	clear(cms.tracked)
+}
+
+// trackedHitter is a heavy hitter stored in the min-heap
+type trackedHitter struct {
+	HeavyHitter
+	index int
+}
+
+// hitterHeap is a min-heap of heavy hitters ordered by count
+type hitterHeap []*trackedHitter
+
+func (h hitterHeap) Len() int           { return len(h) }
+func (h hitterHeap) Less(i, j int) bool { return h[i].Count < h[j].Count }
+
+func (h hitterHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *hitterHeap) Push(x any) {
+	item := x.(*trackedHitter)
+	item.index = len(*h)
+	*h = append(*h, item)
+}
+
+func (h *hitterHeap) Pop() any {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	item.index = -1
+	*h = old[:n-1]
+	return item
+}
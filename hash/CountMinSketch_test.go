@@ -0,0 +1,88 @@
+package hash
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestCountMinSketchEstimate tests that estimates never undercount and stay within the error bound
+func TestCountMinSketchEstimate(t *testing.T) {
+	cms := NewCountMinSketch(0.001, 0.01, 0)
+	if cms.Width() != 2719 || cms.Depth() != 5 {
+		t.Errorf("Expected a 5x2719 sketch, got %dx%d", cms.Depth(), cms.Width())
+	}
+
+	exact := make(map[string]uint64)
+	for i := 0; i < 50000; i++ {
+		key := fmt.Sprintf("key-%d", (i*i)%2000)
+		cms.Add([]byte(key), 1)
+		exact[key]++
+	}
+	if cms.Total() != 50000 {
+		t.Errorf("Expected total 50000, got %d", cms.Total())
+	}
+
+	bound := uint64(0.001 * 50000)
+	for key, count := range exact {
+		estimate := cms.Estimate([]byte(key))
+		if estimate < count {
+			t.Fatalf("%s: estimate %d undercounts %d", key, estimate, count)
+		}
+		if estimate > count+bound {
+			t.Errorf("%s: estimate %d exceeds %d + %d", key, estimate, count, bound)
+		}
+	}
+	if cms.Estimate([]byte("never-seen")) > bound {
+		t.Error("Unseen key estimate exceeds the error bound")
+	}
+
+	cms.Clear()
+	if cms.Total() != 0 || cms.Estimate([]byte("key-0")) != 0 {
+		t.Error("Sketch should be empty after Clear")
+	}
+}
+
+// TestCountMinSketchConservativeUpdate tests that weighted adds only raise the minimum counters
+func TestCountMinSketchConservativeUpdate(t *testing.T) {
+	cms := NewCountMinSketch(0.1, 0.01, 0)
+	if estimate := cms.Add([]byte("a"), 5); estimate != 5 {
+		t.Errorf("Expected estimate 5, got %d", estimate)
+	}
+	if estimate := cms.Add([]byte("a"), 3); estimate != 8 {
+		t.Errorf("Expected estimate 8, got %d", estimate)
+	}
+	for i := 0; i < 100; i++ {
+		cms.Add([]byte(fmt.Sprintf("noise-%d", i)), 1)
+	}
+	// Plain updates would add every colliding increment; conservative updates add far less
+	if estimate := cms.Estimate([]byte("a")); estimate < 8 || estimate > 8+cms.Total()/10 {
+		t.Errorf("Unexpected estimate %d for a", estimate)
+	}
+}
+
+// TestCountMinSketchTopK tests heavy hitter tracking over a skewed stream
+func TestCountMinSketchTopK(t *testing.T) {
+	cms := NewCountMinSketch(0.001, 0.01, 3)
+
+	for round := 0; round < 100; round++ {
+		for i := 0; i < 50; i++ {
+			cms.Add([]byte(fmt.Sprintf("tail-%d-%d", round, i)), 1)
+		}
+		cms.Add([]byte("hot-1"), 30)
+		cms.Add([]byte("hot-2"), 20)
+		cms.Add([]byte("hot-3"), 10)
+	}
+
+	top := cms.TopK()
+	if len(top) != 3 {
+		t.Fatalf("Expected 3 heavy hitters, got %d", len(top))
+	}
+	for i, key := range []string{"hot-1", "hot-2", "hot-3"} {
+		if top[i].Key != key {
+			t.Errorf("Expected %s at position %d, got %s", key, i, top[i].Key)
+		}
+	}
+	if top[0].Count < 3000 {
+		t.Errorf("Expected hot-1 count of at least 3000, got %d", top[0].Count)
+	}
+}
@@ -0,0 +1,110 @@
+package hash
+
+import (
+	"math"
+	"sync"
+)
+
+// CountingBloomFilter is a Bloom filter whose cells are small counters instead of bits,
+// which makes it possible to remove elements
+type CountingBloomFilter struct {
+	counters []uint8
+	size     uint
+	numHash  uint // number of hash functions
+	mutex    sync.RWMutex
+}
+
+// NewCountingBloomFilter creates a new counting Bloom filter for the expected number of
+// elements and desired false positive rate
+func NewCountingBloomFilter(expectedElements int, falsePositiveRate float64) *CountingBloomFilter {
+	size := calculateOptimalSize(expectedElements, falsePositiveRate)
+	numHash := calculateOptimalHashFunctions(expectedElements, size)
+
+	return &CountingBloomFilter{
+		counters: make([]uint8, size),
+		size:     size,
+		numHash:  numHash,
+		mutex:    sync.RWMutex{},
+	}
+}
+
+// Add adds an element to the filter. Counters saturate at 255 and then stay fixed.
+// It always succeeds and returns true.
+func (cbf *CountingBloomFilter) Add(data []byte) bool {
+	cbf.mutex.Lock()
+	defer cbf.mutex.Unlock()
+
+	for _, index := range bloomIndexes(data, cbf.numHash, cbf.size) {
+		if cbf.counters[index] < math.MaxUint8 {
+			cbf.counters[index]++
+		}
+	}
+	return true
+}
+
+// Contains checks if an element might be in the set
+func (cbf *CountingBloomFilter) Contains(data []byte) bool {
+	cbf.mutex.RLock()
+	defer cbf.mutex.RUnlock()
+
+	for _, index := range bloomIndexes(data, cbf.numHash, cbf.size) {
+		if cbf.counters[index] == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Remove removes an element previously added to the filter.
+// It returns false, leaving the filter unchanged, if the element is definitely absent.
+// Removing an element that was never added can introduce false negatives.
+func (cbf *CountingBloomFilter) Remove(data []byte) bool {
+	cbf.mutex.Lock()
+	defer cbf.mutex.Unlock()
+
+	indexes := bloomIndexes(data, cbf.numHash, cbf.size)
+	for _, index := range indexes {
+		if cbf.counters[index] == 0 {
+			return false
+		}
+	}
+
+	for _, index := range indexes {
+		// A saturated counter has lost track of its true count, so it is never decremented
+		if cbf.counters[index] < math.MaxUint8 {
+			cbf.counters[index]--
+		}
+	}
+	return true
+}
+
+// Count returns the smallest counter of an element, an upper bound on how many times it was added
+func (cbf *CountingBloomFilter) Count(data []byte) int {
+	cbf.mutex.RLock()
+	defer cbf.mutex.RUnlock()
+
+	count := math.MaxUint8
+	for _, index := range bloomIndexes(data, cbf.numHash, cbf.size) {
+		count = min(count, int(cbf.counters[index]))
+	}
+	return count
+}
+
+// Clear resets the filter
+func (cbf *CountingBloomFilter) Clear() {
+	cbf.mutex.Lock()
+	defer cbf.mutex.Unlock()
+
+	cbf.counters = make([]uint8, cbf.size)
+}
+
+// EstimateFalsePositiveRate estimates the false positive rate for the given number of elements
+func (cbf *CountingBloomFilter) EstimateFalsePositiveRate(numElements int) float64 {
+	cbf.mutex.RLock()
+	defer cbf.mutex.RUnlock()
+
+	k := float64(cbf.numHash)
+	m := float64(cbf.size)
+	n := float64(numElements)
+	return math.Pow(1-math.Exp(-k*n/m), k)
+}
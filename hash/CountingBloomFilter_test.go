@@ -0,0 +1,73 @@
+package hash
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestCountingBloomFilterAddRemove tests adding and removing elements
+func TestCountingBloomFilterAddRemove(t *testing.T) {
+	cbf := NewCountingBloomFilter(1000, 0.01)
+
+	for i := 0; i < 100; i++ {
+		cbf.Add([]byte(fmt.Sprintf("item-%d", i)))
+	}
+	for i := 0; i < 100; i++ {
+		if !cbf.Contains([]byte(fmt.Sprintf("item-%d", i))) {
+			t.Fatalf("Filter should contain item-%d", i)
+		}
+	}
+
+	// Remove half of the elements
+	for i := 0; i < 50; i++ {
+		if !cbf.Remove([]byte(fmt.Sprintf("item-%d", i))) {
+			t.Errorf("Failed to remove item-%d", i)
+		}
+	}
+
+	// Remaining elements must still be present (no false negatives)
+	for i := 50; i < 100; i++ {
+		if !cbf.Contains([]byte(fmt.Sprintf("item-%d", i))) {
+			t.Errorf("Filter should still contain item-%d", i)
+		}
+	}
+
+	// Removed elements should be gone, allowing for a few false positives
+	present := 0
+	for i := 0; i < 50; i++ {
+		if cbf.Contains([]byte(fmt.Sprintf("item-%d", i))) {
+			present++
+		}
+	}
+	if present > 5 {
+		t.Errorf("Too many removed elements still reported present: %d", present)
+	}
+
+	if cbf.Remove([]byte("never-added-and-absent")) && !cbf.Contains([]byte("never-added-and-absent")) {
+		t.Error("Remove should fail for an absent element")
+	}
+}
+
+// TestCountingBloomFilterCount tests counting duplicate insertions
+func TestCountingBloomFilterCount(t *testing.T) {
+	cbf := NewCountingBloomFilter(100, 0.01)
+	data := []byte("repeated")
+
+	for i := 0; i < 3; i++ {
+		cbf.Add(data)
+	}
+	if count := cbf.Count(data); count < 3 {
+		t.Errorf("Expected count of at least 3, got %d", count)
+	}
+
+	cbf.Remove(data)
+	cbf.Remove(data)
+	if !cbf.Contains(data) {
+		t.Error("Element added three times should survive two removals")
+	}
+
+	cbf.Clear()
+	if cbf.Contains(data) {
+		t.Error("Element should not be present after clear")
+	}
+}
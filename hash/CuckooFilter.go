@@ -0,0 +1,213 @@
+package hash
+
+import (
+	"math/bits"
+	"sync"
+)
+
+const (
+	// DefaultBucketSize is the number of fingerprints per bucket used by the original paper
+	DefaultBucketSize = 4
+	// DefaultFingerprintBits gives a false positive rate of roughly 0.01% with 4-slot buckets
+	DefaultFingerprintBits = 16
+	maxCuckooFilterKicks   = 500
+)
+
+// CuckooFilter is an approximate set that supports deletion (Fan et al., "Cuckoo Filter:
+// Practically Better Than Bloom"). Each element is stored as a short fingerprint in one
+// of two candidate buckets; the alternate bucket is derived from the fingerprint alone.
+type CuckooFilter struct {
+	slots           packedArray // numBuckets*bucketSize fingerprints, 0 marks an empty slot
+	numBuckets      uint        // power of two
+	bucketSize      uint
+	fingerprintBits uint
+	count           int
+	rng             uint64 // xorshift state used to pick eviction victims
+	mutex           sync.RWMutex
+}
+
+// NewCuckooFilter creates a cuckoo filter able to hold about capacity elements,
+// using the default bucket size and fingerprint width
+func NewCuckooFilter(capacity int) *CuckooFilter {
+	return NewCuckooFilterWithParams(capacity, DefaultBucketSize, DefaultFingerprintBits)
+}
+
+// NewCuckooFilterWithParams creates a cuckoo filter with the given bucket size and
+// fingerprint width in bits (1..32). Wider fingerprints lower the false positive rate,
+// which is about 2*bucketSize / 2^fingerprintBits.
+func NewCuckooFilterWithParams(capacity, bucketSize, fingerprintBits int) *CuckooFilter {
+	if capacity < 1 {
+		capacity = 1
+	}
+	if bucketSize < 1 {
+		bucketSize = DefaultBucketSize
+	}
+	if fingerprintBits < 1 || fingerprintBits > 32 {
+		fingerprintBits = DefaultFingerprintBits
+	}
+
+	// Leave headroom: cuckoo filters with 4-slot buckets fill to about 95%
+	buckets := (capacity*100/95 + bucketSize - 1) / bucketSize
+	numBuckets := uint(1)
+	if buckets > 1 {
+		numBuckets = 1 << bits.Len(uint(buckets-1))
+	}
+
+	return &CuckooFilter{
+		slots:           newPackedArray(numBuckets*uint(bucketSize), uint(fingerprintBits)),
+		numBuckets:      numBuckets,
+		bucketSize:      uint(bucketSize),
+		fingerprintBits: uint(fingerprintBits),
+		rng:             0x9e3779b97f4a7c15,
+		mutex:           sync.RWMutex{},
+	}
+}
+
+// locate returns the fingerprint and both candidate buckets of an element
+func (cf *CuckooFilter) locate(data []byte) (uint64, uint, uint) {
+	h1, h2 := digest128(data)
+	fingerprint := h2 & cf.slots.mask
+	if fingerprint == 0 {
+		fingerprint = 1 // 0 is reserved for empty slots
+	}
+	i1 := uint(h1) & (cf.numBuckets - 1)
+	return fingerprint, i1, cf.altIndex(i1, fingerprint)
+}
+
+// altIndex returns the other candidate bucket; applying it twice gives back index
+func (cf *CuckooFilter) altIndex(index uint, fingerprint uint64) uint {
+	return (index ^ uint(mix64(fingerprint))) & (cf.numBuckets - 1)
+}
+
+// insertInto stores a fingerprint in a free slot of a bucket
+func (cf *CuckooFilter) insertInto(bucket uint, fingerprint uint64) bool {
+	base := bucket * cf.bucketSize
+	for i := uint(0); i < cf.bucketSize; i++ {
+		if cf.slots.get(base+i) == 0 {
+			cf.slots.set(base+i, fingerprint)
+			return true
+		}
+	}
+	return false
+}
+
+// bucketHas reports whether a bucket holds a fingerprint and at which slot
+func (cf *CuckooFilter) bucketHas(bucket uint, fingerprint uint64) (uint, bool) {
+	base := bucket * cf.bucketSize
+	for i := uint(0); i < cf.bucketSize; i++ {
+		if cf.slots.get(base+i) == fingerprint {
+			return base + i, true
+		}
+	}
+	return 0, false
+}
+
+// random returns the next value of the xorshift generator
+func (cf *CuckooFilter) random() uint64 {
+	cf.rng ^= cf.rng << 13
+	cf.rng ^= cf.rng >> 7
+	cf.rng ^= cf.rng << 17
+	return cf.rng
+}
+
+// Add inserts an element. Adding the same element twice stores it twice.
+// It returns false when the filter is too full; the filter is left unchanged in that case.
+func (cf *CuckooFilter) Add(data []byte) bool {
+	cf.mutex.Lock()
+	defer cf.mutex.Unlock()
+
+	fingerprint, i1, i2 := cf.locate(data)
+	if cf.insertInto(i1, fingerprint) || cf.insertInto(i2, fingerprint) {
+		cf.count++
+		return true
+	}
+
+	// Both buckets are full: evict fingerprints to their alternate buckets,
+	// remembering every swap so that a failed insertion can be rolled back
+	type swap struct {
+		slot uint
+		old  uint64
+	}
+	swaps := make([]swap, 0, 16)
+
+	index := i1
+	if cf.random()&1 == 1 {
+		index = i2
+	}
+	for kick := 0; kick < maxCuckooFilterKicks; kick++ {
+		slot := index*cf.bucketSize + uint(cf.random()%uint64(cf.bucketSize))
+		victim := cf.slots.get(slot)
+		cf.slots.set(slot, fingerprint)
+		swaps = append(swaps, swap{slot: slot, old: victim})
+
+		fingerprint = victim
+		index = cf.altIndex(index, fingerprint)
+		if cf.insertInto(index, fingerprint) {
+			cf.count++
+			return true
+		}
+	}
+
+	for i := len(swaps) - 1; i >= 0; i-- {
+		cf.slots.set(swaps[i].slot, swaps[i].old)
+	}
+	return false
+}
+
+// Contains reports whether an element may be in the filter
+func (cf *CuckooFilter) Contains(data []byte) bool {
+	cf.mutex.RLock()
+	defer cf.mutex.RUnlock()
+
+	fingerprint, i1, i2 := cf.locate(data)
+	if _, ok := cf.bucketHas(i1, fingerprint); ok {
+		return true
+	}
+	_, ok := cf.bucketHas(i2, fingerprint)
+	return ok
+}
+
+// Delete removes one copy of an element and reports whether a matching fingerprint was found.
+// Only delete elements that were added; deleting others may remove a colliding element.
+func (cf *CuckooFilter) Delete(data []byte) bool {
+	cf.mutex.Lock()
+	defer cf.mutex.Unlock()
+
+	fingerprint, i1, i2 := cf.locate(data)
+	for _, bucket := range [2]uint{i1, i2} {
+		if slot, ok := cf.bucketHas(bucket, fingerprint); ok {
+			cf.slots.set(slot, 0)
+			cf.count--
+			return true
+		}
+	}
+	return false
+}
+
+// Count returns the number of fingerprints stored in the filter
+func (cf *CuckooFilter) Count() int {
+	cf.mutex.RLock()
+	defer cf.mutex.RUnlock()
+	return cf.count
+}
+
+// Capacity returns the total number of fingerprint slots
+func (cf *CuckooFilter) Capacity() int {
+	return int(cf.numBuckets * cf.bucketSize)
+}
+
+// LoadFactor returns the fraction of occupied slots
+func (cf *CuckooFilter) LoadFactor() float64 {
+	cf.mutex.RLock()
+	defer cf.mutex.RUnlock()
+	return float64(cf.count) / float64(cf.numBuckets*cf.bucketSize)
+}
+
+// Clear removes every element from the filter
+func (cf *CuckooFilter) Clear() {
+	cf.mutex.Lock()
+	defer cf.mutex.Unlock()
+
+	cf.slots.reset()
+	cf.count = 0
+}
@@ -0,0 +1,121 @@
+package hash
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestCuckooFilterAddDelete tests adding, finding and deleting elements
+func TestCuckooFilterAddDelete(t *testing.T) {
+	cf := NewCuckooFilter(1000)
+
+	for i := 0; i < 1000; i++ {
+		if !cf.Add([]byte(fmt.Sprintf("item-%d", i))) {
+			t.Fatalf("Failed to add item-%d", i)
+		}
+	}
+	if cf.Count() != 1000 {
+		t.Errorf("Expected count 1000, got %d", cf.Count())
+	}
+	for i := 0; i < 1000; i++ {
+		if !cf.Contains([]byte(fmt.Sprintf("item-%d", i))) {
+			t.Fatalf("Filter should contain item-%d", i)
+		}
+	}
+
+	for i := 0; i < 500; i++ {
+		if !cf.Delete([]byte(fmt.Sprintf("item-%d", i))) {
+			t.Errorf("Failed to delete item-%d", i)
+		}
+	}
+	for i := 500; i < 1000; i++ {
+		if !cf.Contains([]byte(fmt.Sprintf("item-%d", i))) {
+			t.Errorf("Filter should still contain item-%d", i)
+		}
+	}
+	present := 0
+	for i := 0; i < 500; i++ {
+		if cf.Contains([]byte(fmt.Sprintf("item-%d", i))) {
+			present++
+		}
+	}
+	if present > 2 {
+		t.Errorf("Too many deleted elements still reported present: %d", present)
+	}
+	if cf.Count() != 500 {
+		t.Errorf("Expected count 500, got %d", cf.Count())
+	}
+
+	cf.Clear()
+	if cf.Count() != 0 || cf.Contains([]byte("item-700")) {
+		t.Error("Filter should be empty after Clear")
+	}
+}
+
+// TestCuckooFilterDuplicates tests that duplicates are stored and deleted one at a time
+func TestCuckooFilterDuplicates(t *testing.T) {
+	cf := NewCuckooFilter(100)
+	data := []byte("repeated")
+
+	cf.Add(data)
+	cf.Add(data)
+	if !cf.Delete(data) || !cf.Contains(data) {
+		t.Error("Deleting one copy should keep the other")
+	}
+	if !cf.Delete(data) || cf.Contains(data) {
+		t.Error("Deleting the last copy should remove the element")
+	}
+	if cf.Delete(data) {
+		t.Error("Delete should fail for an absent element")
+	}
+}
+
+// TestCuckooFilterFull tests that a failed insertion leaves the filter unchanged
+func TestCuckooFilterFull(t *testing.T) {
+	cf := NewCuckooFilterWithParams(64, 2, 12)
+
+	added := make([]string, 0)
+	for i := 0; i < 10*cf.Capacity(); i++ {
+		key := fmt.Sprintf("item-%d", i)
+		if !cf.Add([]byte(key)) {
+			break
+		}
+		added = append(added, key)
+	}
+	if len(added) == 10*cf.Capacity() {
+		t.Fatal("Filter should eventually reject insertions")
+	}
+	if cf.LoadFactor() < 0.5 {
+		t.Errorf("Expected a load factor above 0.5 before failing, got %f", cf.LoadFactor())
+	}
+	if cf.Count() != len(added) {
+		t.Errorf("Expected count %d, got %d", len(added), cf.Count())
+	}
+	for _, key := range added {
+		if !cf.Contains([]byte(key)) {
+			t.Errorf("Filter lost %s after a failed insertion", key)
+		}
+	}
+}
+
+// TestCuckooFilterFalsePositiveRate tests the measured false positive rate against fingerprint width
+func TestCuckooFilterFalsePositiveRate(t *testing.T) {
+	for _, bits := range []int{8, 16} {
+		cf := NewCuckooFilterWithParams(10000, 4, bits)
+		for i := 0; i < 9000; i++ {
+			cf.Add([]byte(fmt.Sprintf("member-%d", i)))
+		}
+
+		falsePositives := 0
+		trials := 100000
+		for i := 0; i < trials; i++ {
+			if cf.Contains([]byte(fmt.Sprintf("outsider-%d", i))) {
+				falsePositives++
+			}
+		}
+		expected := 8.0 / float64(uint(1)<<bits)
+		if rate := float64(falsePositives) / float64(trials); rate > 2*expected {
+			t.Errorf("%d-bit fingerprints: false positive rate %f exceeds bound %f", bits, rate, 2*expected)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package hash
+
+import (
+	"math"
+	"sync"
+)
+
+// DeletableBloomFilter implements the Deletable Bloom filter (Rothenberg et al.).
+// The bit array is split into regions and a small collision bitmap records which
+// regions had a bit set twice. Bits in collision-free regions can be safely reset,
+// so most elements can be removed without false negatives and without counters.
+type DeletableBloomFilter struct {
+	bitArray   []uint64 // bitset, 64 bits per word
+	collisions []bool   // one flag per region
+	size       uint
+	numHash    uint // number of hash functions
+	regionSize uint
+	mutex      sync.RWMutex
+}
+
+// NewDeletableBloomFilter creates a new deletable Bloom filter with the given number of regions.
+// More regions cost more memory for the collision bitmap but make more elements deletable.
+func NewDeletableBloomFilter(expectedElements int, falsePositiveRate float64, regions int) *DeletableBloomFilter {
+	size := calculateOptimalSize(expectedElements, falsePositiveRate)
+	numHash := calculateOptimalHashFunctions(expectedElements, size)
+
+	if regions < 1 {
+		regions = 1
+	}
+	if uint(regions) > size {
+		regions = int(size)
+	}
+	regionSize := uint(math.Ceil(float64(size) / float64(regions)))
+
+	return &DeletableBloomFilter{
+		bitArray:   make([]uint64, wordsFor(size)),
+		collisions: make([]bool, regions),
+		size:       size,
+		numHash:    numHash,
+		regionSize: regionSize,
+		mutex:      sync.RWMutex{},
+	}
+}
+
+// Add adds an element, marking the regions of bits that were already set as collided.
+// It always succeeds and returns true.
+func (dbf *DeletableBloomFilter) Add(data []byte) bool {
+	dbf.mutex.Lock()
+	defer dbf.mutex.Unlock()
+
+	for _, index := range bloomIndexes(data, dbf.numHash, dbf.size) {
+		if dbf.bitArray[index/64]&(1<<(index%64)) != 0 {
+			dbf.collisions[index/dbf.regionSize] = true
+		}
+		dbf.bitArray[index/64] |= 1 << (index % 64)
+	}
+	return true
+}
+
+// Contains checks if an element might be in the set
+func (dbf *DeletableBloomFilter) Contains(data []byte) bool {
+	dbf.mutex.RLock()
+	defer dbf.mutex.RUnlock()
+
+	for _, index := range bloomIndexes(data, dbf.numHash, dbf.size) {
+		if dbf.bitArray[index/64]&(1<<(index%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Remove resets the element's bits that lie in collision-free regions.
+// It returns true if at least one bit was reset, meaning the element is now reported absent.
+// Elements whose bits all fall in collided regions cannot be removed.
+func (dbf *DeletableBloomFilter) Remove(data []byte) bool {
+	dbf.mutex.Lock()
+	defer dbf.mutex.Unlock()
+
+	indexes := bloomIndexes(data, dbf.numHash, dbf.size)
+	for _, index := range indexes {
+		if dbf.bitArray[index/64]&(1<<(index%64)) == 0 {
+			return false
+		}
+	}
+
+	removed := false
+	for _, index := range indexes {
+		if !dbf.collisions[index/dbf.regionSize] {
+			dbf.bitArray[index/64] &^= 1 << (index % 64)
+			removed = true
+		}
+	}
+	return removed
+}
+
+// Clear resets the filter
+func (dbf *DeletableBloomFilter) Clear() {
+	dbf.mutex.Lock()
+	defer dbf.mutex.Unlock()
+
+	clear(dbf.bitArray)
+	clear(dbf.collisions)
+}
+
+// CollidedRegions returns the fraction of regions whose bits can no longer be reset
+func (dbf *DeletableBloomFilter) CollidedRegions() float64 {
+	dbf.mutex.RLock()
+	defer dbf.mutex.RUnlock()
+
+	collided := 0
+	for _, c := range dbf.collisions {
+		if c {
+			collided++
+		}
+	}
+	return float64(collided) / float64(len(dbf.collisions))
+}
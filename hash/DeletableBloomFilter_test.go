@@ -0,0 +1,59 @@
+package hash
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestDeletableBloomFilterRemove tests removing elements without false negatives
+func TestDeletableBloomFilterRemove(t *testing.T) {
+	dbf := NewDeletableBloomFilter(1000, 0.01, 256)
+
+	for i := 0; i < 200; i++ {
+		dbf.Add([]byte(fmt.Sprintf("item-%d", i)))
+	}
+
+	removed := 0
+	for i := 0; i < 100; i++ {
+		if dbf.Remove([]byte(fmt.Sprintf("item-%d", i))) {
+			removed++
+		}
+	}
+	if removed == 0 {
+		t.Error("Expected at least some elements to be deletable")
+	}
+
+	// Elements that were not removed must still be present
+	for i := 100; i < 200; i++ {
+		if !dbf.Contains([]byte(fmt.Sprintf("item-%d", i))) {
+			t.Fatalf("Deletion caused a false negative for item-%d", i)
+		}
+	}
+
+	if rate := dbf.CollidedRegions(); rate <= 0 || rate > 1 {
+		t.Errorf("Unexpected collided region fraction %v", rate)
+	}
+}
+
+// TestDeletableBloomFilterSingleElement tests that a lone element is always deletable
+func TestDeletableBloomFilterSingleElement(t *testing.T) {
+	dbf := NewDeletableBloomFilter(100, 0.01, 64)
+	data := []byte("only")
+
+	dbf.Add(data)
+	if !dbf.Remove(data) {
+		t.Fatal("A single element should be deletable")
+	}
+	if dbf.Contains(data) {
+		t.Error("Element should be absent after removal")
+	}
+	if dbf.Remove(data) {
+		t.Error("Removing an absent element should fail")
+	}
+
+	dbf.Add(data)
+	dbf.Clear()
+	if dbf.Contains(data) || dbf.CollidedRegions() != 0 {
+		t.Error("Filter should be empty after clear")
+	}
+}
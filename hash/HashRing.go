@@ -0,0 +1,259 @@
+package hash
+
+import (
+	"hash/fnv"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// HashRing implements consistent hashing with virtual nodes, member weights and
+// optional bounded loads ("consistent hashing with bounded loads", Mirrokni et al.)
+type HashRing struct {
+	virtualNodes int               // virtual nodes per unit of weight
+	ring         []uint64          // sorted virtual node positions
+	owners       map[uint64]string // virtual node position -> member
+	weights      map[string]int
+	totalWeight  int
+	loads        map[string]int // keys currently assigned through Acquire
+	totalLoad    int
+	loadFactor   float64 // bounded loads are enabled when > 1
+	mutex        sync.RWMutex
+}
+
+// NewHashRing creates a new ring that places virtualNodes points per unit of member weight
+func NewHashRing(virtualNodes int) *HashRing {
+	if virtualNodes < 1 {
+		virtualNodes = 1
+	}
+	return &HashRing{
+		virtualNodes: virtualNodes,
+		ring:         make([]uint64, 0),
+		owners:       make(map[uint64]string),
+		weights:      make(map[string]int),
+		loads:        make(map[string]int),
+		mutex:        sync.RWMutex{},
+	}
+}
+
+// hashString hashes a string onto the ring
+func hashString(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return mix64(h.Sum64()) // FNV alone clusters similar strings such as "node#1", "node#2"
+}
+
+// Add adds a member with weight 1
+func (r *HashRing) Add(member string) {
+	r.AddWeighted(member, 1)
+}
+
+// AddWeighted adds a member that owns a share of the ring proportional to weight.
+// Adding an existing member replaces its weight.
+func (r *HashRing) AddWeighted(member string, weight int) {
+	if weight < 1 {
+		weight = 1
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.weights[member]; exists {
+		r.removeMember(member)
+	}
+
+	for i := 0; i < r.virtualNodes*weight; i++ {
+		position := hashString(member + "#" + strconv.Itoa(i))
+		if _, taken := r.owners[position]; taken {
+			continue // Astronomically rare collision; the first owner keeps the point
+		}
+		r.owners[position] = member
+		r.ring = append(r.ring, position)
+	}
+	sort.Slice(r.ring, func(i, j int) bool { return r.ring[i] < r.ring[j] })
+
+	r.weights[member] = weight
+	r.totalWeight += weight
+}
+
+// Remove removes a member and its virtual nodes from the ring
+func (r *HashRing) Remove(member string) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.weights[member]; !exists {
+		return false
+	}
+	r.removeMember(member)
+	return true
+}
+
+// removeMember drops a member; the caller must hold the write lock
+func (r *HashRing) removeMember(member string) {
+	ring := r.ring[:0]
+	for _, position := range r.ring {
+		if r.owners[position] == member {
+			delete(r.owners, position)
+			continue
+		}
+		ring = append(ring, position)
+	}
+	r.ring = ring
+
+	r.totalWeight -= r.weights[member]
+	delete(r.weights, member)
+	r.totalLoad -= r.loads[member]
+	delete(r.loads, member)
+}
+
+// Members returns the members of the ring in sorted order
+func (r *HashRing) Members() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	members := make([]string, 0, len(r.weights))
+	for member := range r.weights {
+		members = append(members, member)
+	}
+	sort.Strings(members)
+	return members
+}
+
+// Size returns the number of members
+func (r *HashRing) Size() int {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return len(r.weights)
+}
+
+// search returns the index of the first virtual node at or after the key's position
+func (r *HashRing) search(key string) int {
+	position := hashString(key)
+	index := sort.Search(len(r.ring), func(i int) bool { return r.ring[i] >= position })
+	if index == len(r.ring) {
+		index = 0 // Wrap around
+	}
+	return index
+}
+
+// walk visits distinct members clockwise from the key until visit returns false
+func (r *HashRing) walk(key string, visit func(member string) bool) {
+	if len(r.ring) == 0 {
+		return
+	}
+
+	seen := make(map[string]bool, len(r.weights))
+	start := r.search(key)
+	for i := 0; i < len(r.ring) && len(seen) < len(r.weights); i++ {
+		member := r.owners[r.ring[(start+i)%len(r.ring)]]
+		if seen[member] {
+			continue
+		}
+		seen[member] = true
+		if !visit(member) {
+			return
+		}
+	}
+}
+
+// Get returns the primary owner of a key
+func (r *HashRing) Get(key string) (string, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if len(r.ring) == 0 {
+		return "", false
+	}
+	return r.owners[r.ring[r.search(key)]], true
+}
+
+// GetN returns up to n distinct owners of a key: the primary followed by its replicas
+func (r *HashRing) GetN(key string, n int) []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	owners := make([]string, 0, n)
+	if n <= 0 {
+		return owners
+	}
+	r.walk(key, func(member string) bool {
+		owners = append(owners, member)
+		return len(owners) < n
+	})
+	return owners
+}
+
+// SetLoadFactor enables bounded loads: no member may hold more than
+// ceil(c * average load) keys assigned through Acquire. A factor <= 1 disables the bound.
+func (r *HashRing) SetLoadFactor(c float64) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if c <= 1 {
+		c = 0
+	}
+	r.loadFactor = c
+}
+
+// capacity returns how many keys a member may hold after one more assignment
+func (r *HashRing) capacity(member string) int {
+	if r.loadFactor == 0 {
+		return math.MaxInt
+	}
+	share := float64(r.weights[member]) / float64(r.totalWeight)
+	return int(math.Ceil(r.loadFactor * float64(r.totalLoad+1) * share))
+}
+
+// Acquire assigns a key to a member and counts it towards that member's load.
+// With bounded loads enabled, members at capacity are skipped clockwise.
+// Each successful Acquire should be paired with a Release once the key is done.
+func (r *HashRing) Acquire(key string) (string, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	owner := ""
+	r.walk(key, func(member string) bool {
+		if r.loads[member]+1 <= r.capacity(member) {
+			owner = member
+			return false
+		}
+		return true
+	})
+
+	if owner == "" {
+		return "", false
+	}
+	r.loads[owner]++
+	r.totalLoad++
+	return owner, true
+}
+
+// Release decrements the load of a member previously returned by Acquire
+func (r *HashRing) Release(member string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.loads[member] > 0 {
+		r.loads[member]--
+		r.totalLoad--
+	}
+}
+
+// Load returns the number of keys currently assigned to a member through Acquire
+func (r *HashRing) Load(member string) int {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.loads[member]
+}
+
+// MaxLoad returns the current per-unit-weight load limit, or -1 when loads are unbounded
+func (r *HashRing) MaxLoad() int {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if r.loadFactor == 0 || r.totalWeight == 0 {
+		return -1
+	}
+	return int(math.Ceil(r.loadFactor * float64(r.totalLoad) / float64(r.totalWeight)))
+}
@@ -0,0 +1,134 @@
+package hash
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// TestHashRingBasicOperations tests adding members and looking up owners
+func TestHashRingBasicOperations(t *testing.T) {
+	ring := NewHashRing(100)
+
+	if _, ok := ring.Get("key"); ok {
+		t.Error("Empty ring should not return an owner")
+	}
+
+	ring.Add("a")
+	ring.Add("b")
+	ring.Add("c")
+
+	if ring.Size() != 3 {
+		t.Errorf("Expected 3 members, got %d", ring.Size())
+	}
+
+	owner, ok := ring.Get("key")
+	if !ok {
+		t.Fatal("Expected an owner")
+	}
+	if again, _ := ring.Get("key"); again != owner {
+		t.Error("Lookups should be deterministic")
+	}
+
+	// Primary owner comes first, followed by distinct replicas
+	owners := ring.GetN("key", 5)
+	if len(owners) != 3 {
+		t.Fatalf("Expected 3 distinct owners, got %v", owners)
+	}
+	if owners[0] != owner {
+		t.Errorf("Expected primary %s first, got %v", owner, owners)
+	}
+	seen := make(map[string]bool)
+	for _, o := range owners {
+		if seen[o] {
+			t.Errorf("Duplicate owner in %v", owners)
+		}
+		seen[o] = true
+	}
+}
+
+// TestHashRingMinimalMovement tests that removing a member only moves its own keys
+func TestHashRingMinimalMovement(t *testing.T) {
+	ring := NewHashRing(100)
+	for i := 0; i < 5; i++ {
+		ring.Add(fmt.Sprintf("worker-%d", i))
+	}
+
+	before := make(map[string]string)
+	for i := 0; i < 10000; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		before[key], _ = ring.Get(key)
+	}
+
+	ring.Remove("worker-2")
+
+	for key, owner := range before {
+		after, _ := ring.Get(key)
+		if owner != "worker-2" && after != owner {
+			t.Fatalf("Key %s moved from %s to %s", key, owner, after)
+		}
+		if after == "worker-2" {
+			t.Fatalf("Key %s still owned by a removed member", key)
+		}
+	}
+}
+
+// TestHashRingWeights tests that weights change the share of keys
+func TestHashRingWeights(t *testing.T) {
+	ring := NewHashRing(200)
+	ring.AddWeighted("small", 1)
+	ring.AddWeighted("large", 3)
+
+	counts := make(map[string]int)
+	for i := 0; i < 20000; i++ {
+		owner, _ := ring.Get(fmt.Sprintf("key-%d", i))
+		counts[owner]++
+	}
+
+	share := float64(counts["large"]) / 20000
+	if math.Abs(share-0.75) > 0.05 {
+		t.Errorf("Expected the large member to own about 75%% of keys, got %.2f", share)
+	}
+}
+
+// TestHashRingBoundedLoads tests that no member exceeds the load bound
+func TestHashRingBoundedLoads(t *testing.T) {
+	ring := NewHashRing(50)
+	for i := 0; i < 4; i++ {
+		ring.Add(fmt.Sprintf("worker-%d", i))
+	}
+	ring.SetLoadFactor(1.25)
+
+	for i := 0; i < 1000; i++ {
+		if _, ok := ring.Acquire(fmt.Sprintf("key-%d", i)); !ok {
+			t.Fatalf("Failed to acquire key-%d", i)
+		}
+	}
+
+	limit := int(math.Ceil(1.25 * 1000 / 4))
+	total := 0
+	for _, member := range ring.Members() {
+		load := ring.Load(member)
+		total += load
+		if load > limit {
+			t.Errorf("Member %s has load %d above the bound %d", member, load, limit)
+		}
+	}
+	if total != 1000 {
+		t.Errorf("Expected total load 1000, got %d", total)
+	}
+	if ring.MaxLoad() != limit {
+		t.Errorf("Expected MaxLoad %d, got %d", limit, ring.MaxLoad())
+	}
+
+	loadBefore := ring.Load("worker-0")
+	ring.Release("worker-0")
+	if ring.Load("worker-0") != loadBefore-1 {
+		t.Errorf("Expected load %d after release, got %d", loadBefore-1, ring.Load("worker-0"))
+	}
+
+	ring.SetLoadFactor(0)
+	if ring.MaxLoad() != -1 {
+		t.Error("Expected unbounded loads after disabling the load factor")
+	}
+}
@@ -1,208 +1,701 @@
 package hash
 
-import "sync"
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/maphash"
+	"iter"
+	"math"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// Load factor bounds that trigger resizing
+const (
+	defaultCapacity = 8
+	maxLoadFactor   = 0.75 // grow when (entries + tombstones) exceed this fraction of Capacity
+	minLoadFactor   = 0.2  // shrink when entries fall below this fraction of Capacity
+	cuckooLoad      = 0.45 // two-choice cuckoo hashing needs a load factor below 0.5
+)
+
+// Strategy selects the collision resolution strategy of a HashTable
+type Strategy string
+
+// Supported collision resolution strategies; unknown values fall back to Chaining
+const (
+	LinearProbing    Strategy = "linear"
+	Chaining         Strategy = "chain"
+	QuadraticProbing Strategy = "quadratic"
+	DoubleHashing    Strategy = "double"
+	RobinHood        Strategy = "robinhood"
+	Cuckoo           Strategy = "cuckoo"
+)
+
+// Hasher computes a 64-bit hash for a key
+type Hasher[K comparable] func(key K) uint64
 
 // HashNode represents a key-value pair in the hash table
-type HashNode struct {
-	Key   interface{}
-	Value interface{}
-	Next  *HashNode // Used for chaining collision resolution
+type HashNode[K comparable, V any] struct {
+	Key     K
+	Value   V
+	Next    *HashNode[K, V] // Used for chaining collision resolution
+	hash    uint64          // Cached hash of Key, reused when the table is resized
+	deleted bool            // Tombstone marker used by open addressing
 }
 
 // HashTable represents the hash table data structure
-type HashTable struct {
-	Size     int
-	Capacity int
-	Table    []*HashNode
-	Strategy string // "linear" for linear probing, "chain" for chaining
-	mutex    sync.RWMutex
+type HashTable[K comparable, V any] struct {
+	Size       int
+	Capacity   int
+	Table      []*HashNode[K, V]
+	Strategy   Strategy
+	hasher     Hasher[K]
+	minCap     int // Capacity never shrinks below the initial capacity
+	tombstones int // Deleted slots still occupying the table (open addressing)
+	stats      probeCounters
+	mutex      sync.RWMutex
+}
+
+// ProbeStats summarizes the probe sequence lengths observed by Put, Get and Remove while
+// statistics are enabled. A probe is one slot (or chain node) examined while searching for a key.
+type ProbeStats struct {
+	Operations  uint64 // Number of searches performed
+	TotalProbes uint64 // Probes summed over all searches
+	MaxProbes   uint64 // Longest single search
+}
+
+// MeanProbes returns the average number of probes per search
+func (s ProbeStats) MeanProbes() float64 {
+	if s.Operations == 0 {
+		return 0
+	}
+	return float64(s.TotalProbes) / float64(s.Operations)
+}
+
+// probeCounters accumulates ProbeStats; updated atomically so that Get can record under the read
+// lock. While disabled, recording only reads the flag, so readers share no written cache line.
+type probeCounters struct {
+	enabled    atomic.Bool
+	operations atomic.Uint64
+	total      atomic.Uint64
+	max        atomic.Uint64
 }
 
-// NewHashTable creates a new hash table with specified capacity and collision resolution strategy
-func NewHashTable(capacity int, strategy string) *HashTable {
-	return &HashTable{
+// record adds one search of the given length
+func (c *probeCounters) record(probes int) {
+	if !c.enabled.Load() {
+		return
+	}
+	n := uint64(probes)
+	c.operations.Add(1)
+	c.total.Add(n)
+	for {
+		current := c.max.Load()
+		if n <= current || c.max.CompareAndSwap(current, n) {
+			return
+		}
+	}
+}
+
+// NewHashTable creates a new hash table with specified capacity and collision resolution strategy.
+// Keys and values are untyped; use NewTypedHashTable to avoid boxing.
+func NewHashTable(capacity int, strategy Strategy) *HashTable[any, any] {
+	return NewTypedHashTable[any, any](capacity, strategy, nil)
+}
+
+// NewTypedHashTable creates a new hash table for keys of type K and values of type V.
+// The capacity is rounded up to a power of two and grows or shrinks with the load factor.
+// If hasher is nil, keys are hashed with hash/maphash.
+func NewTypedHashTable[K comparable, V any](capacity int, strategy Strategy, hasher Hasher[K]) *HashTable[K, V] {
+	if hasher == nil {
+		hasher = NewMapHasher[K]()
+	}
+	capacity = nextPowerOfTwo(capacity)
+	return &HashTable[K, V]{
 		Size:     0,
 		Capacity: capacity,
-		Table:    make([]*HashNode, capacity),
+		Table:    make([]*HashNode[K, V], capacity),
 		Strategy: strategy,
+		hasher:   hasher,
+		minCap:   capacity,
 		mutex:    sync.RWMutex{},
 	}
 }
 
-// hash generates a hash value for the given key
-func (h *HashTable) hash(key interface{}) int {
-	// Simple hash function for demonstration
-	switch v := key.(type) {
-	case string:
-		hash := 0
-		for _, ch := range v {
-			hash = (hash*31 + int(ch)) % h.Capacity
+// NewMapHasher returns a Hasher backed by hash/maphash with a random seed.
+// The key kind is resolved once here: strings, integers, floats, booleans,
+// pointers and byte arrays are read in place and hashed without converting
+// them to any, while other structs and arrays are hashed field by field
+// through reflection, so keys that compare equal always hash equally. A
+// custom Hasher is recommended for composite keys on hot paths. Like a
+// builtin map, it panics on an interface key holding a value that is not
+// comparable.
+func NewMapHasher[K comparable]() Hasher[K] {
+	seed := maphash.MakeSeed()
+	if hasher := typedHasher[K](seed); hasher != nil {
+		return hasher
+	}
+	return func(key K) uint64 {
+		return hashComparable(seed, key)
+	}
+}
+
+// typedHasher returns a Hasher that reads keys of a scalar or byte array kind directly from
+// memory, or nil if K needs the generic path. The unsafe casts are sound because the kind,
+// and for integers the size, is checked against K before the closure is built.
+func typedHasher[K comparable](seed maphash.Seed) Hasher[K] {
+	t := reflect.TypeFor[K]()
+	switch t.Kind() {
+	case reflect.String:
+		return func(key K) uint64 {
+			return maphash.String(seed, *(*string)(unsafe.Pointer(&key)))
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch t.Size() {
+		case 1:
+			return integerHasher[K, int8](seed)
+		case 2:
+			return integerHasher[K, int16](seed)
+		case 4:
+			return integerHasher[K, int32](seed)
+		case 8:
+			return integerHasher[K, int64](seed)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		switch t.Size() {
+		case 1:
+			return integerHasher[K, uint8](seed)
+		case 2:
+			return integerHasher[K, uint16](seed)
+		case 4:
+			return integerHasher[K, uint32](seed)
+		case 8:
+			return integerHasher[K, uint64](seed)
+		}
+	case reflect.Float32:
+		return func(key K) uint64 {
+			return hashFloat(seed, float64(*(*float32)(unsafe.Pointer(&key))))
+		}
+	case reflect.Float64:
+		return func(key K) uint64 {
+			return hashFloat(seed, *(*float64)(unsafe.Pointer(&key)))
+		}
+	case reflect.Bool:
+		return func(key K) uint64 {
+			if *(*bool)(unsafe.Pointer(&key)) {
+				return hashUint64(seed, 1)
+			}
+			return hashUint64(seed, 0)
+		}
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		return func(key K) uint64 {
+			return hashUint64(seed, uint64(uintptr(*(*unsafe.Pointer)(unsafe.Pointer(&key)))))
 		}
-		return hash
+	case reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			size := int(t.Size())
+			return func(key K) uint64 {
+				return maphash.Bytes(seed, unsafe.Slice((*byte)(unsafe.Pointer(&key)), size))
+			}
+		}
+	}
+	return nil
+}
+
+// integerHasher returns a Hasher for keys whose memory layout is the integer type I; signed
+// values are sign-extended so every integer kind hashes its value the same way
+func integerHasher[K comparable, I int8 | int16 | int32 | int64 | uint8 | uint16 | uint32 | uint64](seed maphash.Seed) Hasher[K] {
+	return func(key K) uint64 {
+		return hashUint64(seed, uint64(*(*I)(unsafe.Pointer(&key))))
+	}
+}
+
+// hashComparable hashes a comparable value with the given maphash seed
+func hashComparable(seed maphash.Seed, key any) uint64 {
+	switch k := key.(type) {
+	case string:
+		return maphash.String(seed, k)
 	case int:
-		return v % h.Capacity
+		return hashUint64(seed, uint64(k))
+	case int8:
+		return hashUint64(seed, uint64(k))
+	case int16:
+		return hashUint64(seed, uint64(k))
+	case int32:
+		return hashUint64(seed, uint64(k))
+	case int64:
+		return hashUint64(seed, uint64(k))
+	case uint:
+		return hashUint64(seed, uint64(k))
+	case uint8:
+		return hashUint64(seed, uint64(k))
+	case uint16:
+		return hashUint64(seed, uint64(k))
+	case uint32:
+		return hashUint64(seed, uint64(k))
+	case uint64:
+		return hashUint64(seed, k)
+	case uintptr:
+		return hashUint64(seed, uint64(k))
+	case float32:
+		return hashFloat(seed, float64(k))
+	case float64:
+		return hashFloat(seed, k)
+	case bool:
+		if k {
+			return hashUint64(seed, 1)
+		}
+		return hashUint64(seed, 0)
 	default:
-		return 0
+		var h maphash.Hash
+		h.SetSeed(seed)
+		writeComparable(&h, reflect.ValueOf(key))
+		return h.Sum64()
+	}
+}
+
+// writeComparable feeds a comparable value to h so that values equal under == write the same
+// bytes: floats are normalized like hashFloat, pointers and channels write their address and
+// interfaces their dynamic type before the value
+func writeComparable(h *maphash.Hash, v reflect.Value) {
+	var buf [8]byte
+	writeUint64 := func(x uint64) {
+		binary.LittleEndian.PutUint64(buf[:], x)
+		h.Write(buf[:])
+	}
+	writeFloat := func(f float64) {
+		if f == 0 {
+			f = 0
+		}
+		writeUint64(math.Float64bits(f))
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			writeUint64(1)
+		} else {
+			writeUint64(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint64(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeFloat(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		writeFloat(real(v.Complex()))
+		writeFloat(imag(v.Complex()))
+	case reflect.String:
+		// The length keeps adjacent strings in a struct from running together
+		writeUint64(uint64(v.Len()))
+		h.WriteString(v.String())
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		writeUint64(uint64(v.Pointer()))
+	case reflect.Interface:
+		if v.IsNil() {
+			writeUint64(0)
+			return
+		}
+		writeUint64(1)
+		h.WriteString(v.Elem().Type().String())
+		writeComparable(h, v.Elem())
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			writeComparable(h, v.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			writeComparable(h, v.Field(i))
+		}
+	default:
+		panic(fmt.Sprintf("hash: unhashable type %s", v.Type()))
 	}
 }
 
+// hashUint64 hashes the little-endian encoding of v
+func hashUint64(seed maphash.Seed, v uint64) uint64 {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], v)
+	return maphash.Bytes(seed, buf[:])
+}
+
+// hashFloat hashes a float so that equal values (including +0 and -0) collide
+func hashFloat(seed maphash.Seed, f float64) uint64 {
+	if f == 0 {
+		f = 0
+	}
+	return hashUint64(seed, math.Float64bits(f))
+}
+
+// nextPowerOfTwo returns the smallest power of two >= n (at least defaultCapacity)
+func nextPowerOfTwo(n int) int {
+	capacity := defaultCapacity
+	for capacity < n {
+		capacity <<= 1
+	}
+	return capacity
+}
+
+// mix64 is the splitmix64 finalizer, used to derive secondary hashes from a primary one
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// hash generates a table index for the given key
+func (h *HashTable[K, V]) hash(key K) int {
+	return h.indexFor(h.hasher(key))
+}
+
+// indexFor maps a full hash value to a table index
+func (h *HashTable[K, V]) indexFor(hashValue uint64) int {
+	return int(hashValue & uint64(h.Capacity-1))
+}
+
 // Put inserts a key-value pair into the hash table
-func (h *HashTable) Put(key, value interface{}) bool {
+func (h *HashTable[K, V]) Put(key K, value V) bool {
 	h.mutex.Lock()
 	defer h.mutex.Unlock()
 
-	hashIndex := h.hash(key)
+	return h.put(key, value)
+}
 
-	if h.Strategy == "linear" {
-		return h.putLinearProbing(hashIndex, key, value)
-	}
-	return h.putChaining(hashIndex, key, value)
+// put inserts or updates a key; the caller must hold the write lock
+func (h *HashTable[K, V]) put(key K, value V) bool {
+	return h.putHashed(h.hasher(key), key, value)
 }
 
-// putLinearProbing handles insertion using linear probing
-func (h *HashTable) putLinearProbing(hashIndex int, key, value interface{}) bool {
-	if h.Size >= h.Capacity {
-		return false // Table is full
+// putHashed is put for a key whose hash is already known
+func (h *HashTable[K, V]) putHashed(hashValue uint64, key K, value V) bool {
+	if node := h.lookup(hashValue, key); node != nil {
+		node.Value = value // Update existing key
+		return true
 	}
 
-	index := hashIndex
-	for {
-		if h.Table[index] == nil {
-			h.Table[index] = &HashNode{Key: key, Value: value}
-			h.Size++
-			return true
-		}
-		if h.Table[index].Key == key {
-			h.Table[index].Value = value // Update existing key
-			return true
-		}
-		index = (index + 1) % h.Capacity // Linear probing
-		if index == hashIndex {
-			return false // Went full circle, table is full
-		}
-	}
+	h.growIfNeeded()
+	h.insert(&HashNode[K, V]{Key: key, Value: value, hash: hashValue})
+	h.Size++
+	return true
 }
 
-// putChaining handles insertion using chaining
-func (h *HashTable) putChaining(hashIndex int, key, value interface{}) bool {
-	newNode := &HashNode{Key: key, Value: value}
+// lookup finds the live node holding key, or nil, and records the probe count
+func (h *HashTable[K, V]) lookup(hashValue uint64, key K) *HashNode[K, V] {
+	var node *HashNode[K, V]
+	var probes int
+
+	switch h.Strategy {
+	case LinearProbing, QuadraticProbing, DoubleHashing:
+		node, _, probes = h.lookupOpenAddressing(hashValue, key)
+	case RobinHood:
+		node, _, probes = h.lookupRobinHood(hashValue, key)
+	case Cuckoo:
+		node, _, probes = h.lookupCuckoo(hashValue, key)
+	default:
+		node, probes = h.lookupChaining(hashValue, key)
+	}
 
-	if h.Table[hashIndex] == nil {
-		h.Table[hashIndex] = newNode
-	} else {
-		// Check if key exists and update value
-		current := h.Table[hashIndex]
-		for current != nil {
-			if current.Key == key {
-				current.Value = value
-				return true
+	h.stats.record(probes)
+	return node
+}
+
+// insert places a node whose key is known to be absent
+func (h *HashTable[K, V]) insert(node *HashNode[K, V]) {
+	switch h.Strategy {
+	case LinearProbing, QuadraticProbing, DoubleHashing:
+		h.insertOpenAddressing(node)
+	case RobinHood:
+		h.insertRobinHood(node)
+	case Cuckoo:
+		// A failed displacement chain leaves one node homeless; grow until it fits
+		for attempt := 0; ; attempt++ {
+			if node = h.insertCuckoo(node); node == nil {
+				return
 			}
-			if current.Next == nil {
-				break
+			if attempt == maxCuckooGrowths {
+				panic("hash: cuckoo insertion failed, the hasher produces too many identical hashes")
 			}
-			current = current.Next
+			h.resize(h.Capacity * 2)
 		}
-		// Add new node to the chain
-		current.Next = newNode
+	default:
+		h.insertChaining(node)
 	}
-	h.Size++
-	return true
 }
 
 // Get retrieves a value by key from the hash table
-func (h *HashTable) Get(key interface{}) (interface{}, bool) {
+func (h *HashTable[K, V]) Get(key K) (V, bool) {
 	h.mutex.RLock()
 	defer h.mutex.RUnlock()
 
-	hashIndex := h.hash(key)
+	return h.get(key)
+}
+
+// get retrieves a value; the caller must hold at least the read lock
+func (h *HashTable[K, V]) get(key K) (V, bool) {
+	return h.getHashed(h.hasher(key), key)
+}
 
-	if h.Strategy == "linear" {
-		return h.getLinearProbing(hashIndex, key)
+// getHashed is get for a key whose hash is already known
+func (h *HashTable[K, V]) getHashed(hashValue uint64, key K) (V, bool) {
+	if node := h.lookup(hashValue, key); node != nil {
+		return node.Value, true
 	}
-	return h.getChaining(hashIndex, key)
+	var zero V
+	return zero, false
 }
 
-// getLinearProbing retrieves a value using linear probing
-func (h *HashTable) getLinearProbing(hashIndex int, key interface{}) (interface{}, bool) {
-	index := hashIndex
-	for {
-		if h.Table[index] == nil {
-			return nil, false
-		}
-		if h.Table[index].Key == key {
-			return h.Table[index].Value, true
+// Remove removes a key-value pair from the hash table
+func (h *HashTable[K, V]) Remove(key K) bool {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return h.remove(key)
+}
+
+// remove deletes a key; the caller must hold the write lock
+func (h *HashTable[K, V]) remove(key K) bool {
+	return h.removeHashed(h.hasher(key), key)
+}
+
+// removeHashed is remove for a key whose hash is already known
+func (h *HashTable[K, V]) removeHashed(hashValue uint64, key K) bool {
+	var removed bool
+	var probes int
+	switch h.Strategy {
+	case LinearProbing, QuadraticProbing, DoubleHashing:
+		removed, probes = h.removeOpenAddressing(hashValue, key)
+	case RobinHood:
+		removed, probes = h.removeRobinHood(hashValue, key)
+	case Cuckoo:
+		removed, probes = h.removeCuckoo(hashValue, key)
+	default:
+		removed, probes = h.removeChaining(hashValue, key)
+	}
+	h.stats.record(probes)
+
+	if removed {
+		h.Size--
+		h.shrinkIfNeeded()
+	}
+	return removed
+}
+
+// LoadFactor returns the ratio of stored entries to capacity
+func (h *HashTable[K, V]) LoadFactor() float64 {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	return float64(h.Size) / float64(h.Capacity)
+}
+
+// Len returns the number of stored entries
+func (h *HashTable[K, V]) Len() int {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	return h.Size
+}
+
+// All returns an iterator over the table's key-value pairs in unspecified order.
+// The entries are copied under the read lock when iteration starts, so the loop body
+// may modify the table; such changes are not seen by the running iteration.
+func (h *HashTable[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		keys, values := h.snapshot()
+		for i := range keys {
+			if !yield(keys[i], values[i]) {
+				return
+			}
 		}
-		index = (index + 1) % h.Capacity
-		if index == hashIndex {
-			return nil, false
+	}
+}
+
+// Keys returns an iterator over the table's keys with the same snapshot semantics as All
+func (h *HashTable[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		keys, _ := h.snapshot()
+		for _, key := range keys {
+			if !yield(key) {
+				return
+			}
 		}
 	}
 }
 
-// getChaining retrieves a value using chaining
-func (h *HashTable) getChaining(hashIndex int, key interface{}) (interface{}, bool) {
-	current := h.Table[hashIndex]
-	for current != nil {
-		if current.Key == key {
-			return current.Value, true
+// Values returns an iterator over the table's values with the same snapshot semantics as All
+func (h *HashTable[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		_, values := h.snapshot()
+		for _, value := range values {
+			if !yield(value) {
+				return
+			}
 		}
-		current = current.Next
 	}
-	return nil, false
 }
 
-// Remove removes a key-value pair from the hash table
-func (h *HashTable) Remove(key interface{}) bool {
+// snapshot copies the live keys and values under the read lock
+func (h *HashTable[K, V]) snapshot() ([]K, []V) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	keys := make([]K, 0, h.Size)
+	values := make([]V, 0, h.Size)
+	for _, node := range h.liveNodes() {
+		keys = append(keys, node.Key)
+		values = append(values, node.Value)
+	}
+	return keys, values
+}
+
+// Clear removes every entry and shrinks the table back to its initial capacity
+func (h *HashTable[K, V]) Clear() {
 	h.mutex.Lock()
 	defer h.mutex.Unlock()
 
-	hashIndex := h.hash(key)
+	h.Size = 0
+	h.tombstones = 0
+	h.Capacity = h.minCap
+	h.Table = make([]*HashNode[K, V], h.minCap)
+}
+
+// Clone returns an independent copy of the table with the same strategy and hasher.
+// Probe statistics and whether they are enabled are not copied.
+func (h *HashTable[K, V]) Clone() *HashTable[K, V] {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
 
-	if h.Strategy == "linear" {
-		return h.removeLinearProbing(hashIndex, key)
+	clone := &HashTable[K, V]{
+		Size:     h.Size,
+		Capacity: h.Capacity,
+		Table:    make([]*HashNode[K, V], h.Capacity),
+		Strategy: h.Strategy,
+		hasher:   h.hasher,
+		minCap:   h.minCap,
+		mutex:    sync.RWMutex{},
+	}
+	nodes := h.liveNodes()
+	copies := make([]*HashNode[K, V], len(nodes))
+	for i, node := range nodes {
+		copies[i] = &HashNode[K, V]{Key: node.Key, Value: node.Value, hash: node.hash}
 	}
-	return h.removeChaining(hashIndex, key)
+	// Cuckoo placement depends on insertion order, so a copy may need a larger table
+	for attempt := 0; !clone.reinsert(copies); attempt++ {
+		if attempt == maxCuckooGrowths {
+			panic("hash: cuckoo rehash failed, the hasher produces too many identical hashes")
+		}
+		clone.Capacity *= 2
+		clone.Table = make([]*HashNode[K, V], clone.Capacity)
+	}
+	return clone
 }
 
-// removeLinearProbing removes an entry using linear probing
-func (h *HashTable) removeLinearProbing(hashIndex int, key interface{}) bool {
-	index := hashIndex
-	for {
-		if h.Table[index] == nil {
-			return false
-		}
-		if h.Table[index].Key == key {
-			h.Table[index] = nil
-			h.Size--
-			return true
-		}
-		index = (index + 1) % h.Capacity
-		if index == hashIndex {
-			return false
-		}
+// SetProbeStatsEnabled turns probe statistics on or off; they are off by default. Collecting
+// them writes table-wide counters on every Put, Get and Remove, which contends between readers.
+func (h *HashTable[K, V]) SetProbeStatsEnabled(enabled bool) {
+	h.stats.enabled.Store(enabled)
+}
+
+// ProbeStats returns the probe statistics collected while enabled, since creation or the last
+// ResetProbeStats
+func (h *HashTable[K, V]) ProbeStats() ProbeStats {
+	return ProbeStats{
+		Operations:  h.stats.operations.Load(),
+		TotalProbes: h.stats.total.Load(),
+		MaxProbes:   h.stats.max.Load(),
 	}
 }
 
-// removeChaining removes an entry using chaining
-func (h *HashTable) removeChaining(hashIndex int, key interface{}) bool {
-	if h.Table[hashIndex] == nil {
-		return false
+// ResetProbeStats clears the collected probe statistics
+func (h *HashTable[K, V]) ResetProbeStats() {
+	h.stats.operations.Store(0)
+	h.stats.total.Store(0)
+	h.stats.max.Store(0)
+}
+
+// maxLoad returns the load factor at which the current strategy grows
+func (h *HashTable[K, V]) maxLoad() float64 {
+	if h.Strategy == Cuckoo {
+		return cuckooLoad
 	}
+	return maxLoadFactor
+}
 
-	if h.Table[hashIndex].Key == key {
-		h.Table[hashIndex] = h.Table[hashIndex].Next
-		h.Size--
-		return true
+// growIfNeeded makes room for one more entry, doubling the capacity when the
+// live entries demand it and otherwise rehashing in place to purge tombstones
+func (h *HashTable[K, V]) growIfNeeded() {
+	used := h.Size + h.tombstones + 1
+	if float64(used) <= float64(h.Capacity)*h.maxLoad() {
+		return
 	}
 
-	current := h.Table[hashIndex]
-	for current.Next != nil {
-		if current.Next.Key == key {
-			current.Next = current.Next.Next
-			h.Size--
-			return true
+	newCapacity := h.Capacity
+	if float64(h.Size+1) > float64(h.Capacity)*h.maxLoad()/2 {
+		newCapacity *= 2
+	}
+	h.resize(newCapacity)
+}
+
+// shrinkIfNeeded halves the capacity when the table becomes sparse
+func (h *HashTable[K, V]) shrinkIfNeeded() {
+	if h.Capacity <= h.minCap {
+		return
+	}
+	if float64(h.Size) < float64(h.Capacity)*minLoadFactor {
+		h.resize(h.Capacity / 2)
+	}
+}
+
+// resize rebuilds the table with the given capacity using the cached hashes
+func (h *HashTable[K, V]) resize(newCapacity int) {
+	nodes := h.liveNodes()
+
+	for attempt := 0; ; attempt++ {
+		h.Capacity = newCapacity
+		h.Table = make([]*HashNode[K, V], newCapacity)
+		h.tombstones = 0
+
+		if h.reinsert(nodes) {
+			return
+		}
+		// Only cuckoo insertion can fail; retry with a larger table
+		if attempt == maxCuckooGrowths {
+			panic("hash: cuckoo rehash failed, the hasher produces too many identical hashes")
+		}
+		newCapacity *= 2
+	}
+}
+
+// reinsert places nodes into a freshly allocated table, reporting false if cuckoo displacement failed
+func (h *HashTable[K, V]) reinsert(nodes []*HashNode[K, V]) bool {
+	for _, node := range nodes {
+		node.Next = nil
+		if h.Strategy == Cuckoo {
+			if h.insertCuckoo(node) != nil {
+				return false
+			}
+			continue
+		}
+		h.insert(node)
+	}
+	return true
+}
+
+// liveNodes collects every stored node, skipping tombstones
+func (h *HashTable[K, V]) liveNodes() []*HashNode[K, V] {
+	nodes := make([]*HashNode[K, V], 0, h.Size)
+	for _, slot := range h.Table {
+		for current := slot; current != nil; current = current.Next {
+			if !current.deleted {
+				nodes = append(nodes, current)
+			}
 		}
-		current = current.Next
 	}
-	return false
+	return nodes
 }
@@ -0,0 +1,226 @@
+package hash
+
+// Cuckoo hashing limits
+const (
+	maxCuckooKicks   = 500 // displacement chain length before the table grows
+	maxCuckooGrowths = 8   // consecutive growths tolerated for a single insertion
+)
+
+// insertChaining handles insertion using chaining
+func (h *HashTable[K, V]) insertChaining(node *HashNode[K, V]) {
+	index := h.indexFor(node.hash)
+	node.Next = h.Table[index]
+	h.Table[index] = node
+}
+
+// lookupChaining finds a node using chaining; every chain node examined counts as a probe
+func (h *HashTable[K, V]) lookupChaining(hashValue uint64, key K) (*HashNode[K, V], int) {
+	probes := 1
+	for current := h.Table[h.indexFor(hashValue)]; current != nil; current = current.Next {
+		if current.hash == hashValue && current.Key == key {
+			return current, probes
+		}
+		probes++
+	}
+	return nil, probes
+}
+
+// removeChaining removes an entry using chaining
+func (h *HashTable[K, V]) removeChaining(hashValue uint64, key K) (bool, int) {
+	index := h.indexFor(hashValue)
+	probes := 1
+	var prev *HashNode[K, V]
+	for current := h.Table[index]; current != nil; current = current.Next {
+		if current.hash == hashValue && current.Key == key {
+			if prev == nil {
+				h.Table[index] = current.Next
+			} else {
+				prev.Next = current.Next
+			}
+			return true, probes
+		}
+		prev = current
+		probes++
+	}
+	return false, probes
+}
+
+// probeIndex returns the i-th slot of the probe sequence for linear, quadratic and double hashing.
+// Every sequence visits all slots of a power-of-two table.
+func (h *HashTable[K, V]) probeIndex(hashValue uint64, i int) int {
+	mask := uint64(h.Capacity - 1)
+	home := hashValue & mask
+	step := uint64(i)
+
+	switch h.Strategy {
+	case QuadraticProbing:
+		step = uint64(i) * uint64(i+1) / 2 // Triangular numbers
+	case DoubleHashing:
+		step = uint64(i) * (mix64(hashValue) | 1) // Odd step is coprime with the capacity
+	}
+	return int((home + step) & mask)
+}
+
+// lookupOpenAddressing finds a node, returning it with its slot and the number of probes
+func (h *HashTable[K, V]) lookupOpenAddressing(hashValue uint64, key K) (*HashNode[K, V], int, int) {
+	for i := 0; i < h.Capacity; i++ {
+		index := h.probeIndex(hashValue, i)
+		slot := h.Table[index]
+		if slot == nil {
+			return nil, -1, i + 1
+		}
+		if !slot.deleted && slot.hash == hashValue && slot.Key == key {
+			return slot, index, i + 1
+		}
+	}
+	return nil, -1, h.Capacity
+}
+
+// insertOpenAddressing places a node in the first empty or deleted slot of its probe sequence
+func (h *HashTable[K, V]) insertOpenAddressing(node *HashNode[K, V]) {
+	for i := 0; ; i++ {
+		index := h.probeIndex(node.hash, i)
+		slot := h.Table[index]
+		if slot == nil {
+			h.Table[index] = node
+			return
+		}
+		if slot.deleted {
+			h.Table[index] = node
+			h.tombstones--
+			return
+		}
+	}
+}
+
+// removeOpenAddressing removes an entry by leaving a tombstone so later probe sequences stay intact
+func (h *HashTable[K, V]) removeOpenAddressing(hashValue uint64, key K) (bool, int) {
+	node, _, probes := h.lookupOpenAddressing(hashValue, key)
+	if node == nil {
+		return false, probes
+	}
+
+	var zeroKey K
+	var zeroValue V
+	node.Key, node.Value = zeroKey, zeroValue
+	node.deleted = true
+	h.tombstones++
+	return true, probes
+}
+
+// distance returns how far the entry at index sits from its home slot
+func (h *HashTable[K, V]) distance(hashValue uint64, index int) int {
+	return (index - h.indexFor(hashValue)) & (h.Capacity - 1)
+}
+
+// lookupRobinHood finds a node, stopping as soon as a resident is closer to home than the probe
+func (h *HashTable[K, V]) lookupRobinHood(hashValue uint64, key K) (*HashNode[K, V], int, int) {
+	index := h.indexFor(hashValue)
+	for dist := 0; dist < h.Capacity; dist++ {
+		slot := h.Table[index]
+		if slot == nil || h.distance(slot.hash, index) < dist {
+			return nil, -1, dist + 1
+		}
+		if slot.hash == hashValue && slot.Key == key {
+			return slot, index, dist + 1
+		}
+		index = (index + 1) & (h.Capacity - 1)
+	}
+	return nil, -1, h.Capacity
+}
+
+// insertRobinHood places a node, displacing residents that are closer to their home slot
+func (h *HashTable[K, V]) insertRobinHood(node *HashNode[K, V]) {
+	index := h.indexFor(node.hash)
+	dist := 0
+	for {
+		slot := h.Table[index]
+		if slot == nil {
+			h.Table[index] = node
+			return
+		}
+		if slotDist := h.distance(slot.hash, index); slotDist < dist {
+			// Take from the rich: the carried node claims the slot, the resident moves on
+			h.Table[index], node = node, slot
+			dist = slotDist
+		}
+		index = (index + 1) & (h.Capacity - 1)
+		dist++
+	}
+}
+
+// removeRobinHood removes an entry with backward-shift deletion, so no tombstones are needed
+func (h *HashTable[K, V]) removeRobinHood(hashValue uint64, key K) (bool, int) {
+	node, index, probes := h.lookupRobinHood(hashValue, key)
+	if node == nil {
+		return false, probes
+	}
+
+	for {
+		next := (index + 1) & (h.Capacity - 1)
+		slot := h.Table[next]
+		if slot == nil || h.distance(slot.hash, next) == 0 {
+			h.Table[index] = nil
+			return true, probes
+		}
+		h.Table[index] = slot
+		index = next
+	}
+}
+
+// cuckooOffset returns the XOR distance between the two candidate slots of a hash
+func (h *HashTable[K, V]) cuckooOffset(hashValue uint64) int {
+	offset := int(mix64(hashValue) & uint64(h.Capacity-1))
+	if offset == 0 {
+		offset = 1
+	}
+	return offset
+}
+
+// lookupCuckoo checks the two candidate slots of a key
+func (h *HashTable[K, V]) lookupCuckoo(hashValue uint64, key K) (*HashNode[K, V], int, int) {
+	first := h.indexFor(hashValue)
+	second := first ^ h.cuckooOffset(hashValue)
+
+	if slot := h.Table[first]; slot != nil && slot.hash == hashValue && slot.Key == key {
+		return slot, first, 1
+	}
+	if slot := h.Table[second]; slot != nil && slot.hash == hashValue && slot.Key == key {
+		return slot, second, 2
+	}
+	return nil, -1, 2
+}
+
+// insertCuckoo places a node in one of its two slots, evicting residents to their alternate slot.
+// It returns the node left without a slot when the displacement chain is too long, or nil on success.
+func (h *HashTable[K, V]) insertCuckoo(node *HashNode[K, V]) *HashNode[K, V] {
+	index := h.indexFor(node.hash)
+	if h.Table[index] == nil {
+		h.Table[index] = node
+		return nil
+	}
+	if alternate := index ^ h.cuckooOffset(node.hash); h.Table[alternate] == nil {
+		h.Table[alternate] = node
+		return nil
+	}
+
+	for kicks := 0; kicks < maxCuckooKicks; kicks++ {
+		h.Table[index], node = node, h.Table[index]
+		index ^= h.cuckooOffset(node.hash)
+		if h.Table[index] == nil {
+			h.Table[index] = node
+			return nil
+		}
+	}
+	return node
+}
+
+// removeCuckoo removes an entry from its slot
+func (h *HashTable[K, V]) removeCuckoo(hashValue uint64, key K) (bool, int) {
+	_, index, probes := h.lookupCuckoo(hashValue, key)
+	if index < 0 {
+		return false, probes
+	}
+	h.Table[index] = nil
+	return true, probes
+}
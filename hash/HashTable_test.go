@@ -1,6 +1,11 @@
 package hash
 
-import "testing"
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
 
 // TestHashTableLinearProbing tests the linear probing implementation
 func TestHashTableLinearProbing(t *testing.T) {
@@ -88,3 +93,400 @@ func TestHashTableChaining(t *testing.T) {
 		t.Error("test1 should not exist after removal")
 	}
 }
+
+// TestHashTableResizing tests that the table grows and shrinks with the load factor
+func TestHashTableResizing(t *testing.T) {
+	for _, strategy := range []Strategy{LinearProbing, Chaining, QuadraticProbing, DoubleHashing, RobinHood, Cuckoo} {
+		ht := NewTypedHashTable[int, string](8, strategy, nil)
+
+		for i := 0; i < 1000; i++ {
+			if !ht.Put(i, "value") {
+				t.Fatalf("%s: failed to insert %d", strategy, i)
+			}
+		}
+		if ht.Size != 1000 {
+			t.Errorf("%s: expected size 1000, got %d", strategy, ht.Size)
+		}
+		if ht.Capacity < 1000 {
+			t.Errorf("%s: expected capacity to grow past 1000, got %d", strategy, ht.Capacity)
+		}
+		if lf := ht.LoadFactor(); lf > maxLoadFactor {
+			t.Errorf("%s: load factor %v exceeds %v", strategy, lf, maxLoadFactor)
+		}
+
+		for i := 0; i < 1000; i++ {
+			if val, ok := ht.Get(i); !ok || val != "value" {
+				t.Fatalf("%s: failed to get %d after growth", strategy, i)
+			}
+		}
+
+		grown := ht.Capacity
+		for i := 0; i < 990; i++ {
+			if !ht.Remove(i) {
+				t.Fatalf("%s: failed to remove %d", strategy, i)
+			}
+		}
+		if ht.Capacity >= grown {
+			t.Errorf("%s: expected capacity to shrink from %d, got %d", strategy, grown, ht.Capacity)
+		}
+		if ht.Capacity < 8 {
+			t.Errorf("%s: capacity shrank below the initial capacity: %d", strategy, ht.Capacity)
+		}
+		for i := 990; i < 1000; i++ {
+			if _, ok := ht.Get(i); !ok {
+				t.Errorf("%s: key %d lost after shrinking", strategy, i)
+			}
+		}
+	}
+}
+
+// TestHashTableLinearProbingTombstones tests lookups past removed slots
+func TestHashTableLinearProbingTombstones(t *testing.T) {
+	// A constant hasher forces every key into the same probe sequence
+	ht := NewTypedHashTable[string, int](16, "linear", func(string) uint64 { return 3 })
+
+	ht.Put("a", 1)
+	ht.Put("b", 2)
+	ht.Put("c", 3)
+
+	if !ht.Remove("b") {
+		t.Fatal("Failed to remove b")
+	}
+	if val, ok := ht.Get("c"); !ok || val != 3 {
+		t.Error("c should be reachable past the removed slot")
+	}
+
+	ht.Put("d", 4)
+	if ht.tombstones != 0 {
+		t.Errorf("Expected the tombstone to be reused, got %d tombstones", ht.tombstones)
+	}
+	if val, ok := ht.Get("d"); !ok || val != 4 {
+		t.Error("Failed to get d")
+	}
+}
+
+// TestHashTableCustomHasher tests that a pluggable hasher is used
+func TestHashTableCustomHasher(t *testing.T) {
+	calls := 0
+	hasher := func(key int) uint64 {
+		calls++
+		return uint64(key)
+	}
+	ht := NewTypedHashTable[int, int](8, "chain", hasher)
+
+	ht.Put(1, 10)
+	ht.Put(9, 90) // Same bucket as 1 with 8 slots
+
+	if calls == 0 {
+		t.Error("Custom hasher was not used")
+	}
+	if ht.hash(1) != ht.hash(9) {
+		t.Error("Expected keys 1 and 9 to share a bucket")
+	}
+	if val, ok := ht.Get(9); !ok || val != 90 {
+		t.Error("Failed to get colliding key 9")
+	}
+}
+
+// TestMapHasherCompositeKeys tests that composite keys that compare equal hash equally
+func TestMapHasherCompositeKeys(t *testing.T) {
+	type point struct {
+		X, Y float64
+		Tag  any
+		Ref  *int
+	}
+	shared := new(int)
+	negativeZero := math.Copysign(0, -1)
+
+	hasher := NewMapHasher[point]()
+	equal := [][2]point{
+		{{X: negativeZero, Y: 1, Ref: shared}, {X: 0, Y: 1, Ref: shared}},
+		{{Tag: [2]float64{negativeZero, 2}}, {Tag: [2]float64{0, 2}}},
+		{{Tag: "label"}, {Tag: "label"}},
+	}
+	for _, pair := range equal {
+		if pair[0] != pair[1] {
+			t.Fatalf("Test keys %v and %v should be equal", pair[0], pair[1])
+		}
+		if hasher(pair[0]) != hasher(pair[1]) {
+			t.Errorf("Equal keys %+v and %+v hash differently", pair[0], pair[1])
+		}
+	}
+	if hasher(point{Ref: shared}) == hasher(point{Ref: new(int)}) {
+		t.Error("Keys pointing at different variables should not collide")
+	}
+
+	ht := NewTypedHashTable[point, string](8, "chain", hasher)
+	ht.Put(point{X: negativeZero, Ref: shared}, "origin")
+	if value, ok := ht.Get(point{X: 0, Ref: shared}); !ok || value != "origin" {
+		t.Errorf("Expected to find the key through +0, got %q, %v", value, ok)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for an unhashable interface value")
+		}
+	}()
+	NewMapHasher[any]()([]int{1})
+}
+
+// TestMapHasherTypedKeys tests that scalar and byte array keys hash by value without allocating
+func TestMapHasherTypedKeys(t *testing.T) {
+	type userID string
+	type digest [16]byte
+
+	stringHasher := NewMapHasher[userID]()
+	name := strings.Repeat("user-", 4)
+	if stringHasher(userID(name)) != stringHasher(userID(strings.Clone(name))) {
+		t.Error("Equal string keys hash differently")
+	}
+	intHasher := NewMapHasher[int16]()
+	if intHasher(-1) == intHasher(1) {
+		t.Error("Keys -1 and 1 should not collide")
+	}
+	floatHasher := NewMapHasher[float32]()
+	if floatHasher(float32(math.Copysign(0, -1))) != floatHasher(0) {
+		t.Error("Keys -0 and +0 should hash equally")
+	}
+	digestHasher := NewMapHasher[digest]()
+	if digestHasher(digest{1, 2}) != digestHasher(digest{1, 2}) || digestHasher(digest{1, 2}) == digestHasher(digest{2, 1}) {
+		t.Error("Byte array keys should hash by their contents")
+	}
+
+	key, number, sum := userID(name), int16(300), digest{7}
+	allocs := testing.AllocsPerRun(100, func() {
+		stringHasher(key)
+		intHasher(number)
+		digestHasher(sum)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected typed keys to hash without allocating, got %v allocations", allocs)
+	}
+}
+
+// TestHashTableStrategies compares every strategy against a builtin map under mixed operations
+func TestHashTableStrategies(t *testing.T) {
+	strategies := []Strategy{LinearProbing, Chaining, QuadraticProbing, DoubleHashing, RobinHood, Cuckoo}
+
+	for _, strategy := range strategies {
+		t.Run(string(strategy), func(t *testing.T) {
+			ht := NewTypedHashTable[int, int](8, strategy, nil)
+			expected := make(map[int]int)
+
+			for i := 0; i < 5000; i++ {
+				key := (i * 7919) % 1500
+				switch i % 3 {
+				case 0, 1:
+					ht.Put(key, i)
+					expected[key] = i
+				case 2:
+					_, exists := expected[key]
+					if removed := ht.Remove(key); removed != exists {
+						t.Fatalf("Remove(%d) = %v, expected %v", key, removed, exists)
+					}
+					delete(expected, key)
+				}
+			}
+
+			if ht.Size != len(expected) {
+				t.Errorf("Expected size %d, got %d", len(expected), ht.Size)
+			}
+			for key, value := range expected {
+				if got, ok := ht.Get(key); !ok || got != value {
+					t.Errorf("Get(%d) = %v, %v; expected %v", key, got, ok, value)
+				}
+			}
+			for key := 1500; key < 1600; key++ {
+				if _, ok := ht.Get(key); ok {
+					t.Errorf("Unexpected key %d", key)
+				}
+			}
+		})
+	}
+}
+
+// TestHashTableRobinHoodBackwardShift tests that deletions leave no tombstones and keep displacement minimal
+func TestHashTableRobinHoodBackwardShift(t *testing.T) {
+	ht := NewTypedHashTable[int, int](16, RobinHood, func(k int) uint64 { return uint64(k / 10) })
+
+	// Keys 0..3 share home slot 0, keys 10..11 share home slot 1
+	for _, k := range []int{0, 1, 2, 3, 10, 11} {
+		ht.Put(k, k)
+	}
+	ht.Remove(1)
+
+	if ht.tombstones != 0 {
+		t.Errorf("Robin Hood deletion should not leave tombstones, got %d", ht.tombstones)
+	}
+	for _, k := range []int{0, 2, 3, 10, 11} {
+		if v, ok := ht.Get(k); !ok || v != k {
+			t.Errorf("Key %d lost after backward shift", k)
+		}
+	}
+	// After the shift the cluster is contiguous again: 0 2 3 10 11 in slots 0..4
+	for i := 0; i < 5; i++ {
+		if ht.Table[i] == nil {
+			t.Errorf("Expected slot %d to be occupied after backward shift", i)
+		}
+	}
+	if ht.Table[5] != nil {
+		t.Error("Expected slot 5 to be empty after backward shift")
+	}
+}
+
+// TestHashTableProbeStats tests probe statistics collection
+func TestHashTableProbeStats(t *testing.T) {
+	ht := NewTypedHashTable[int, int](64, LinearProbing, func(k int) uint64 { return 0 })
+
+	for i := 0; i < 4; i++ {
+		ht.Put(i, i)
+	}
+	if stats := ht.ProbeStats(); stats.Operations != 0 {
+		t.Errorf("Expected no stats while disabled, got %+v", stats)
+	}
+	ht.SetProbeStatsEnabled(true)
+
+	ht.Get(3) // Fourth slot of the cluster
+	stats := ht.ProbeStats()
+	if stats.Operations != 1 || stats.TotalProbes != 4 || stats.MaxProbes != 4 {
+		t.Errorf("Unexpected stats after one lookup: %+v", stats)
+	}
+
+	ht.Get(0)
+	stats = ht.ProbeStats()
+	if stats.MeanProbes() != 2.5 {
+		t.Errorf("Expected mean of 2.5 probes, got %v", stats.MeanProbes())
+	}
+
+	ht.ResetProbeStats()
+	if stats = ht.ProbeStats(); stats.Operations != 0 || stats.MeanProbes() != 0 {
+		t.Errorf("Expected empty stats after reset, got %+v", stats)
+	}
+
+	ht.SetProbeStatsEnabled(false)
+	ht.Get(3)
+	if stats = ht.ProbeStats(); stats.Operations != 0 {
+		t.Errorf("Expected no stats after disabling, got %+v", stats)
+	}
+}
+
+// TestHashTableIteration tests All, Keys, Values and Len, skipping tombstones
+func TestHashTableIteration(t *testing.T) {
+	strategies := []Strategy{LinearProbing, Chaining, QuadraticProbing, DoubleHashing, RobinHood, Cuckoo}
+
+	for _, strategy := range strategies {
+		ht := NewTypedHashTable[int, string](8, strategy, nil)
+		for i := 0; i < 50; i++ {
+			ht.Put(i, fmt.Sprintf("v%d", i))
+		}
+		for i := 0; i < 50; i += 2 {
+			ht.Remove(i) // Leaves tombstones under open addressing
+		}
+		if ht.Len() != 25 {
+			t.Errorf("%s: expected Len 25, got %d", strategy, ht.Len())
+		}
+
+		seen := make(map[int]string)
+		for key, value := range ht.All() {
+			seen[key] = value
+		}
+		if len(seen) != 25 {
+			t.Errorf("%s: All yielded %d entries, expected 25", strategy, len(seen))
+		}
+		for key, value := range seen {
+			if key%2 == 0 || value != fmt.Sprintf("v%d", key) {
+				t.Errorf("%s: unexpected entry %d=%s", strategy, key, value)
+			}
+		}
+
+		keys, values := 0, 0
+		for key := range ht.Keys() {
+			if key%2 == 0 {
+				t.Errorf("%s: Keys yielded removed key %d", strategy, key)
+			}
+			keys++
+		}
+		for range ht.Values() {
+			values++
+		}
+		if keys != 25 || values != 25 {
+			t.Errorf("%s: expected 25 keys and values, got %d and %d", strategy, keys, values)
+		}
+	}
+}
+
+// TestHashTableModifyDuringIteration tests that the loop body may modify the table
+func TestHashTableModifyDuringIteration(t *testing.T) {
+	ht := NewTypedHashTable[int, int](8, RobinHood, nil)
+	for i := 0; i < 20; i++ {
+		ht.Put(i, i)
+	}
+
+	visited := 0
+	for key := range ht.All() {
+		ht.Remove(key)
+		ht.Put(key+100, key) // Not seen by the running iteration
+		visited++
+	}
+	if visited != 20 {
+		t.Errorf("Expected to visit the 20 snapshotted entries, visited %d", visited)
+	}
+	if _, ok := ht.Get(5); ok || ht.Len() != 20 {
+		t.Errorf("Expected 20 moved entries, got %d", ht.Len())
+	}
+
+	// Early exit
+	count := 0
+	for range ht.Keys() {
+		count++
+		if count == 3 {
+			break
+		}
+	}
+	if count != 3 {
+		t.Errorf("Expected to stop after 3 keys, got %d", count)
+	}
+}
+
+// TestHashTableClearAndClone tests Clear and Clone
+func TestHashTableClearAndClone(t *testing.T) {
+	for _, strategy := range []Strategy{LinearProbing, Chaining, Cuckoo} {
+		ht := NewTypedHashTable[string, int](8, strategy, nil)
+		for i := 0; i < 100; i++ {
+			ht.Put(fmt.Sprintf("k%d", i), i)
+		}
+
+		clone := ht.Clone()
+		clone.Put("k0", -1)
+		clone.Remove("k1")
+		if value, _ := ht.Get("k0"); value != 0 {
+			t.Errorf("%s: modifying the clone changed the original", strategy)
+		}
+		if _, ok := ht.Get("k1"); !ok {
+			t.Errorf("%s: removing from the clone removed from the original", strategy)
+		}
+		if clone.Len() != 99 {
+			t.Errorf("%s: expected clone Len 99, got %d", strategy, clone.Len())
+		}
+		for i := 2; i < 100; i++ {
+			if value, ok := clone.Get(fmt.Sprintf("k%d", i)); !ok || value != i {
+				t.Errorf("%s: clone is missing k%d", strategy, i)
+			}
+		}
+
+		ht.Clear()
+		if ht.Len() != 0 || ht.Capacity != 8 {
+			t.Errorf("%s: expected an empty table of capacity 8, got %d entries in %d slots", strategy, ht.Len(), ht.Capacity)
+		}
+		if _, ok := ht.Get("k5"); ok {
+			t.Errorf("%s: cleared table still contains k5", strategy)
+		}
+		ht.Put("again", 1)
+		if value, ok := ht.Get("again"); !ok || value != 1 {
+			t.Errorf("%s: table unusable after Clear", strategy)
+		}
+		if clone.Len() != 99 {
+			t.Errorf("%s: clearing the original changed the clone", strategy)
+		}
+	}
+}
@@ -0,0 +1,313 @@
+package hash
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+	"slices"
+	"sync"
+)
+
+const (
+	// MinPrecision and MaxPrecision bound the number of index bits of a HyperLogLog
+	MinPrecision = 4
+	MaxPrecision = 18
+	// sparsePrecision is the index width used while the sketch is sparse (HLL++)
+	sparsePrecision = 25
+	// sparseRankBits holds a rank of up to 64-sparsePrecision+1 below the index of a packed
+	// sparse entry
+	sparseRankBits = 6
+	// sparseEntryBytes is the size of one packed sparse entry; a dense register is one byte
+	sparseEntryBytes = 4
+)
+
+// ErrPrecisionMismatch is returned when merging HyperLogLog sketches of different precision
+var ErrPrecisionMismatch = errors.New("hyperloglog sketches differ in precision")
+
+// HyperLogLog estimates the number of distinct elements in a stream using
+// 2^precision small registers (HyperLogLog++, Heule et al.). Small sets are kept as a sorted
+// list of packed entries with a finer index, plus a small buffer of recent entries that is
+// merged in when it fills, and converted to dense registers once those would be smaller.
+// Dense estimates use Ertl's improved estimator, which needs no empirical bias tables.
+type HyperLogLog struct {
+	precision uint8
+	registers []uint8  // dense registers, nil while sparse
+	sparse    []uint32 // sorted packed entries: sparse index (25 bits) << sparseRankBits | rank
+	pending   []uint32 // unsorted packed entries not yet merged into sparse
+	mutex     sync.RWMutex
+}
+
+// NewHyperLogLog creates a sketch with 2^precision registers; the standard error is
+// about 1.04/sqrt(2^precision). Precision is clamped to [MinPrecision, MaxPrecision].
+func NewHyperLogLog(precision uint8) *HyperLogLog {
+	if precision < MinPrecision {
+		precision = MinPrecision
+	}
+	if precision > MaxPrecision {
+		precision = MaxPrecision
+	}
+	return &HyperLogLog{
+		precision: precision,
+		mutex:     sync.RWMutex{},
+	}
+}
+
+// rank returns the position of the leftmost 1-bit among the top width bits of x, or width+1 if none
+func rank(x uint64, width uint8) uint8 {
+	r := uint8(bits.LeadingZeros64(x)) + 1
+	if r > width+1 {
+		r = width + 1
+	}
+	return r
+}
+
+// Add records an element
+func (hll *HyperLogLog) Add(data []byte) {
+	h, _ := digest128(data)
+
+	hll.mutex.Lock()
+	defer hll.mutex.Unlock()
+
+	if hll.registers != nil {
+		hll.addDense(h)
+		return
+	}
+
+	index := uint32(h >> (64 - sparsePrecision))
+	r := rank(h<<sparsePrecision, 64-sparsePrecision)
+	hll.pending = append(hll.pending, packSparse(index, r))
+	if len(hll.pending) >= hll.pendingLimit() {
+		hll.compact()
+	}
+}
+
+// packSparse packs a sparse index and rank into one entry; entries sort by index, then rank
+func packSparse(index uint32, r uint8) uint32 {
+	return index<<sparseRankBits | uint32(r)
+}
+
+// unpackSparse splits a packed entry into its sparse index and rank
+func unpackSparse(entry uint32) (uint32, uint8) {
+	return entry >> sparseRankBits, uint8(entry & (1<<sparseRankBits - 1))
+}
+
+// mergeSparse returns a new sorted list holding the entries of a sorted list and of unsorted
+// extra entries, keeping the highest rank of each index
+func mergeSparse(sorted, extra []uint32) []uint32 {
+	extra = slices.Clone(extra)
+	slices.Sort(extra)
+
+	merged := make([]uint32, 0, len(sorted)+len(extra))
+	i, j := 0, 0
+	for i < len(sorted) || j < len(extra) {
+		var next uint32
+		if j == len(extra) || i < len(sorted) && sorted[i] < extra[j] {
+			next, i = sorted[i], i+1
+		} else {
+			next, j = extra[j], j+1
+		}
+		// Equal indexes arrive in increasing rank, so the later entry wins
+		if n := len(merged); n > 0 && merged[n-1]>>sparseRankBits == next>>sparseRankBits {
+			merged[n-1] = next
+		} else {
+			merged = append(merged, next)
+		}
+	}
+	return merged
+}
+
+// compact merges the pending entries into the sorted list, switching to dense registers once
+// they would be smaller
+func (hll *HyperLogLog) compact() {
+	hll.sparse = mergeSparse(hll.sparse, hll.pending)
+	hll.pending = hll.pending[:0]
+	if len(hll.sparse) > hll.sparseLimit() {
+		hll.toDense()
+	}
+}
+
+// addDense updates the dense register for hash h
+func (hll *HyperLogLog) addDense(h uint64) {
+	index := h >> (64 - hll.precision)
+	r := rank(h<<hll.precision, 64-hll.precision)
+	if r > hll.registers[index] {
+		hll.registers[index] = r
+	}
+}
+
+// sparseLimit is the number of sorted entries beyond which the 2^precision one-byte dense
+// registers are smaller than the sorted list and a full pending buffer together
+func (hll *HyperLogLog) sparseLimit() int {
+	return (1<<hll.precision)/sparseEntryBytes - hll.pendingLimit()
+}
+
+// pendingLimit is the size of the pending buffer: a quarter of the sparse budget, so that
+// sorting it stays cheap next to the merge
+func (hll *HyperLogLog) pendingLimit() int {
+	return (1 << hll.precision) / (4 * sparseEntryBytes)
+}
+
+// denseEntry converts a sparse entry into a dense register index and rank
+func (hll *HyperLogLog) denseEntry(index uint32, r uint8) (uint32, uint8) {
+	shift := sparsePrecision - hll.precision
+	denseIndex := index >> shift
+	// The bits between the two precisions become the start of the dense rank's bit string
+	if extra := uint64(index) & (1<<shift - 1); extra != 0 {
+		return denseIndex, rank(extra<<(64-shift), shift)
+	}
+	return denseIndex, shift + r
+}
+
+// toDense converts the sparse representation into registers
+func (hll *HyperLogLog) toDense() {
+	hll.registers = make([]uint8, 1<<hll.precision)
+	for _, entry := range hll.sparse {
+		hll.foldSparse(entry)
+	}
+	for _, entry := range hll.pending {
+		hll.foldSparse(entry)
+	}
+	hll.sparse, hll.pending = nil, nil
+}
+
+// foldSparse merges one packed sparse entry into the dense registers
+func (hll *HyperLogLog) foldSparse(entry uint32) {
+	denseIndex, denseRank := hll.denseEntry(unpackSparse(entry))
+	if denseRank > hll.registers[denseIndex] {
+		hll.registers[denseIndex] = denseRank
+	}
+}
+
+// Count returns the estimated number of distinct elements
+func (hll *HyperLogLog) Count() uint64 {
+	hll.mutex.RLock()
+	defer hll.mutex.RUnlock()
+
+	if hll.registers == nil {
+		// Linear counting over the 2^25 sparse buckets is accurate while the sketch is sparse
+		used := len(hll.sparse)
+		if len(hll.pending) > 0 {
+			used = len(mergeSparse(hll.sparse, hll.pending))
+		}
+		m := float64(uint64(1) << sparsePrecision)
+		return uint64(math.Round(m * math.Log(m/(m-float64(used)))))
+	}
+	return uint64(math.Round(ertlEstimate(hll.registers, hll.precision)))
+}
+
+// ertlEstimate implements the improved raw estimator from Ertl,
+// "New cardinality estimation algorithms for HyperLogLog sketches" (2017)
+func ertlEstimate(registers []uint8, precision uint8) float64 {
+	q := int(64 - precision)
+	histogram := make([]int, q+2)
+	for _, r := range registers {
+		histogram[r]++
+	}
+
+	m := float64(len(registers))
+	z := m * hllTau(1-float64(histogram[q+1])/m)
+	for k := q; k >= 1; k-- {
+		z = 0.5 * (z + float64(histogram[k]))
+	}
+	z += m * hllSigma(float64(histogram[0])/m)
+	return m * m / (2 * math.Ln2 * z)
+}
+
+// hllSigma computes x + sum_{k>=1} x^(2^k) 2^(k-1)
+func hllSigma(x float64) float64 {
+	if x == 1 {
+		return math.Inf(1)
+	}
+	y, z := 1.0, x
+	for {
+		x *= x
+		previous := z
+		z += x * y
+		y += y
+		if z == previous {
+			return z
+		}
+	}
+}
+
+// hllTau computes (1 - x - sum_{k>=1} (1 - x^(2^-k))^2 2^-k) / 3
+func hllTau(x float64) float64 {
+	if x == 0 || x == 1 {
+		return 0
+	}
+	y, z := 1.0, 1-x
+	for {
+		x = math.Sqrt(x)
+		previous := z
+		y *= 0.5
+		z -= (1 - x) * (1 - x) * y
+		if z == previous {
+			return z / 3
+		}
+	}
+}
+
+// Merge folds another sketch into this one, so that Count estimates the size of the union
+func (hll *HyperLogLog) Merge(other *HyperLogLog) error {
+	if hll == other {
+		return nil
+	}
+
+	other.mutex.RLock()
+	precision := other.precision
+	var registers []uint8
+	var sparse []uint32
+	if other.registers != nil {
+		registers = append([]uint8(nil), other.registers...)
+	} else {
+		sparse = append(slices.Clone(other.sparse), other.pending...)
+	}
+	other.mutex.RUnlock()
+
+	hll.mutex.Lock()
+	defer hll.mutex.Unlock()
+
+	if precision != hll.precision {
+		return ErrPrecisionMismatch
+	}
+
+	if registers != nil && hll.registers == nil {
+		hll.toDense()
+	}
+	if hll.registers != nil {
+		for i, r := range registers {
+			if r > hll.registers[i] {
+				hll.registers[i] = r
+			}
+		}
+		for _, entry := range sparse {
+			hll.foldSparse(entry)
+		}
+		return nil
+	}
+
+	hll.pending = append(hll.pending, sparse...)
+	hll.compact()
+	return nil
+}
+
+// Precision returns the number of index bits
+func (hll *HyperLogLog) Precision() uint8 {
+	return hll.precision
+}
+
+// IsSparse reports whether the sketch still uses the sparse representation
+func (hll *HyperLogLog) IsSparse() bool {
+	hll.mutex.RLock()
+	defer hll.mutex.RUnlock()
+	return hll.registers == nil
+}
+
+// Clear resets the sketch to an empty sparse representation
+func (hll *HyperLogLog) Clear() {
+	hll.mutex.Lock()
+	defer hll.mutex.Unlock()
+
+	hll.registers = nil
+	hll.sparse, hll.pending = nil, nil
+}
@@ -0,0 +1,123 @@
+package hash
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// TestHyperLogLogAccuracy tests estimates across sparse and dense ranges
+func TestHyperLogLogAccuracy(t *testing.T) {
+	for _, n := range []int{0, 10, 1000, 20000, 200000} {
+		hll := NewHyperLogLog(14)
+		for i := 0; i < n; i++ {
+			hll.Add([]byte(fmt.Sprintf("visitor-%d", i)))
+		}
+		// Duplicates must not change the estimate
+		for i := 0; i < n/2; i++ {
+			hll.Add([]byte(fmt.Sprintf("visitor-%d", i)))
+		}
+
+		estimate := float64(hll.Count())
+		if n == 0 {
+			if estimate != 0 {
+				t.Errorf("Expected 0 for an empty sketch, got %f", estimate)
+			}
+			continue
+		}
+		// Standard error at precision 14 is about 0.8%; allow 4 standard errors
+		if relative := math.Abs(estimate-float64(n)) / float64(n); relative > 0.035 {
+			t.Errorf("n=%d: estimate %f is off by %.2f%%", n, estimate, relative*100)
+		}
+	}
+}
+
+// TestHyperLogLogSparseToDense tests the switch from sparse to dense registers
+func TestHyperLogLogSparseToDense(t *testing.T) {
+	hll := NewHyperLogLog(10)
+	if !hll.IsSparse() {
+		t.Fatal("A new sketch should be sparse")
+	}
+	for i := 0; i < 100; i++ {
+		hll.Add([]byte(fmt.Sprintf("item-%d", i)))
+	}
+	if !hll.IsSparse() {
+		t.Error("Sketch should still be sparse after 100 elements")
+	}
+	before := hll.Count()
+	for i := 100; i < 1000; i++ {
+		hll.Add([]byte(fmt.Sprintf("item-%d", i)))
+	}
+	if hll.IsSparse() {
+		t.Error("Sketch should be dense after 1000 elements")
+	}
+	if before < 95 || before > 105 {
+		t.Errorf("Sparse estimate for 100 elements is %d", before)
+	}
+
+	hll.Clear()
+	if !hll.IsSparse() || hll.Count() != 0 {
+		t.Error("Clear should reset to an empty sparse sketch")
+	}
+}
+
+// TestHyperLogLogSparseSize tests that the sparse entries never take more memory than the
+// dense registers they stand in for
+func TestHyperLogLogSparseSize(t *testing.T) {
+	hll := NewHyperLogLog(12)
+	dense := 1 << hll.Precision()
+	for i := 0; hll.IsSparse(); i++ {
+		if size := sparseEntryBytes * (len(hll.sparse) + len(hll.pending)); size > dense {
+			t.Fatalf("After %d elements the sparse entries take %d bytes, more than %d dense registers", i, size, dense)
+		}
+		if i > dense {
+			t.Fatal("Sketch stayed sparse past 2^precision elements")
+		}
+		hll.Add([]byte(fmt.Sprintf("item-%d", i)))
+	}
+
+	// Pending duplicates count once
+	small := NewHyperLogLog(14)
+	for i := 0; i < 3; i++ {
+		small.Add([]byte("same"))
+	}
+	if small.Count() != 1 {
+		t.Errorf("Expected 1 distinct element, got %d", small.Count())
+	}
+}
+
+// TestHyperLogLogMerge tests merging sketches in every representation combination
+func TestHyperLogLogMerge(t *testing.T) {
+	build := func(from, to int) *HyperLogLog {
+		hll := NewHyperLogLog(12)
+		for i := from; i < to; i++ {
+			hll.Add([]byte(fmt.Sprintf("user-%d", i)))
+		}
+		return hll
+	}
+
+	cases := []struct {
+		name             string
+		a, b             [2]int
+		expectedDistinct int
+	}{
+		{"sparse+sparse", [2]int{0, 300}, [2]int{200, 500}, 500},
+		{"sparse+dense", [2]int{0, 300}, [2]int{100, 20100}, 20100},
+		{"dense+sparse", [2]int{0, 20000}, [2]int{19900, 20200}, 20200},
+		{"dense+dense", [2]int{0, 20000}, [2]int{10000, 30000}, 30000},
+	}
+	for _, tc := range cases {
+		a, b := build(tc.a[0], tc.a[1]), build(tc.b[0], tc.b[1])
+		if err := a.Merge(b); err != nil {
+			t.Fatalf("%s: merge failed: %v", tc.name, err)
+		}
+		estimate := float64(a.Count())
+		if relative := math.Abs(estimate-float64(tc.expectedDistinct)) / float64(tc.expectedDistinct); relative > 0.07 {
+			t.Errorf("%s: estimate %f for %d distinct elements", tc.name, estimate, tc.expectedDistinct)
+		}
+	}
+
+	if err := NewHyperLogLog(10).Merge(NewHyperLogLog(12)); err != ErrPrecisionMismatch {
+		t.Errorf("Expected ErrPrecisionMismatch, got %v", err)
+	}
+}
@@ -0,0 +1,21 @@
+package hash
+
+// MembershipFilter is an approximate set-membership structure. Contains may report
+// false positives but never false negatives for elements that were added (and not removed).
+type MembershipFilter interface {
+	// Add inserts an element and reports whether it was stored
+	Add(data []byte) bool
+	// Contains reports whether an element may be in the set
+	Contains(data []byte) bool
+	// Clear removes every element
+	Clear()
+}
+
+var (
+	_ MembershipFilter = (*BloomFilter)(nil)
+	_ MembershipFilter = (*CountingBloomFilter)(nil)
+	_ MembershipFilter = (*ScalableBloomFilter)(nil)
+	_ MembershipFilter = (*DeletableBloomFilter)(nil)
+	_ MembershipFilter = (*CuckooFilter)(nil)
+	_ MembershipFilter = (*QuotientFilter)(nil)
+)
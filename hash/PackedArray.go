@@ -0,0 +1,54 @@
+package hash
+
+// packedArray stores fixed-width unsigned integers back to back in 64-bit words,
+// so that small fingerprints do not waste a whole machine word each
+type packedArray struct {
+	words  []uint64
+	width  uint // bits per element, 1..64
+	mask   uint64
+	length uint
+}
+
+// newPackedArray creates an array of length zeroed elements of the given bit width
+func newPackedArray(length, width uint) packedArray {
+	mask := uint64(1)<<width - 1
+	if width == 64 {
+		mask = ^uint64(0)
+	}
+	return packedArray{
+		words:  make([]uint64, (length*width+63)/64),
+		width:  width,
+		mask:   mask,
+		length: length,
+	}
+}
+
+// get returns element i
+func (a *packedArray) get(i uint) uint64 {
+	bitPos := i * a.width
+	word, offset := bitPos/64, bitPos%64
+
+	value := a.words[word] >> offset
+	if spill := int(offset+a.width) - 64; spill > 0 {
+		value |= a.words[word+1] << (a.width - uint(spill))
+	}
+	return value & a.mask
+}
+
+// set stores the low width bits of value as element i
+func (a *packedArray) set(i uint, value uint64) {
+	value &= a.mask
+	bitPos := i * a.width
+	word, offset := bitPos/64, bitPos%64
+
+	a.words[word] = a.words[word]&^(a.mask<<offset) | value<<offset
+	if spill := int(offset+a.width) - 64; spill > 0 {
+		spillMask := uint64(1)<<uint(spill) - 1
+		a.words[word+1] = a.words[word+1]&^spillMask | value>>(a.width-uint(spill))
+	}
+}
+
+// reset zeroes every element
+func (a *packedArray) reset() {
+	clear(a.words)
+}
@@ -0,0 +1,468 @@
+package hash
+
+import (
+	"errors"
+	"math"
+	"sync"
+)
+
+// Quotient filter slot metadata bits, stored below the remainder
+const (
+	qfOccupied     = 1 << 0 // some element has this slot as its canonical slot
+	qfContinuation = 1 << 1 // the slot continues the run of the previous slot
+	qfShifted      = 1 << 2 // the remainder is not in its canonical slot
+	qfMetaBits     = 3
+	qfMaxLoad      = 0.75
+)
+
+var (
+	// ErrFingerprintMismatch is returned when merging quotient filters with different fingerprint widths
+	ErrFingerprintMismatch = errors.New("quotient filters use different fingerprint sizes")
+	// ErrCannotResize is returned when a quotient filter has no remainder bits left to give up
+	ErrCannotResize = errors.New("quotient filter remainder too small to resize")
+)
+
+// QuotientFilter is an approximate set that stores p-bit fingerprints compactly
+// (Bender et al., "Don't Thrash: How to Cache Your Hash on Flash"). The top q bits of a
+// fingerprint select a slot and the remaining r bits are stored in it, kept sorted in
+// runs with three metadata bits per slot. Because full fingerprints can be recovered,
+// the filter can be resized and merged without access to the original elements.
+type QuotientFilter struct {
+	slots     packedArray // 2^q slots of r+3 bits
+	qBits     uint
+	rBits     uint
+	indexMask uint64
+	count     int
+	mutex     sync.RWMutex
+}
+
+// NewQuotientFilter creates a quotient filter sized for expectedElements at the given false positive rate
+func NewQuotientFilter(expectedElements int, falsePositiveRate float64) *QuotientFilter {
+	if expectedElements < 1 {
+		expectedElements = 1
+	}
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		falsePositiveRate = 0.01
+	}
+	qBits := uint(math.Ceil(math.Log2(float64(expectedElements) / qfMaxLoad)))
+	rBits := uint(math.Ceil(math.Log2(1 / falsePositiveRate)))
+	if qBits < 1 {
+		qBits = 1
+	}
+	if rBits < 1 {
+		rBits = 1
+	}
+	for qBits+rBits > 64 {
+		rBits--
+	}
+	return NewQuotientFilterWithBits(qBits, rBits)
+}
+
+// NewQuotientFilterWithBits creates a quotient filter with 2^qBits slots holding rBits-bit remainders.
+// qBits+rBits must not exceed 64 and rBits must not exceed 61.
+func NewQuotientFilterWithBits(qBits, rBits uint) *QuotientFilter {
+	if qBits < 1 || rBits < 1 || qBits+rBits > 64 || rBits > 64-qfMetaBits {
+		panic("invalid quotient filter dimensions")
+	}
+	return &QuotientFilter{
+		slots:     newPackedArray(1<<qBits, rBits+qfMetaBits),
+		qBits:     qBits,
+		rBits:     rBits,
+		indexMask: 1<<qBits - 1,
+		mutex:     sync.RWMutex{},
+	}
+}
+
+// fingerprint returns the (q+r)-bit fingerprint of an element
+func (qf *QuotientFilter) fingerprint(data []byte) uint64 {
+	h, _ := digest128(data)
+	if width := qf.qBits + qf.rBits; width < 64 {
+		h &= 1<<width - 1
+	}
+	return h
+}
+
+// split divides a fingerprint into its quotient and remainder
+func (qf *QuotientFilter) split(fingerprint uint64) (uint64, uint64) {
+	return fingerprint >> qf.rBits, fingerprint & (1<<qf.rBits - 1)
+}
+
+// get returns the slot entry at index i
+func (qf *QuotientFilter) get(i uint64) uint64 {
+	return qf.slots.get(uint(i))
+}
+
+// set stores a slot entry at index i
+func (qf *QuotientFilter) set(i, entry uint64) {
+	qf.slots.set(uint(i), entry)
+}
+
+// next returns the slot after i, wrapping around the table
+func (qf *QuotientFilter) next(i uint64) uint64 {
+	return (i + 1) & qf.indexMask
+}
+
+// prev returns the slot before i, wrapping around the table
+func (qf *QuotientFilter) prev(i uint64) uint64 {
+	return (i - 1) & qf.indexMask
+}
+
+// remainderOf extracts the remainder stored in a slot entry
+func remainderOf(entry uint64) uint64 {
+	return entry >> qfMetaBits
+}
+
+// isEmptySlot reports whether a slot holds nothing; every used slot has a metadata bit set
+func isEmptySlot(entry uint64) bool {
+	return entry&(qfOccupied|qfContinuation|qfShifted) == 0
+}
+
+// isRunStart reports whether a slot holds the first remainder of a run
+func isRunStart(entry uint64) bool {
+	return entry&qfContinuation == 0 && entry&(qfOccupied|qfShifted) != 0
+}
+
+// isClusterStart reports whether a slot holds the first remainder of a cluster
+func isClusterStart(entry uint64) bool {
+	return entry&qfOccupied != 0 && entry&(qfContinuation|qfShifted) == 0
+}
+
+// findRunStart returns the slot holding the first remainder of the run for quotient fq
+func (qf *QuotientFilter) findRunStart(fq uint64) uint64 {
+	// Walk back to the start of the cluster
+	b := fq
+	for qf.get(b)&qfShifted != 0 {
+		b = qf.prev(b)
+	}
+	// Walk forward run by run until reaching the run of fq
+	s := b
+	for b != fq {
+		for {
+			s = qf.next(s)
+			if qf.get(s)&qfContinuation == 0 {
+				break
+			}
+		}
+		for {
+			b = qf.next(b)
+			if qf.get(b)&qfOccupied != 0 {
+				break
+			}
+		}
+	}
+	return s
+}
+
+// insertAt stores entry at slot s, shifting the rest of the cluster right by one.
+// Occupied bits stay with their slots rather than moving with the remainders.
+func (qf *QuotientFilter) insertAt(s, entry uint64) {
+	current := entry
+	for {
+		previous := qf.get(s)
+		empty := isEmptySlot(previous)
+		if !empty {
+			previous |= qfShifted
+			if previous&qfOccupied != 0 {
+				current |= qfOccupied
+				previous &^= qfOccupied
+			}
+		}
+		qf.set(s, current)
+		if empty {
+			return
+		}
+		current = previous
+		s = qf.next(s)
+	}
+}
+
+// insert adds a fingerprint; the caller must hold the write lock and ensure there is a free slot
+func (qf *QuotientFilter) insert(fingerprint uint64) {
+	fq, fr := qf.split(fingerprint)
+	canonical := qf.get(fq)
+	entry := fr << qfMetaBits
+
+	if isEmptySlot(canonical) {
+		qf.set(fq, entry|qfOccupied)
+		qf.count++
+		return
+	}
+	if canonical&qfOccupied == 0 {
+		qf.set(fq, canonical|qfOccupied)
+	}
+
+	start := qf.findRunStart(fq)
+	s := start
+	if canonical&qfOccupied != 0 {
+		// The run exists: find the sorted position of fr within it
+		for {
+			remainder := remainderOf(qf.get(s))
+			if remainder == fr {
+				return // Already present
+			}
+			if remainder > fr {
+				break
+			}
+			s = qf.next(s)
+			if qf.get(s)&qfContinuation == 0 {
+				break
+			}
+		}
+		if s == start {
+			// The old run head becomes a continuation of the new one
+			qf.set(start, qf.get(start)|qfContinuation)
+		} else {
+			entry |= qfContinuation
+		}
+	}
+	if s != fq {
+		entry |= qfShifted
+	}
+	qf.insertAt(s, entry)
+	qf.count++
+}
+
+// Add inserts an element. It returns false when the filter has no free slot;
+// call Resize to make room.
+func (qf *QuotientFilter) Add(data []byte) bool {
+	qf.mutex.Lock()
+	defer qf.mutex.Unlock()
+
+	if uint64(qf.count) > qf.indexMask {
+		return false
+	}
+	qf.insert(qf.fingerprint(data))
+	return true
+}
+
+// Contains reports whether an element may be in the filter
+func (qf *QuotientFilter) Contains(data []byte) bool {
+	qf.mutex.RLock()
+	defer qf.mutex.RUnlock()
+
+	fq, fr := qf.split(qf.fingerprint(data))
+	if qf.get(fq)&qfOccupied == 0 {
+		return false
+	}
+	s := qf.findRunStart(fq)
+	for {
+		remainder := remainderOf(qf.get(s))
+		if remainder == fr {
+			return true
+		}
+		if remainder > fr {
+			return false
+		}
+		s = qf.next(s)
+		if qf.get(s)&qfContinuation == 0 {
+			return false
+		}
+	}
+}
+
+// Delete removes an element's fingerprint and reports whether it was present.
+// Elements sharing the same fingerprint are stored once, so deleting one removes them all.
+func (qf *QuotientFilter) Delete(data []byte) bool {
+	qf.mutex.Lock()
+	defer qf.mutex.Unlock()
+
+	return qf.deleteFingerprint(qf.split(qf.fingerprint(data)))
+}
+
+// deleteFingerprint removes the remainder fr from the run of quotient fq; the caller must hold the write lock
+func (qf *QuotientFilter) deleteFingerprint(fq, fr uint64) bool {
+	if qf.get(fq)&qfOccupied == 0 {
+		return false
+	}
+
+	s := qf.findRunStart(fq)
+	for {
+		remainder := remainderOf(qf.get(s))
+		if remainder == fr {
+			break
+		}
+		if remainder > fr {
+			return false
+		}
+		s = qf.next(s)
+		if qf.get(s)&qfContinuation == 0 {
+			return false
+		}
+	}
+
+	kill := qf.get(s)
+	replaceRunStart := isRunStart(kill)
+	if replaceRunStart && qf.get(qf.next(s))&qfContinuation == 0 {
+		// Deleting the only remainder of the run: fq no longer has a run.
+		// Reload when s == fq, since the slot's own occupied bit is part of kill.
+		qf.set(fq, qf.get(fq)&^qfOccupied)
+	}
+	qf.deleteAt(s, fq)
+
+	if replaceRunStart {
+		head := qf.get(s)
+		updated := head &^ qfContinuation
+		if s == fq && isRunStart(updated) {
+			updated &^= qfShifted // The new run head sits in its canonical slot
+		}
+		if updated != head {
+			qf.set(s, updated)
+		}
+	}
+	qf.count--
+	return true
+}
+
+// deleteAt removes the remainder at slot s and shifts the rest of the cluster left,
+// clearing shifted bits of runs that slide back into their canonical slots
+func (qf *QuotientFilter) deleteAt(s, quotient uint64) {
+	current := qf.get(s)
+	origin := s
+	sp := qf.next(s)
+	for {
+		following := qf.get(sp)
+		occupied := current&qfOccupied != 0
+		if isEmptySlot(following) || isClusterStart(following) || sp == origin {
+			qf.set(s, 0)
+			return
+		}
+
+		updated := following
+		if isRunStart(following) {
+			// The next run belongs to the next occupied quotient
+			for {
+				quotient = qf.next(quotient)
+				if qf.get(quotient)&qfOccupied != 0 {
+					break
+				}
+			}
+			if occupied && quotient == s {
+				updated &^= qfShifted
+			}
+		}
+		if occupied {
+			updated |= qfOccupied
+		} else {
+			updated &^= qfOccupied
+		}
+		qf.set(s, updated)
+
+		s = sp
+		sp = qf.next(sp)
+		current = following
+	}
+}
+
+// fingerprints returns every stored fingerprint in ascending order
+func (qf *QuotientFilter) fingerprints() []uint64 {
+	result := make([]uint64, 0, qf.count)
+	for fq := uint64(0); fq <= qf.indexMask; fq++ {
+		if qf.get(fq)&qfOccupied == 0 {
+			continue
+		}
+		s := qf.findRunStart(fq)
+		for {
+			result = append(result, fq<<qf.rBits|remainderOf(qf.get(s)))
+			s = qf.next(s)
+			if qf.get(s)&qfContinuation == 0 {
+				break
+			}
+		}
+	}
+	return result
+}
+
+// rebuild re-inserts fingerprints into a table with 2^qBits slots, keeping q+r constant
+func (qf *QuotientFilter) rebuild(qBits uint, fingerprints []uint64) {
+	qf.rBits = qf.qBits + qf.rBits - qBits
+	qf.qBits = qBits
+	qf.indexMask = 1<<qBits - 1
+	qf.slots = newPackedArray(1<<qBits, qf.rBits+qfMetaBits)
+	qf.count = 0
+	for _, fingerprint := range fingerprints {
+		qf.insert(fingerprint)
+	}
+}
+
+// Resize doubles the number of slots, moving one bit of every fingerprint from the
+// remainder to the quotient. The false positive rate at a given load roughly doubles.
+func (qf *QuotientFilter) Resize() error {
+	qf.mutex.Lock()
+	defer qf.mutex.Unlock()
+
+	if qf.rBits <= 1 {
+		return ErrCannotResize
+	}
+	qf.rebuild(qf.qBits+1, qf.fingerprints())
+	return nil
+}
+
+// Merge adds every fingerprint of other to the filter, growing it when the combined
+// load would exceed 75%. Both filters must use the same fingerprint width.
+func (qf *QuotientFilter) Merge(other *QuotientFilter) error {
+	if qf == other {
+		return nil
+	}
+
+	other.mutex.RLock()
+	width := other.qBits + other.rBits
+	theirs := other.fingerprints()
+	other.mutex.RUnlock()
+
+	qf.mutex.Lock()
+	defer qf.mutex.Unlock()
+
+	if width != qf.qBits+qf.rBits {
+		return ErrFingerprintMismatch
+	}
+
+	qBits := qf.qBits
+	for float64(qf.count+len(theirs)) > qfMaxLoad*float64(uint64(1)<<qBits) {
+		if qBits+1 >= width {
+			if uint64(qf.count+len(theirs)) > uint64(1)<<qBits {
+				return ErrCannotResize
+			}
+			break
+		}
+		qBits++
+	}
+	qf.rebuild(qBits, append(qf.fingerprints(), theirs...))
+	return nil
+}
+
+// Count returns the number of distinct fingerprints stored
+func (qf *QuotientFilter) Count() int {
+	qf.mutex.RLock()
+	defer qf.mutex.RUnlock()
+	return qf.count
+}
+
+// Capacity returns the number of slots
+func (qf *QuotientFilter) Capacity() int {
+	qf.mutex.RLock()
+	defer qf.mutex.RUnlock()
+	return int(qf.indexMask + 1)
+}
+
+// LoadFactor returns the fraction of occupied slots
+func (qf *QuotientFilter) LoadFactor() float64 {
+	qf.mutex.RLock()
+	defer qf.mutex.RUnlock()
+	return float64(qf.count) / float64(qf.indexMask+1)
+}
+
+// RemainderBits returns the number of fingerprint bits stored per slot
+func (qf *QuotientFilter) RemainderBits() int {
+	qf.mutex.RLock()
+	defer qf.mutex.RUnlock()
+	return int(qf.rBits)
+}
+
+// Clear removes every element from the filter
+func (qf *QuotientFilter) Clear() {
+	qf.mutex.Lock()
+	defer qf.mutex.Unlock()
+
+	qf.slots.reset()
+	qf.count = 0
+}
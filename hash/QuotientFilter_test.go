@@ -0,0 +1,188 @@
+package hash
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// TestQuotientFilterAddDelete tests adding, finding and deleting elements
+func TestQuotientFilterAddDelete(t *testing.T) {
+	qf := NewQuotientFilter(1000, 0.01)
+
+	for i := 0; i < 1000; i++ {
+		if !qf.Add([]byte(fmt.Sprintf("item-%d", i))) {
+			t.Fatalf("Failed to add item-%d", i)
+		}
+	}
+	for i := 0; i < 1000; i++ {
+		if !qf.Contains([]byte(fmt.Sprintf("item-%d", i))) {
+			t.Fatalf("Filter should contain item-%d", i)
+		}
+	}
+
+	for i := 0; i < 500; i++ {
+		qf.Delete([]byte(fmt.Sprintf("item-%d", i)))
+	}
+	for i := 500; i < 1000; i++ {
+		if !qf.Contains([]byte(fmt.Sprintf("item-%d", i))) {
+			t.Errorf("Filter should still contain item-%d", i)
+		}
+	}
+	present := 0
+	for i := 0; i < 500; i++ {
+		if qf.Contains([]byte(fmt.Sprintf("item-%d", i))) {
+			present++
+		}
+	}
+	if present > 10 {
+		t.Errorf("Too many deleted elements still reported present: %d", present)
+	}
+
+	qf.Clear()
+	if qf.Count() != 0 || qf.Contains([]byte("item-700")) {
+		t.Error("Filter should be empty after Clear")
+	}
+}
+
+// TestQuotientFilterInvariants compares the stored fingerprints with a reference set
+// under random insertions and deletions in a small, crowded table
+func TestQuotientFilterInvariants(t *testing.T) {
+	random := rand.New(rand.NewSource(7))
+	qf := NewQuotientFilterWithBits(6, 4)
+	reference := make(map[uint64]bool)
+
+	for step := 0; step < 20000; step++ {
+		fingerprint := uint64(random.Intn(1 << 10))
+		if random.Intn(3) > 0 && len(reference) < 60 {
+			qf.insert(fingerprint)
+			reference[fingerprint] = true
+		} else {
+			deleted := qf.deleteFingerprint(qf.split(fingerprint))
+			if deleted != reference[fingerprint] {
+				t.Fatalf("Step %d: delete of %d returned %v", step, fingerprint, deleted)
+			}
+			delete(reference, fingerprint)
+		}
+
+		stored := qf.fingerprints()
+		if len(stored) != len(reference) || qf.count != len(reference) {
+			t.Fatalf("Step %d: stored %d fingerprints, count %d, expected %d", step, len(stored), qf.count, len(reference))
+		}
+		expected := make([]uint64, 0, len(reference))
+		for fingerprint := range reference {
+			expected = append(expected, fingerprint)
+		}
+		sort.Slice(expected, func(i, j int) bool { return expected[i] < expected[j] })
+		for i := range expected {
+			if stored[i] != expected[i] {
+				t.Fatalf("Step %d: fingerprint %d is %d, expected %d", step, i, stored[i], expected[i])
+			}
+		}
+	}
+}
+
+// TestQuotientFilterFull tests that Add fails once every slot is used
+func TestQuotientFilterFull(t *testing.T) {
+	qf := NewQuotientFilterWithBits(3, 20)
+
+	added := 0
+	for i := 0; added < 8; i++ {
+		before := qf.Count()
+		if !qf.Add([]byte(fmt.Sprintf("item-%d", i))) {
+			t.Fatalf("Add failed with %d of 8 slots used", before)
+		}
+		added = qf.Count()
+	}
+	if qf.Add([]byte("one-too-many")) {
+		t.Error("Add should fail when the filter is full")
+	}
+}
+
+// TestQuotientFilterResize tests that resizing keeps every element
+func TestQuotientFilterResize(t *testing.T) {
+	qf := NewQuotientFilterWithBits(8, 12)
+	for i := 0; i < 180; i++ {
+		qf.Add([]byte(fmt.Sprintf("item-%d", i)))
+	}
+
+	if err := qf.Resize(); err != nil {
+		t.Fatalf("Resize failed: %v", err)
+	}
+	if qf.Capacity() != 512 || qf.RemainderBits() != 11 {
+		t.Errorf("Expected 512 slots with 11-bit remainders, got %d and %d", qf.Capacity(), qf.RemainderBits())
+	}
+	for i := 0; i < 180; i++ {
+		if !qf.Contains([]byte(fmt.Sprintf("item-%d", i))) {
+			t.Errorf("Filter lost item-%d after resize", i)
+		}
+	}
+
+	small := NewQuotientFilterWithBits(4, 1)
+	if err := small.Resize(); err != ErrCannotResize {
+		t.Errorf("Expected ErrCannotResize, got %v", err)
+	}
+}
+
+// TestQuotientFilterMerge tests merging two filters
+func TestQuotientFilterMerge(t *testing.T) {
+	a := NewQuotientFilterWithBits(8, 12)
+	b := NewQuotientFilterWithBits(7, 13)
+	for i := 0; i < 150; i++ {
+		a.Add([]byte(fmt.Sprintf("a-%d", i)))
+	}
+	for i := 0; i < 80; i++ {
+		b.Add([]byte(fmt.Sprintf("b-%d", i)))
+	}
+
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if a.LoadFactor() > 0.75 {
+		t.Errorf("Merged filter should grow to keep load below 0.75, got %f", a.LoadFactor())
+	}
+	for i := 0; i < 150; i++ {
+		if !a.Contains([]byte(fmt.Sprintf("a-%d", i))) {
+			t.Errorf("Merged filter lost a-%d", i)
+		}
+	}
+	for i := 0; i < 80; i++ {
+		if !a.Contains([]byte(fmt.Sprintf("b-%d", i))) {
+			t.Errorf("Merged filter lost b-%d", i)
+		}
+	}
+
+	if err := a.Merge(NewQuotientFilterWithBits(8, 8)); err != ErrFingerprintMismatch {
+		t.Errorf("Expected ErrFingerprintMismatch, got %v", err)
+	}
+}
+
+// TestMembershipFilters tests every filter through the shared interface
+func TestMembershipFilters(t *testing.T) {
+	filters := map[string]MembershipFilter{
+		"bloom":     NewBloomFilter(500, 0.01),
+		"counting":  NewCountingBloomFilter(500, 0.01),
+		"scalable":  NewScalableBloomFilter(100, 0.01),
+		"deletable": NewDeletableBloomFilter(500, 0.01, 64),
+		"cuckoo":    NewCuckooFilter(500),
+		"quotient":  NewQuotientFilter(500, 0.01),
+	}
+
+	for name, filter := range filters {
+		for i := 0; i < 300; i++ {
+			if !filter.Add([]byte(fmt.Sprintf("item-%d", i))) {
+				t.Fatalf("%s: failed to add item-%d", name, i)
+			}
+		}
+		for i := 0; i < 300; i++ {
+			if !filter.Contains([]byte(fmt.Sprintf("item-%d", i))) {
+				t.Errorf("%s: missing item-%d", name, i)
+			}
+		}
+		filter.Clear()
+		if filter.Contains([]byte("item-1")) {
+			t.Errorf("%s: filter should be empty after Clear", name)
+		}
+	}
+}
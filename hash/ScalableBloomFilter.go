@@ -0,0 +1,150 @@
+package hash
+
+import (
+	"math"
+	"sync"
+)
+
+// Default growth parameters for ScalableBloomFilter (Almeida et al., "Scalable Bloom Filters")
+const (
+	DefaultGrowthFactor    = 2
+	DefaultTighteningRatio = 0.85
+)
+
+// ScalableBloomFilter grows by chaining Bloom filters, each larger and with a tighter
+// error rate than the last, so that the compound false positive rate stays bounded
+// no matter how many elements are added
+type ScalableBloomFilter struct {
+	filters           []*BloomFilter
+	capacities        []int // expected elements of each sub-filter
+	counts            []int // elements added to each sub-filter
+	falsePositiveRate float64
+	growthFactor      int
+	tighteningRatio   float64
+	mutex             sync.RWMutex
+}
+
+// NewScalableBloomFilter creates a scalable Bloom filter with the default growth factor and
+// tightening ratio. The compound false positive rate stays below falsePositiveRate.
+func NewScalableBloomFilter(initialCapacity int, falsePositiveRate float64) *ScalableBloomFilter {
+	return NewScalableBloomFilterWithParams(initialCapacity, falsePositiveRate, DefaultGrowthFactor, DefaultTighteningRatio)
+}
+
+// NewScalableBloomFilterWithParams creates a scalable Bloom filter where every new sub-filter
+// holds growthFactor times more elements and has its error rate multiplied by tighteningRatio
+func NewScalableBloomFilterWithParams(initialCapacity int, falsePositiveRate float64, growthFactor int, tighteningRatio float64) *ScalableBloomFilter {
+	if initialCapacity < 1 {
+		initialCapacity = 1
+	}
+	if growthFactor < 1 {
+		growthFactor = DefaultGrowthFactor
+	}
+	if tighteningRatio <= 0 || tighteningRatio >= 1 {
+		tighteningRatio = DefaultTighteningRatio
+	}
+
+	sbf := &ScalableBloomFilter{
+		filters:           make([]*BloomFilter, 0),
+		capacities:        make([]int, 0),
+		counts:            make([]int, 0),
+		falsePositiveRate: falsePositiveRate,
+		growthFactor:      growthFactor,
+		tighteningRatio:   tighteningRatio,
+		mutex:             sync.RWMutex{},
+	}
+	sbf.addFilter(initialCapacity)
+	return sbf
+}
+
+// addFilter appends a sub-filter. Sub-filter i gets error rate P(1-r)r^i, so the
+// rates form a geometric series that sums to P.
+func (sbf *ScalableBloomFilter) addFilter(capacity int) {
+	i := float64(len(sbf.filters))
+	rate := sbf.falsePositiveRate * (1 - sbf.tighteningRatio) * math.Pow(sbf.tighteningRatio, i)
+
+	sbf.filters = append(sbf.filters, NewBloomFilter(capacity, rate))
+	sbf.capacities = append(sbf.capacities, capacity)
+	sbf.counts = append(sbf.counts, 0)
+}
+
+// Add adds an element, starting a new sub-filter once the current one is full.
+// Elements that already test positive are not added again. It always succeeds and returns true.
+func (sbf *ScalableBloomFilter) Add(data []byte) bool {
+	sbf.mutex.Lock()
+	defer sbf.mutex.Unlock()
+
+	if sbf.contains(data) {
+		return true
+	}
+
+	last := len(sbf.filters) - 1
+	if sbf.counts[last] >= sbf.capacities[last] {
+		sbf.addFilter(sbf.capacities[last] * sbf.growthFactor)
+		last++
+	}
+	sbf.filters[last].Add(data)
+	sbf.counts[last]++
+	return true
+}
+
+// Contains checks if an element might be in the set
+func (sbf *ScalableBloomFilter) Contains(data []byte) bool {
+	sbf.mutex.RLock()
+	defer sbf.mutex.RUnlock()
+
+	return sbf.contains(data)
+}
+
+// contains checks every sub-filter, newest first since recent elements are more likely queried
+func (sbf *ScalableBloomFilter) contains(data []byte) bool {
+	for i := len(sbf.filters) - 1; i >= 0; i-- {
+		if sbf.filters[i].Contains(data) {
+			return true
+		}
+	}
+	return false
+}
+
+// Count returns the number of elements added (elements that tested positive on Add are not counted)
+func (sbf *ScalableBloomFilter) Count() int {
+	sbf.mutex.RLock()
+	defer sbf.mutex.RUnlock()
+
+	total := 0
+	for _, count := range sbf.counts {
+		total += count
+	}
+	return total
+}
+
+// NumFilters returns the number of chained sub-filters
+func (sbf *ScalableBloomFilter) NumFilters() int {
+	sbf.mutex.RLock()
+	defer sbf.mutex.RUnlock()
+	return len(sbf.filters)
+}
+
+// Clear resets the filter to a single empty sub-filter of the initial capacity
+func (sbf *ScalableBloomFilter) Clear() {
+	sbf.mutex.Lock()
+	defer sbf.mutex.Unlock()
+
+	initialCapacity := sbf.capacities[0]
+	sbf.filters = sbf.filters[:0]
+	sbf.capacities = sbf.capacities[:0]
+	sbf.counts = sbf.counts[:0]
+	sbf.addFilter(initialCapacity)
+}
+
+// EstimateFalsePositiveRate estimates the compound false positive rate from the current fill of each sub-filter
+func (sbf *ScalableBloomFilter) EstimateFalsePositiveRate() float64 {
+	sbf.mutex.RLock()
+	defer sbf.mutex.RUnlock()
+
+	// P(false positive) = 1 - product of (1 - p_i)
+	pass := 1.0
+	for i, filter := range sbf.filters {
+		pass *= 1 - filter.EstimateFalsePositiveRate(sbf.counts[i])
+	}
+	return 1 - pass
+}
@@ -0,0 +1,55 @@
+package hash
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestScalableBloomFilterGrowth tests that the filter grows past its initial capacity
+func TestScalableBloomFilterGrowth(t *testing.T) {
+	sbf := NewScalableBloomFilter(100, 0.01)
+
+	for i := 0; i < 5000; i++ {
+		sbf.Add([]byte(fmt.Sprintf("item-%d", i)))
+	}
+
+	if sbf.NumFilters() < 2 {
+		t.Errorf("Expected the filter to chain sub-filters, got %d", sbf.NumFilters())
+	}
+
+	// No false negatives
+	for i := 0; i < 5000; i++ {
+		if !sbf.Contains([]byte(fmt.Sprintf("item-%d", i))) {
+			t.Fatalf("Filter should contain item-%d", i)
+		}
+	}
+
+	// Count excludes elements that already tested positive
+	if count := sbf.Count(); count > 5000 || count < 4900 {
+		t.Errorf("Unexpected count %d", count)
+	}
+
+	if rate := sbf.EstimateFalsePositiveRate(); rate > 0.01 {
+		t.Errorf("Estimated compound false positive rate %v exceeds target 0.01", rate)
+	}
+}
+
+// TestScalableBloomFilterClear tests resetting the filter
+func TestScalableBloomFilterClear(t *testing.T) {
+	sbf := NewScalableBloomFilterWithParams(10, 0.01, 4, 0.5)
+
+	for i := 0; i < 100; i++ {
+		sbf.Add([]byte(fmt.Sprintf("item-%d", i)))
+	}
+	sbf.Clear()
+
+	if sbf.NumFilters() != 1 {
+		t.Errorf("Expected a single sub-filter after clear, got %d", sbf.NumFilters())
+	}
+	if sbf.Count() != 0 {
+		t.Errorf("Expected count 0 after clear, got %d", sbf.Count())
+	}
+	if sbf.Contains([]byte("item-1")) {
+		t.Error("Element should not be present after clear")
+	}
+}
@@ -0,0 +1,162 @@
+package hash
+
+import (
+	"math/bits"
+	"runtime"
+)
+
+// ShardedHashTable splits keys across independently locked HashTable shards (lock
+// striping), so writers to different shards do not contend. The shard is chosen from
+// the high bits of the key's hash while each shard indexes with the low bits.
+type ShardedHashTable[K comparable, V any] struct {
+	shards    []*HashTable[K, V]
+	shardBits uint
+	hasher    Hasher[K]
+}
+
+// NewShardedHashTable creates a table with the given number of shards, rounded up to a
+// power of two. A shard count below 1 uses four shards per CPU. If hasher is nil,
+// keys are hashed with hash/maphash.
+func NewShardedHashTable[K comparable, V any](shards int, strategy Strategy, hasher Hasher[K]) *ShardedHashTable[K, V] {
+	if shards < 1 {
+		shards = 4 * runtime.GOMAXPROCS(0)
+	}
+	shards = nextPowerOfTwo(shards)
+	if hasher == nil {
+		hasher = NewMapHasher[K]()
+	}
+
+	table := &ShardedHashTable[K, V]{
+		shards:    make([]*HashTable[K, V], shards),
+		shardBits: uint(bits.TrailingZeros(uint(shards))),
+		hasher:    hasher,
+	}
+	for i := range table.shards {
+		table.shards[i] = NewTypedHashTable[K, V](defaultCapacity, strategy, hasher)
+	}
+	return table
+}
+
+// shardFor hashes key once and returns the hash with the shard responsible for it
+func (s *ShardedHashTable[K, V]) shardFor(key K) (uint64, *HashTable[K, V]) {
+	hashValue := s.hasher(key)
+	if s.shardBits == 0 {
+		return hashValue, s.shards[0]
+	}
+	return hashValue, s.shards[hashValue>>(64-s.shardBits)]
+}
+
+// Put inserts or updates a key-value pair
+func (s *ShardedHashTable[K, V]) Put(key K, value V) bool {
+	hashValue, shard := s.shardFor(key)
+	shard.mutex.Lock()
+	defer shard.mutex.Unlock()
+
+	return shard.putHashed(hashValue, key, value)
+}
+
+// Get retrieves a value by key
+func (s *ShardedHashTable[K, V]) Get(key K) (V, bool) {
+	hashValue, shard := s.shardFor(key)
+	shard.mutex.RLock()
+	defer shard.mutex.RUnlock()
+
+	return shard.getHashed(hashValue, key)
+}
+
+// Remove removes a key-value pair
+func (s *ShardedHashTable[K, V]) Remove(key K) bool {
+	hashValue, shard := s.shardFor(key)
+	shard.mutex.Lock()
+	defer shard.mutex.Unlock()
+
+	return shard.removeHashed(hashValue, key)
+}
+
+// GetOrPut returns the existing value for key if present. Otherwise it stores value
+// and returns it. The loaded result is true if the value was already present.
+func (s *ShardedHashTable[K, V]) GetOrPut(key K, value V) (V, bool) {
+	hashValue, shard := s.shardFor(key)
+	shard.mutex.Lock()
+	defer shard.mutex.Unlock()
+
+	if existing, ok := shard.getHashed(hashValue, key); ok {
+		return existing, true
+	}
+	shard.putHashed(hashValue, key, value)
+	return value, false
+}
+
+// ComputeIfAbsent returns the value for key, computing and storing it with compute if
+// the key is absent. compute runs at most once per absent key, under the shard's lock,
+// so it must not access the same table.
+func (s *ShardedHashTable[K, V]) ComputeIfAbsent(key K, compute func(key K) V) V {
+	hashValue, shard := s.shardFor(key)
+	shard.mutex.Lock()
+	defer shard.mutex.Unlock()
+
+	if existing, ok := shard.getHashed(hashValue, key); ok {
+		return existing
+	}
+	value := compute(key)
+	shard.putHashed(hashValue, key, value)
+	return value
+}
+
+// CompareAndSwap replaces the value for key with newValue if the current value equals
+// oldValue, and reports whether it did. As with sync.Map, values are compared with ==
+// and CompareAndSwap panics if V is not comparable at runtime.
+func (s *ShardedHashTable[K, V]) CompareAndSwap(key K, oldValue, newValue V) bool {
+	hashValue, shard := s.shardFor(key)
+	shard.mutex.Lock()
+	defer shard.mutex.Unlock()
+
+	current, ok := shard.getHashed(hashValue, key)
+	if !ok || any(current) != any(oldValue) {
+		return false
+	}
+	shard.putHashed(hashValue, key, newValue)
+	return true
+}
+
+// Range calls visit for every key-value pair until visit returns false. Each shard is
+// copied under its read lock before it is visited, so visit sees a consistent snapshot
+// of every shard and may modify the table. Shards are snapshotted one at a time.
+func (s *ShardedHashTable[K, V]) Range(visit func(key K, value V) bool) {
+	type entry struct {
+		key   K
+		value V
+	}
+
+	for _, shard := range s.shards {
+		shard.mutex.RLock()
+		entries := make([]entry, 0, shard.Size)
+		for _, node := range shard.liveNodes() {
+			entries = append(entries, entry{key: node.Key, value: node.Value})
+		}
+		shard.mutex.RUnlock()
+
+		for _, e := range entries {
+			if !visit(e.key, e.value) {
+				return
+			}
+		}
+	}
+}
+
+// Len returns the number of stored entries. Concurrent writers may change the
+// result while it is being summed across shards.
+func (s *ShardedHashTable[K, V]) Len() int {
+	total := 0
+	for _, shard := range s.shards {
+		shard.mutex.RLock()
+		total += shard.Size
+		shard.mutex.RUnlock()
+	}
+	return total
+}
+
+// Shards returns the number of shards
+func (s *ShardedHashTable[K, V]) Shards() int {
+	return len(s.shards)
+}
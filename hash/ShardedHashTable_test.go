@@ -0,0 +1,228 @@
+package hash
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// TestShardedHashTableBasicOperations tests Put, Get and Remove across shards
+func TestShardedHashTableBasicOperations(t *testing.T) {
+	table := NewShardedHashTable[string, int](8, LinearProbing, nil)
+	if table.Shards() != 8 {
+		t.Errorf("Expected 8 shards, got %d", table.Shards())
+	}
+
+	for i := 0; i < 1000; i++ {
+		table.Put("key-"+strconv.Itoa(i), i)
+	}
+	if table.Len() != 1000 {
+		t.Errorf("Expected 1000 entries, got %d", table.Len())
+	}
+	for i := 0; i < 1000; i++ {
+		if value, ok := table.Get("key-" + strconv.Itoa(i)); !ok || value != i {
+			t.Errorf("Expected %d for key-%d, got %d (found: %v)", i, i, value, ok)
+		}
+	}
+	for i := 0; i < 500; i++ {
+		if !table.Remove("key-" + strconv.Itoa(i)) {
+			t.Errorf("Failed to remove key-%d", i)
+		}
+	}
+	if _, ok := table.Get("key-1"); ok {
+		t.Error("key-1 should have been removed")
+	}
+	if table.Len() != 500 {
+		t.Errorf("Expected 500 entries, got %d", table.Len())
+	}
+
+	// Every shard should receive some keys
+	for i, shard := range table.shards {
+		if shard.Size == 0 {
+			t.Errorf("Shard %d received no keys", i)
+		}
+	}
+}
+
+// TestShardedHashTableAtomicOperations tests GetOrPut, ComputeIfAbsent and CompareAndSwap
+func TestShardedHashTableAtomicOperations(t *testing.T) {
+	table := NewShardedHashTable[string, int](4, Chaining, nil)
+
+	if value, loaded := table.GetOrPut("a", 1); loaded || value != 1 {
+		t.Errorf("Expected to store 1, got %d (loaded: %v)", value, loaded)
+	}
+	if value, loaded := table.GetOrPut("a", 2); !loaded || value != 1 {
+		t.Errorf("Expected to load 1, got %d (loaded: %v)", value, loaded)
+	}
+
+	calls := 0
+	compute := func(key string) int {
+		calls++
+		return len(key)
+	}
+	if value := table.ComputeIfAbsent("hello", compute); value != 5 {
+		t.Errorf("Expected computed value 5, got %d", value)
+	}
+	if value := table.ComputeIfAbsent("hello", compute); value != 5 || calls != 1 {
+		t.Errorf("Expected cached value 5 after one call, got %d after %d calls", value, calls)
+	}
+
+	if table.CompareAndSwap("a", 2, 3) {
+		t.Error("CompareAndSwap should fail when the old value differs")
+	}
+	if !table.CompareAndSwap("a", 1, 3) {
+		t.Error("CompareAndSwap should succeed when the old value matches")
+	}
+	if value, _ := table.Get("a"); value != 3 {
+		t.Errorf("Expected 3 after CompareAndSwap, got %d", value)
+	}
+	if table.CompareAndSwap("missing", 0, 1) {
+		t.Error("CompareAndSwap should fail for a missing key")
+	}
+}
+
+// TestShardedHashTableConcurrency tests that atomic operations are linearizable under contention
+func TestShardedHashTableConcurrency(t *testing.T) {
+	table := NewShardedHashTable[int, int](0, RobinHood, nil)
+
+	var computed atomic.Int64
+	var wg sync.WaitGroup
+	for worker := 0; worker < 8; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				table.ComputeIfAbsent(i, func(key int) int {
+					computed.Add(1)
+					return key * 2
+				})
+				// Increment a shared counter with a CAS loop
+				for {
+					current, _ := table.GetOrPut(-1, 0)
+					if table.CompareAndSwap(-1, current, current+1) {
+						break
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if computed.Load() != 1000 {
+		t.Errorf("Expected 1000 computations, got %d", computed.Load())
+	}
+	if counter, _ := table.Get(-1); counter != 8000 {
+		t.Errorf("Expected counter 8000, got %d", counter)
+	}
+	if table.Len() != 1001 {
+		t.Errorf("Expected 1001 entries, got %d", table.Len())
+	}
+}
+
+// TestShardedHashTableRange tests iteration, early exit and modification during Range
+func TestShardedHashTableRange(t *testing.T) {
+	table := NewShardedHashTable[int, int](4, DoubleHashing, nil)
+	for i := 0; i < 100; i++ {
+		table.Put(i, i*i)
+	}
+
+	seen := make(map[int]bool)
+	table.Range(func(key, value int) bool {
+		if value != key*key {
+			t.Errorf("Unexpected value %d for key %d", value, key)
+		}
+		seen[key] = true
+		table.Remove(key) // Modifying the table while ranging must not deadlock
+		return true
+	})
+	if len(seen) != 100 {
+		t.Errorf("Expected to visit 100 keys, visited %d", len(seen))
+	}
+	if table.Len() != 0 {
+		t.Errorf("Expected an empty table, got %d entries", table.Len())
+	}
+
+	table.Put(1, 1)
+	table.Put(2, 2)
+	visits := 0
+	table.Range(func(key, value int) bool {
+		visits++
+		return false
+	})
+	if visits != 1 {
+		t.Errorf("Range should stop after the first visit, visited %d", visits)
+	}
+}
+
+// benchmarkKeys is the key space shared by the concurrent benchmarks
+const benchmarkKeys = 1 << 14
+
+// runMixedBenchmark runs a 90% read / 10% write workload from parallel goroutines
+func runMixedBenchmark(b *testing.B, get func(key int), put func(key, value int)) {
+	for i := 0; i < benchmarkKeys; i++ {
+		put(i, i)
+	}
+	var seed atomic.Uint64
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		x := seed.Add(0x9e3779b97f4a7c15)
+		for pb.Next() {
+			x = mix64(x)
+			key := int(x % benchmarkKeys)
+			if x%10 == 0 {
+				put(key, key)
+			} else {
+				get(key)
+			}
+		}
+	})
+}
+
+func BenchmarkShardedHashTable_Mixed(b *testing.B) {
+	table := NewShardedHashTable[int, int](0, LinearProbing, nil)
+	runMixedBenchmark(b, func(key int) { table.Get(key) }, func(key, value int) { table.Put(key, value) })
+}
+
+func BenchmarkHashTable_Mixed(b *testing.B) {
+	table := NewTypedHashTable[int, int](0, LinearProbing, nil)
+	runMixedBenchmark(b, func(key int) { table.Get(key) }, func(key, value int) { table.Put(key, value) })
+}
+
+func BenchmarkSyncMap_Mixed(b *testing.B) {
+	var table sync.Map
+	runMixedBenchmark(b, func(key int) { table.Load(key) }, func(key, value int) { table.Store(key, value) })
+}
+
+func BenchmarkShardedHashTable_Put(b *testing.B) {
+	table := NewShardedHashTable[int, int](0, LinearProbing, nil)
+	var next atomic.Int64
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			key := int(next.Add(1) % benchmarkKeys)
+			table.Put(key, key)
+		}
+	})
+}
+
+func BenchmarkHashTable_Put(b *testing.B) {
+	table := NewTypedHashTable[int, int](0, LinearProbing, nil)
+	var next atomic.Int64
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			key := int(next.Add(1) % benchmarkKeys)
+			table.Put(key, key)
+		}
+	})
+}
+
+func BenchmarkSyncMap_Put(b *testing.B) {
+	var table sync.Map
+	var next atomic.Int64
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			key := int(next.Add(1) % benchmarkKeys)
+			table.Store(key, key)
+		}
+	})
+}